@@ -0,0 +1,393 @@
+/*
+	import "github.com/thetruetrade/gotrade/candlesticks"
+
+	Package candlesticks recognises candlestick patterns in DOHLCV source data, after the CDL family of TA-Lib.
+	Each pattern produces a result for each source data bar once its lookback period has passed:
+		- 100 when a bullish, or non directional, pattern completes on the bar
+		- -100 when a bearish pattern completes on the bar
+		- 0 when the pattern does not complete on the bar
+
+	Whether a candle's body or shadows are long, short or near another candle is decided by CandleSettings,
+	comparing the candle against a multiple of the average range of the preceding candles, as TA-Lib does.
+
+	Only a subset of TA-Lib's 61 CDL functions is implemented, the patterns listed by the CandlestickPatternType
+	constants, and each of them is checked against the output of its TA-Lib function.
+*/
+package candlesticks
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"math"
+)
+
+// CandleRangeType is the part of a candle measured by a CandleSetting
+type CandleRangeType int
+
+const (
+	// the distance between the open and the close
+	RealBodyRange CandleRangeType = iota
+	// the distance between the high and the low
+	HighLowRange
+	// the sum of the upper and lower shadows
+	ShadowsRange
+)
+
+// A CandleSetting is the threshold against which a part of a candle is compared
+//	- rangeType: the part of the candles which is averaged
+//	- averagePeriod: the number of preceding candles averaged, 0 compares against the candle itself
+//	- factor: the multiple of the average which is the threshold
+type CandleSetting struct {
+	RangeType     CandleRangeType
+	AveragePeriod int
+	Factor        float64
+}
+
+// CandleSettings are the thresholds used to recognise the patterns
+type CandleSettings struct {
+	// a real body is long when longer than the threshold
+	BodyLong CandleSetting
+	// a real body is short when shorter than the threshold
+	BodyShort CandleSetting
+	// a real body is a doji when no longer than the threshold
+	BodyDoji CandleSetting
+	// a shadow is long when longer than the threshold
+	ShadowLong CandleSetting
+	// a shadow is very short when shorter than the threshold
+	ShadowVeryShort CandleSetting
+	// a shadow is very long when longer than the threshold
+	ShadowVeryLong CandleSetting
+	// a price is near another when closer than the threshold
+	Near CandleSetting
+	// a price is far from another when further than the threshold
+	Far CandleSetting
+	// the fraction of the first candle's real body the last candle of a morning or evening star, doji star or
+	// abandoned baby must penetrate
+	StarPenetration float64
+	// the fraction of the first candle's real body the second candle of a dark cloud cover must penetrate
+	DarkCloudPenetration float64
+}
+
+// DefaultCandleSettings returns the TA-Lib default settings
+//	- bodyLong: 1.0 times the average real body of the last 10 candles
+//	- bodyShort: 1.0 times the average real body of the last 10 candles
+//	- bodyDoji: 0.1 times the average high low range of the last 10 candles
+//	- shadowLong: 1.0 times the candle's real body
+//	- shadowVeryShort: 0.1 times the average high low range of the last 10 candles
+//	- shadowVeryLong: 2.0 times the candle's real body
+//	- near: 0.2 times the average high low range of the last 5 candles
+//	- far: 0.6 times the average high low range of the last 5 candles
+//	- starPenetration: 0.3
+//	- darkCloudPenetration: 0.5
+func DefaultCandleSettings() CandleSettings {
+	return CandleSettings{
+		BodyLong:             CandleSetting{RangeType: RealBodyRange, AveragePeriod: 10, Factor: 1.0},
+		BodyShort:            CandleSetting{RangeType: RealBodyRange, AveragePeriod: 10, Factor: 1.0},
+		BodyDoji:             CandleSetting{RangeType: HighLowRange, AveragePeriod: 10, Factor: 0.1},
+		ShadowLong:           CandleSetting{RangeType: RealBodyRange, AveragePeriod: 0, Factor: 1.0},
+		ShadowVeryShort:      CandleSetting{RangeType: HighLowRange, AveragePeriod: 10, Factor: 0.1},
+		ShadowVeryLong:       CandleSetting{RangeType: RealBodyRange, AveragePeriod: 0, Factor: 2.0},
+		Near:                 CandleSetting{RangeType: HighLowRange, AveragePeriod: 5, Factor: 0.2},
+		Far:                  CandleSetting{RangeType: HighLowRange, AveragePeriod: 5, Factor: 0.6},
+		StarPenetration:      0.3,
+		DarkCloudPenetration: 0.5,
+	}
+}
+
+func (s CandleSettings) validate() error {
+	for _, setting := range []CandleSetting{s.BodyLong, s.BodyShort, s.BodyDoji, s.ShadowLong, s.ShadowVeryShort, s.ShadowVeryLong, s.Near, s.Far} {
+		if setting.AveragePeriod < 0 {
+			return errors.New("averagePeriod is less than the minimum (0)")
+		}
+
+		if setting.Factor < 0.0 {
+			return errors.New("factor is less than the minimum (0.0)")
+		}
+	}
+
+	if s.StarPenetration < 0.0 || s.DarkCloudPenetration < 0.0 {
+		return errors.New("penetration is less than the minimum (0.0)")
+	}
+	return nil
+}
+
+// CandlestickPatternType is a candlestick pattern, named after its TA-Lib function
+type CandlestickPatternType int
+
+const (
+	Doji CandlestickPatternType = iota
+	DragonflyDoji
+	GravestoneDoji
+	LongLeggedDoji
+	DojiStar
+	Hammer
+	HangingMan
+	InvertedHammer
+	ShootingStar
+	Engulfing
+	Harami
+	Piercing
+	DarkCloudCover
+	MorningStar
+	EveningStar
+	ThreeWhiteSoldiers
+	ThreeBlackCrows
+	Marubozu
+	SpinningTop
+	MorningDojiStar
+	EveningDojiStar
+	ThreeInside
+	ThreeOutside
+	AbandonedBaby
+	Kicking
+	Takuri
+)
+
+// a patternDefinition describes how a pattern is recognised
+//	- lookback: the number of source data bars before the first result
+//	- recognise: the result for the latest candle
+type patternDefinition struct {
+	name      string
+	lookback  func(s CandleSettings) int
+	recognise func(c *candleWindow, s CandleSettings) int64
+}
+
+var patternDefinitions = []patternDefinition{
+	Doji:               {"CDLDOJI", dojiLookback, recogniseDoji},
+	DragonflyDoji:      {"CDLDRAGONFLYDOJI", dragonflyDojiLookback, recogniseDragonflyDoji},
+	GravestoneDoji:     {"CDLGRAVESTONEDOJI", dragonflyDojiLookback, recogniseGravestoneDoji},
+	LongLeggedDoji:     {"CDLLONGLEGGEDDOJI", longLeggedDojiLookback, recogniseLongLeggedDoji},
+	DojiStar:           {"CDLDOJISTAR", dojiStarLookback, recogniseDojiStar},
+	Hammer:             {"CDLHAMMER", hammerLookback, recogniseHammer},
+	HangingMan:         {"CDLHANGINGMAN", hammerLookback, recogniseHangingMan},
+	InvertedHammer:     {"CDLINVERTEDHAMMER", invertedHammerLookback, recogniseInvertedHammer},
+	ShootingStar:       {"CDLSHOOTINGSTAR", invertedHammerLookback, recogniseShootingStar},
+	Engulfing:          {"CDLENGULFING", engulfingLookback, recogniseEngulfing},
+	Harami:             {"CDLHARAMI", haramiLookback, recogniseHarami},
+	Piercing:           {"CDLPIERCING", piercingLookback, recognisePiercing},
+	DarkCloudCover:     {"CDLDARKCLOUDCOVER", piercingLookback, recogniseDarkCloudCover},
+	MorningStar:        {"CDLMORNINGSTAR", starLookback, recogniseMorningStar},
+	EveningStar:        {"CDLEVENINGSTAR", starLookback, recogniseEveningStar},
+	ThreeWhiteSoldiers: {"CDL3WHITESOLDIERS", threeWhiteSoldiersLookback, recogniseThreeWhiteSoldiers},
+	ThreeBlackCrows:    {"CDL3BLACKCROWS", threeBlackCrowsLookback, recogniseThreeBlackCrows},
+	Marubozu:           {"CDLMARUBOZU", marubozuLookback, recogniseMarubozu},
+	SpinningTop:        {"CDLSPINNINGTOP", spinningTopLookback, recogniseSpinningTop},
+	MorningDojiStar:    {"CDLMORNINGDOJISTAR", dojiStarsLookback, recogniseMorningDojiStar},
+	EveningDojiStar:    {"CDLEVENINGDOJISTAR", dojiStarsLookback, recogniseEveningDojiStar},
+	ThreeInside:        {"CDL3INSIDE", threeInsideLookback, recogniseThreeInside},
+	ThreeOutside:       {"CDL3OUTSIDE", threeOutsideLookback, recogniseThreeOutside},
+	AbandonedBaby:      {"CDLABANDONEDBABY", dojiStarsLookback, recogniseAbandonedBaby},
+	Kicking:            {"CDLKICKING", kickingLookback, recogniseKicking},
+	Takuri:             {"CDLTAKURI", takuriLookback, recogniseTakuri},
+}
+
+// String returns the name of the pattern's TA-Lib function, e.g. CDLDOJI
+func (t CandlestickPatternType) String() string {
+	return patternDefinitions[t].name
+}
+
+// A CandlestickPattern recognises a candlestick pattern in DOHLCV source data, storing a result for each source
+// data bar once its lookback period has passed
+type CandlestickPattern struct {
+	// private variables
+	patternType    CandlestickPatternType
+	settings       CandleSettings
+	lookback       int
+	candles        candleWindow
+	barCount       int
+	validFromBar   int
+	latestBarIndex int
+
+	// public variables
+	Data []int64
+}
+
+// NewCandlestickPattern creates a CandlestickPattern for online usage
+func NewCandlestickPattern(patternType CandlestickPatternType, settings CandleSettings) (pattern *CandlestickPattern, err error) {
+	if patternType < Doji || int(patternType) >= len(patternDefinitions) {
+		return nil, errors.New("patternType is not a candlestick pattern")
+	}
+
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+
+	p := CandlestickPattern{
+		patternType:  patternType,
+		settings:     settings,
+		lookback:     patternDefinitions[patternType].lookback(settings),
+		validFromBar: -1,
+	}
+	return &p, nil
+}
+
+// NewDefaultCandlestickPattern creates a CandlestickPattern for online usage with the default settings
+func NewDefaultCandlestickPattern(patternType CandlestickPatternType) (pattern *CandlestickPattern, err error) {
+	return NewCandlestickPattern(patternType, DefaultCandleSettings())
+}
+
+// NewCandlestickPatternForStream creates a CandlestickPattern for online usage with a source data stream
+func NewCandlestickPatternForStream(priceStream gotrade.DOHLCVStreamSubscriber, patternType CandlestickPatternType, settings CandleSettings) (pattern *CandlestickPattern, err error) {
+	p, err := NewCandlestickPattern(patternType, settings)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(p)
+	return p, nil
+}
+
+// NewDefaultCandlestickPatternForStream creates a CandlestickPattern for online usage with a source data stream
+// and the default settings
+func NewDefaultCandlestickPatternForStream(priceStream gotrade.DOHLCVStreamSubscriber, patternType CandlestickPatternType) (pattern *CandlestickPattern, err error) {
+	return NewCandlestickPatternForStream(priceStream, patternType, DefaultCandleSettings())
+}
+
+func (p *CandlestickPattern) PatternType() CandlestickPatternType {
+	return p.patternType
+}
+
+// ValidFromBar returns the source data bar number from which the pattern has results, starting at bar 1
+func (p *CandlestickPattern) ValidFromBar() int {
+	return p.validFromBar
+}
+
+// GetLookbackPeriod returns the number of source data bars before the first result
+func (p *CandlestickPattern) GetLookbackPeriod() int {
+	return p.lookback
+}
+
+// Length returns the number of results
+func (p *CandlestickPattern) Length() int {
+	return len(p.Data)
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (p *CandlestickPattern) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	p.candles.push(tickData, p.lookback+1)
+	p.barCount++
+	p.latestBarIndex = streamBarIndex
+
+	if p.barCount > p.lookback {
+		if p.validFromBar == -1 {
+			p.validFromBar = streamBarIndex
+		}
+		p.Data = append(p.Data, patternDefinitions[p.patternType].recognise(&p.candles, p.settings))
+	}
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (p *CandlestickPattern) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if p.barCount == 0 || streamBarIndex != p.latestBarIndex {
+		return
+	}
+
+	p.candles.replaceLatest(tickData)
+	if p.barCount > p.lookback {
+		p.Data[len(p.Data)-1] = patternDefinitions[p.patternType].recognise(&p.candles, p.settings)
+	}
+}
+
+// a candleWindow holds the candles needed to recognise a pattern in the latest candle
+type candleWindow struct {
+	candles []gotrade.DOHLCV
+}
+
+func (w *candleWindow) push(candle gotrade.DOHLCV, capacity int) {
+	if len(w.candles) == capacity {
+		copy(w.candles, w.candles[1:])
+		w.candles[len(w.candles)-1] = candle
+		return
+	}
+	w.candles = append(w.candles, candle)
+}
+
+func (w *candleWindow) replaceLatest(candle gotrade.DOHLCV) {
+	w.candles[len(w.candles)-1] = candle
+}
+
+// at returns the candle offset candles before the latest candle, an offset of 0 is the latest candle
+func (w *candleWindow) at(offset int) gotrade.DOHLCV {
+	return w.candles[len(w.candles)-1-offset]
+}
+
+// average returns the threshold of the setting for the candle offset candles before the latest candle,
+// the average of the setting's range over the candles preceding it times the setting's factor
+func (w *candleWindow) average(setting CandleSetting, offset int) float64 {
+	var average float64
+	if setting.AveragePeriod == 0 {
+		average = candleRange(setting.RangeType, w.at(offset))
+	} else {
+		sum := 0.0
+		for i := offset + setting.AveragePeriod; i > offset; i-- {
+			sum += candleRange(setting.RangeType, w.at(i))
+		}
+		average = sum / float64(setting.AveragePeriod)
+	}
+
+	// the shadows range covers two shadows, the threshold is for one of them
+	if setting.RangeType == ShadowsRange {
+		return setting.Factor * average / 2.0
+	}
+	return setting.Factor * average
+}
+
+func candleRange(rangeType CandleRangeType, c gotrade.DOHLCV) float64 {
+	switch rangeType {
+	case RealBodyRange:
+		return realBody(c)
+	case HighLowRange:
+		return c.H() - c.L()
+	}
+	return upperShadow(c) + lowerShadow(c)
+}
+
+func realBody(c gotrade.DOHLCV) float64 {
+	return math.Abs(c.C() - c.O())
+}
+
+func upperShadow(c gotrade.DOHLCV) float64 {
+	return c.H() - math.Max(c.C(), c.O())
+}
+
+func lowerShadow(c gotrade.DOHLCV) float64 {
+	return math.Min(c.C(), c.O()) - c.L()
+}
+
+// color returns 1 for a white candle, which closes at or above its open, and -1 for a black candle
+func color(c gotrade.DOHLCV) int64 {
+	if c.C() >= c.O() {
+		return 1
+	}
+	return -1
+}
+
+// realBodyGapUp returns true when the real body of the candle is entirely above the real body of the previous candle
+func realBodyGapUp(c gotrade.DOHLCV, previous gotrade.DOHLCV) bool {
+	return math.Min(c.O(), c.C()) > math.Max(previous.O(), previous.C())
+}
+
+// realBodyGapDown returns true when the real body of the candle is entirely below the real body of the previous candle
+func realBodyGapDown(c gotrade.DOHLCV, previous gotrade.DOHLCV) bool {
+	return math.Max(c.O(), c.C()) < math.Min(previous.O(), previous.C())
+}
+
+// candleGapUp returns true when the low of the candle is above the high of the previous candle
+func candleGapUp(c gotrade.DOHLCV, previous gotrade.DOHLCV) bool {
+	return c.L() > previous.H()
+}
+
+// candleGapDown returns true when the high of the candle is below the low of the previous candle
+func candleGapDown(c gotrade.DOHLCV, previous gotrade.DOHLCV) bool {
+	return c.H() < previous.L()
+}
+
+// maxAveragePeriod returns the longest average period of the settings
+func maxAveragePeriod(settings ...CandleSetting) int {
+	period := 0
+	for _, setting := range settings {
+		if setting.AveragePeriod > period {
+			period = setting.AveragePeriod
+		}
+	}
+	return period
+}
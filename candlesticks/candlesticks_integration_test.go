@@ -0,0 +1,87 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+// the expected results of each pattern, written by the indicator test generator from its TA-Lib function
+var talibExpectedResults = []struct {
+	patternType candlesticks.CandlestickPatternType
+	fileName    string
+}{
+	{candlesticks.Doji, "cdldoji_expectedresult.data"},
+	{candlesticks.DragonflyDoji, "cdldragonflydoji_expectedresult.data"},
+	{candlesticks.GravestoneDoji, "cdlgravestonedoji_expectedresult.data"},
+	{candlesticks.LongLeggedDoji, "cdllongleggeddoji_expectedresult.data"},
+	{candlesticks.DojiStar, "cdldojistar_expectedresult.data"},
+	{candlesticks.Hammer, "cdlhammer_expectedresult.data"},
+	{candlesticks.HangingMan, "cdlhangingman_expectedresult.data"},
+	{candlesticks.InvertedHammer, "cdlinvertedhammer_expectedresult.data"},
+	{candlesticks.ShootingStar, "cdlshootingstar_expectedresult.data"},
+	{candlesticks.Engulfing, "cdlengulfing_expectedresult.data"},
+	{candlesticks.Harami, "cdlharami_expectedresult.data"},
+	{candlesticks.Piercing, "cdlpiercing_expectedresult.data"},
+	{candlesticks.DarkCloudCover, "cdldarkcloudcover_05_expectedresult.data"},
+	{candlesticks.MorningStar, "cdlmorningstar_03_expectedresult.data"},
+	{candlesticks.EveningStar, "cdleveningstar_03_expectedresult.data"},
+	{candlesticks.ThreeWhiteSoldiers, "cdl3whitesoldiers_expectedresult.data"},
+	{candlesticks.ThreeBlackCrows, "cdl3blackcrows_expectedresult.data"},
+	{candlesticks.Marubozu, "cdlmarubozu_expectedresult.data"},
+	{candlesticks.SpinningTop, "cdlspinningtop_expectedresult.data"},
+	{candlesticks.MorningDojiStar, "cdlmorningdojistar_03_expectedresult.data"},
+	{candlesticks.EveningDojiStar, "cdleveningdojistar_03_expectedresult.data"},
+	{candlesticks.ThreeInside, "cdl3inside_expectedresult.data"},
+	{candlesticks.ThreeOutside, "cdl3outside_expectedresult.data"},
+	{candlesticks.AbandonedBaby, "cdlabandonedbaby_03_expectedresult.data"},
+	{candlesticks.Kicking, "cdlkicking_expectedresult.data"},
+	{candlesticks.Takuri, "cdltakuri_expectedresult.data"},
+}
+
+var _ = Describe("when recognising the candlestick patterns in a synthetic series with known TA-Lib output", func() {
+	for _, expected := range talibExpectedResults {
+		patternType, fileName := expected.patternType, expected.fileName
+
+		Describe("using the "+patternType.String()+" pattern", func() {
+			var (
+				pattern         *candlesticks.CandlestickPattern
+				expectedResults []int64
+				priceStream     *gotrade.InterDayDOHLCVStream
+				err             error
+			)
+
+			BeforeEach(func() {
+				// load the expected results data
+				expectedResults, err = loadExpectedResults(fileName)
+				Expect(err).To(BeNil())
+				priceStream = gotrade.NewDailyDOHLCVStream()
+				pattern, err = candlesticks.NewDefaultCandlestickPatternForStream(priceStream, patternType)
+				Expect(err).To(BeNil())
+				csvFeed.FillDOHLCVStream(priceStream)
+			})
+
+			It("the result set should have a length equal to the source data length less the lookbackperiod", func() {
+				Expect(pattern.Length()).To(Equal(len(priceStream.Data) - pattern.GetLookbackPeriod()))
+				Expect(pattern.Length()).To(Equal(len(expectedResults)))
+			})
+
+			It("TA-Lib should have recognised the pattern in the series", func() {
+				recognised := 0
+				for _, result := range expectedResults {
+					if result != 0 {
+						recognised++
+					}
+				}
+				Expect(recognised).To(BeNumerically(">", 0))
+			})
+
+			It("it should have recognised the pattern on the same bars as TA-Lib", func() {
+				for k := range expectedResults {
+					Expect(pattern.Data[k]).To(Equal(expectedResults[k]), "result %d", k)
+				}
+			})
+		})
+	}
+})
@@ -0,0 +1,89 @@
+package candlesticks_test
+
+import (
+	"encoding/csv"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/candlesticks"
+	"github.com/thetruetrade/gotrade/feeds"
+	"io"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var (
+	csvFeed *feeds.CSVFileFeed
+)
+
+func TestCandlesticks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Candlesticks Suite")
+}
+
+var _ = BeforeSuite(func() {
+	// a synthetic series of random candles with the rarer patterns set into it, on which TA-Lib recognises every pattern
+	csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/SYNTHETIC.candlesticks.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+})
+
+var _ = AfterSuite(func() {
+	csvFeed = nil
+})
+
+// loadExpectedResults loads the results of a TA-Lib CDL function written by the indicator test generator
+func loadExpectedResults(fileName string) (results []int64, err error) {
+	file, err := os.Open("../testdata/" + fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		result, err := strconv.ParseInt(record[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func newCandle(openPrice float64, high float64, low float64, closePrice float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Date(2013, time.January, 1, 0, 0, 0, 0, time.UTC), openPrice, high, low, closePrice, 100.0)
+}
+
+// neutralCandles returns white candles with a real body of 1 and a high low range of 2, which complete none of the
+// patterns, so that with the default settings a long body is longer than 1 and a short body shorter than 1
+func neutralCandles(count int) []gotrade.DOHLCV {
+	candles := make([]gotrade.DOHLCV, count)
+	for i := range candles {
+		candles[i] = newCandle(10.0, 11.5, 9.5, 11.0)
+	}
+	return candles
+}
+
+// recognise creates the pattern with the default settings and passes it the neutral candles then the pattern's candles
+func recognise(patternType candlesticks.CandlestickPatternType, neutralCount int, candles ...gotrade.DOHLCV) *candlesticks.CandlestickPattern {
+	pattern, err := candlesticks.NewDefaultCandlestickPattern(patternType)
+	Expect(err).To(BeNil())
+	for i, candle := range append(neutralCandles(neutralCount), candles...) {
+		pattern.ReceiveDOHLCVTick(candle, i+1)
+	}
+	return pattern
+}
+
+// latestResult returns the result for the last candle
+func latestResult(pattern *candlesticks.CandlestickPattern) int64 {
+	Expect(pattern.Length()).To(BeNumerically(">", 0))
+	return pattern.Data[pattern.Length()-1]
+}
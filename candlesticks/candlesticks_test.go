@@ -0,0 +1,85 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+var _ = Describe("when creating a candlestick pattern", func() {
+	var (
+		settings candlesticks.CandleSettings
+		pattern  *candlesticks.CandlestickPattern
+		err      error
+	)
+
+	BeforeEach(func() {
+		settings = candlesticks.DefaultCandleSettings()
+	})
+
+	It("should reject an average period below the minimum", func() {
+		settings.BodyLong.AveragePeriod = -1
+		pattern, err = candlesticks.NewCandlestickPattern(candlesticks.Harami, settings)
+		Expect(pattern).To(BeNil())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject a factor below the minimum", func() {
+		settings.Near.Factor = -0.2
+		pattern, err = candlesticks.NewCandlestickPattern(candlesticks.Hammer, settings)
+		Expect(pattern).To(BeNil())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an unknown pattern type", func() {
+		pattern, err = candlesticks.NewCandlestickPattern(candlesticks.CandlestickPatternType(-1), settings)
+		Expect(pattern).To(BeNil())
+		Expect(err).To(HaveOccurred())
+
+		pattern, err = candlesticks.NewCandlestickPattern(candlesticks.Takuri+1, settings)
+		Expect(pattern).To(BeNil())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should name the pattern after its TA-Lib function", func() {
+		Expect(candlesticks.ThreeWhiteSoldiers.String()).To(Equal("CDL3WHITESOLDIERS"))
+	})
+
+	It("should take its lookback period from the settings it uses", func() {
+		pattern, _ = candlesticks.NewDefaultCandlestickPattern(candlesticks.MorningStar)
+		Expect(pattern.GetLookbackPeriod()).To(Equal(12))
+
+		settings.BodyLong.AveragePeriod = 20
+		pattern, _ = candlesticks.NewCandlestickPattern(candlesticks.MorningStar, settings)
+		Expect(pattern.GetLookbackPeriod()).To(Equal(22))
+	})
+})
+
+var _ = Describe("when recognising a candlestick pattern in a price stream", func() {
+	var (
+		stream  *gotrade.DOHLCVStream
+		pattern *candlesticks.CandlestickPattern
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDOHLCVStream()
+		stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		pattern, _ = candlesticks.NewDefaultCandlestickPatternForStream(stream, candlesticks.Doji)
+		for _, candle := range neutralCandles(11) {
+			stream.ReceiveTick(candle)
+		}
+	})
+
+	It("should produce a result for each bar after the lookback period", func() {
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(pattern.ValidFromBar()).To(Equal(11))
+		Expect(pattern.Data[0]).To(Equal(int64(0)))
+	})
+
+	It("should revise the latest result when the latest bar is revised", func() {
+		stream.ReceiveTickUpdate(newCandle(10.0, 11.0, 9.0, 10.1))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(pattern.Data[0]).To(Equal(int64(100)))
+	})
+})
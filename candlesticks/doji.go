@@ -0,0 +1,84 @@
+package candlesticks
+
+func dojiLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyDoji)
+}
+
+// recogniseDoji recognises a candle whose open and close are practically equal
+func recogniseDoji(c *candleWindow, s CandleSettings) int64 {
+	if realBody(c.at(0)) <= c.average(s.BodyDoji, 0) {
+		return 100
+	}
+	return 0
+}
+
+func dragonflyDojiLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyDoji, s.ShadowVeryShort)
+}
+
+// recogniseDragonflyDoji recognises a doji with no upper shadow and a lower shadow which is not very short
+func recogniseDragonflyDoji(c *candleWindow, s CandleSettings) int64 {
+	candle := c.at(0)
+	if realBody(candle) <= c.average(s.BodyDoji, 0) &&
+		upperShadow(candle) < c.average(s.ShadowVeryShort, 0) &&
+		lowerShadow(candle) > c.average(s.ShadowVeryShort, 0) {
+		return 100
+	}
+	return 0
+}
+
+// recogniseGravestoneDoji recognises a doji with no lower shadow and an upper shadow which is not very short
+func recogniseGravestoneDoji(c *candleWindow, s CandleSettings) int64 {
+	candle := c.at(0)
+	if realBody(candle) <= c.average(s.BodyDoji, 0) &&
+		lowerShadow(candle) < c.average(s.ShadowVeryShort, 0) &&
+		upperShadow(candle) > c.average(s.ShadowVeryShort, 0) {
+		return 100
+	}
+	return 0
+}
+
+func longLeggedDojiLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyDoji, s.ShadowLong)
+}
+
+// recogniseLongLeggedDoji recognises a doji with a long upper or lower shadow
+func recogniseLongLeggedDoji(c *candleWindow, s CandleSettings) int64 {
+	candle := c.at(0)
+	if realBody(candle) <= c.average(s.BodyDoji, 0) &&
+		(lowerShadow(candle) > c.average(s.ShadowLong, 0) || upperShadow(candle) > c.average(s.ShadowLong, 0)) {
+		return 100
+	}
+	return 0
+}
+
+func dojiStarLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyDoji, s.BodyLong) + 1
+}
+
+// recogniseDojiStar recognises a long candle followed by a doji whose body gaps away from it in its direction,
+// bearish after a white candle and bullish after a black candle
+func recogniseDojiStar(c *candleWindow, s CandleSettings) int64 {
+	first, second := c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 1) &&
+		realBody(second) <= c.average(s.BodyDoji, 0) &&
+		((color(first) == 1 && realBodyGapUp(second, first)) || (color(first) == -1 && realBodyGapDown(second, first))) {
+		return -color(first) * 100
+	}
+	return 0
+}
+
+func takuriLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyDoji, s.ShadowVeryShort, s.ShadowVeryLong)
+}
+
+// recogniseTakuri recognises a dragonfly doji whose lower shadow is very long
+func recogniseTakuri(c *candleWindow, s CandleSettings) int64 {
+	candle := c.at(0)
+	if realBody(candle) <= c.average(s.BodyDoji, 0) &&
+		upperShadow(candle) < c.average(s.ShadowVeryShort, 0) &&
+		lowerShadow(candle) > c.average(s.ShadowVeryLong, 0) {
+		return 100
+	}
+	return 0
+}
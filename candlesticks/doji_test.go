@@ -0,0 +1,42 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+var _ = Describe("when recognising doji patterns", func() {
+	It("should recognise a doji whose real body is within a tenth of the average range", func() {
+		Expect(latestResult(recognise(candlesticks.Doji, 10, newCandle(10.0, 11.0, 9.0, 10.1)))).To(Equal(int64(100)))
+		Expect(latestResult(recognise(candlesticks.Doji, 10, newCandle(10.0, 11.0, 9.0, 10.5)))).To(Equal(int64(0)))
+	})
+
+	It("should not produce a result before the lookback period", func() {
+		Expect(recognise(candlesticks.Doji, 9, newCandle(10.0, 11.0, 9.0, 10.1)).Length()).To(Equal(0))
+	})
+
+	It("should recognise a dragonfly doji by its long lower shadow", func() {
+		Expect(latestResult(recognise(candlesticks.DragonflyDoji, 10, newCandle(11.0, 11.1, 9.0, 11.05)))).To(Equal(int64(100)))
+		Expect(latestResult(recognise(candlesticks.GravestoneDoji, 10, newCandle(11.0, 11.1, 9.0, 11.05)))).To(Equal(int64(0)))
+	})
+
+	It("should recognise a gravestone doji by its long upper shadow", func() {
+		Expect(latestResult(recognise(candlesticks.GravestoneDoji, 10, newCandle(9.0, 11.0, 8.95, 9.05)))).To(Equal(int64(100)))
+	})
+
+	It("should recognise a long legged doji", func() {
+		Expect(latestResult(recognise(candlesticks.LongLeggedDoji, 10, newCandle(10.0, 11.0, 9.0, 10.1)))).To(Equal(int64(100)))
+	})
+
+	It("should recognise a bearish doji star gapping above a long white candle", func() {
+		pattern := recognise(candlesticks.DojiStar, 10, newCandle(10.0, 13.2, 9.9, 13.0), newCandle(13.5, 14.0, 13.3, 13.55))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+
+	It("should recognise a takuri by its very long lower shadow", func() {
+		Expect(latestResult(recognise(candlesticks.Takuri, 10, newCandle(11.0, 11.1, 9.0, 11.05)))).To(Equal(int64(100)))
+		Expect(latestResult(recognise(candlesticks.DragonflyDoji, 10, newCandle(11.0, 11.16, 10.75, 11.15)))).To(Equal(int64(100)))
+		Expect(latestResult(recognise(candlesticks.Takuri, 10, newCandle(11.0, 11.16, 10.75, 11.15)))).To(Equal(int64(0)))
+	})
+})
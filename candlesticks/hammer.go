@@ -0,0 +1,63 @@
+package candlesticks
+
+import (
+	"math"
+)
+
+func hammerLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyShort, s.ShadowLong, s.ShadowVeryShort, s.Near) + 1
+}
+
+// isHammerShape returns true for a small body with a long lower shadow and a very short upper shadow
+func isHammerShape(c *candleWindow, s CandleSettings) bool {
+	candle := c.at(0)
+	return realBody(candle) < c.average(s.BodyShort, 0) &&
+		lowerShadow(candle) > c.average(s.ShadowLong, 0) &&
+		upperShadow(candle) < c.average(s.ShadowVeryShort, 0)
+}
+
+// recogniseHammer recognises a hammer shape whose body is at or near the low of the previous candle
+func recogniseHammer(c *candleWindow, s CandleSettings) int64 {
+	candle, previous := c.at(0), c.at(1)
+	if isHammerShape(c, s) && math.Min(candle.C(), candle.O()) <= previous.L()+c.average(s.Near, 1) {
+		return 100
+	}
+	return 0
+}
+
+// recogniseHangingMan recognises a hammer shape whose body is at or near the high of the previous candle
+func recogniseHangingMan(c *candleWindow, s CandleSettings) int64 {
+	candle, previous := c.at(0), c.at(1)
+	if isHammerShape(c, s) && math.Min(candle.C(), candle.O()) >= previous.H()-c.average(s.Near, 1) {
+		return -100
+	}
+	return 0
+}
+
+func invertedHammerLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyShort, s.ShadowLong, s.ShadowVeryShort) + 1
+}
+
+// isInvertedHammerShape returns true for a small body with a long upper shadow and a very short lower shadow
+func isInvertedHammerShape(c *candleWindow, s CandleSettings) bool {
+	candle := c.at(0)
+	return realBody(candle) < c.average(s.BodyShort, 0) &&
+		upperShadow(candle) > c.average(s.ShadowLong, 0) &&
+		lowerShadow(candle) < c.average(s.ShadowVeryShort, 0)
+}
+
+// recogniseInvertedHammer recognises an inverted hammer shape whose body gaps below the previous body
+func recogniseInvertedHammer(c *candleWindow, s CandleSettings) int64 {
+	if isInvertedHammerShape(c, s) && realBodyGapDown(c.at(0), c.at(1)) {
+		return 100
+	}
+	return 0
+}
+
+// recogniseShootingStar recognises an inverted hammer shape whose body gaps above the previous body
+func recogniseShootingStar(c *candleWindow, s CandleSettings) int64 {
+	if isInvertedHammerShape(c, s) && realBodyGapUp(c.at(0), c.at(1)) {
+		return -100
+	}
+	return 0
+}
@@ -0,0 +1,27 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+var _ = Describe("when recognising hammer patterns", func() {
+	It("should recognise a hammer near the low of the previous candle", func() {
+		Expect(latestResult(recognise(candlesticks.Hammer, 11, newCandle(9.6, 9.85, 8.5, 9.8)))).To(Equal(int64(100)))
+		Expect(latestResult(recognise(candlesticks.HangingMan, 11, newCandle(9.6, 9.85, 8.5, 9.8)))).To(Equal(int64(0)))
+	})
+
+	It("should recognise a hanging man near the high of the previous candle", func() {
+		Expect(latestResult(recognise(candlesticks.HangingMan, 11, newCandle(11.4, 11.65, 10.3, 11.6)))).To(Equal(int64(-100)))
+	})
+
+	It("should recognise a shooting star gapping above the previous real body", func() {
+		Expect(latestResult(recognise(candlesticks.ShootingStar, 11, newCandle(11.2, 12.6, 11.15, 11.4)))).To(Equal(int64(-100)))
+		Expect(latestResult(recognise(candlesticks.InvertedHammer, 11, newCandle(11.2, 12.6, 11.15, 11.4)))).To(Equal(int64(0)))
+	})
+
+	It("should recognise an inverted hammer gapping below the previous real body", func() {
+		Expect(latestResult(recognise(candlesticks.InvertedHammer, 11, newCandle(9.6, 11.0, 9.55, 9.8)))).To(Equal(int64(100)))
+	})
+})
@@ -0,0 +1,51 @@
+package candlesticks
+
+func marubozuLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyLong, s.ShadowVeryShort)
+}
+
+// recogniseMarubozu recognises a long candle with very short shadows, bullish when white and bearish when black
+func recogniseMarubozu(c *candleWindow, s CandleSettings) int64 {
+	if isMarubozuShape(c, s, 0) {
+		return color(c.at(0)) * 100
+	}
+	return 0
+}
+
+func spinningTopLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyShort)
+}
+
+// recogniseSpinningTop recognises a short candle with shadows longer than its real body, signed by its color
+func recogniseSpinningTop(c *candleWindow, s CandleSettings) int64 {
+	candle := c.at(0)
+	if realBody(candle) < c.average(s.BodyShort, 0) &&
+		upperShadow(candle) > realBody(candle) &&
+		lowerShadow(candle) > realBody(candle) {
+		return color(candle) * 100
+	}
+	return 0
+}
+
+func kickingLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.ShadowVeryShort, s.BodyLong) + 1
+}
+
+// isMarubozuShape returns true for a long body with very short shadows
+func isMarubozuShape(c *candleWindow, s CandleSettings, offset int) bool {
+	candle := c.at(offset)
+	return realBody(candle) > c.average(s.BodyLong, offset) &&
+		upperShadow(candle) < c.average(s.ShadowVeryShort, offset) &&
+		lowerShadow(candle) < c.average(s.ShadowVeryShort, offset)
+}
+
+// recogniseKicking recognises a marubozu followed by a marubozu of the opposite color whose whole range gaps away
+// from it, bullish when the second candle is white and bearish when black
+func recogniseKicking(c *candleWindow, s CandleSettings) int64 {
+	first, second := c.at(1), c.at(0)
+	if color(first) == -color(second) && isMarubozuShape(c, s, 1) && isMarubozuShape(c, s, 0) &&
+		((color(first) == -1 && candleGapUp(second, first)) || (color(first) == 1 && candleGapDown(second, first))) {
+		return color(second) * 100
+	}
+	return 0
+}
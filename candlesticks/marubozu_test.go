@@ -0,0 +1,28 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+var _ = Describe("when recognising single candle body patterns", func() {
+	It("should recognise a white marubozu", func() {
+		Expect(latestResult(recognise(candlesticks.Marubozu, 10, newCandle(10.0, 12.05, 9.95, 12.0)))).To(Equal(int64(100)))
+	})
+
+	It("should recognise a black spinning top", func() {
+		Expect(latestResult(recognise(candlesticks.SpinningTop, 10, newCandle(10.5, 11.0, 9.8, 10.3)))).To(Equal(int64(-100)))
+	})
+
+	It("should recognise a bullish kicking pattern", func() {
+		pattern := recognise(candlesticks.Kicking, 10, newCandle(12.0, 12.05, 9.95, 10.0), newCandle(12.5, 14.55, 12.45, 14.5))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should not recognise a kicking pattern without a gap", func() {
+		pattern := recognise(candlesticks.Kicking, 10, newCandle(12.0, 12.05, 9.95, 10.0), newCandle(12.0, 14.05, 11.95, 14.0))
+		Expect(latestResult(pattern)).To(Equal(int64(0)))
+	})
+})
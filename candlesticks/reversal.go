@@ -0,0 +1,103 @@
+package candlesticks
+
+import (
+	"math"
+)
+
+// the TA-Lib lookback of the engulfing pattern is two bars, one more than the pattern needs
+func engulfingLookback(s CandleSettings) int {
+	return 2
+}
+
+// recogniseEngulfing recognises a candle whose real body engulfs the real body of the previous candle of the
+// opposite color, bullish when white and bearish when black
+func recogniseEngulfing(c *candleWindow, s CandleSettings) int64 {
+	first, second := c.at(1), c.at(0)
+	if (color(second) == 1 && color(first) == -1 && second.C() > first.O() && second.O() < first.C()) ||
+		(color(second) == -1 && color(first) == 1 && second.O() > first.C() && second.C() < first.O()) {
+		return color(second) * 100
+	}
+	return 0
+}
+
+func haramiLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyShort, s.BodyLong) + 1
+}
+
+// recogniseHarami recognises a short candle whose real body lies within the real body of the previous long candle,
+// bearish after a white candle and bullish after a black candle
+func recogniseHarami(c *candleWindow, s CandleSettings) int64 {
+	first, second := c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 1) &&
+		realBody(second) <= c.average(s.BodyShort, 0) &&
+		math.Max(second.C(), second.O()) < math.Max(first.C(), first.O()) &&
+		math.Min(second.C(), second.O()) > math.Min(first.C(), first.O()) {
+		return -color(first) * 100
+	}
+	return 0
+}
+
+func piercingLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyLong) + 1
+}
+
+// recognisePiercing recognises a long black candle followed by a long white candle which opens below its low and
+// closes above the middle of its real body
+func recognisePiercing(c *candleWindow, s CandleSettings) int64 {
+	first, second := c.at(1), c.at(0)
+	if color(first) == -1 && realBody(first) > c.average(s.BodyLong, 1) &&
+		color(second) == 1 && realBody(second) > c.average(s.BodyLong, 0) &&
+		second.O() < first.L() && second.C() < first.O() &&
+		second.C() > first.C()+realBody(first)*0.5 {
+		return 100
+	}
+	return 0
+}
+
+// recogniseDarkCloudCover recognises a long white candle followed by a black candle which opens above its high
+// and closes within its real body, penetrating it by at least the dark cloud penetration
+func recogniseDarkCloudCover(c *candleWindow, s CandleSettings) int64 {
+	first, second := c.at(1), c.at(0)
+	if color(first) == 1 && realBody(first) > c.average(s.BodyLong, 1) &&
+		color(second) == -1 && second.O() > first.H() && second.C() > first.O() &&
+		second.C() < first.C()-realBody(first)*s.DarkCloudPenetration {
+		return -100
+	}
+	return 0
+}
+
+func threeInsideLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyShort, s.BodyLong) + 2
+}
+
+// recogniseThreeInside recognises a harami confirmed by a third candle which closes beyond the first candle's open,
+// bearish after a white candle and bullish after a black candle
+func recogniseThreeInside(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 2) &&
+		realBody(second) <= c.average(s.BodyShort, 1) &&
+		math.Max(second.C(), second.O()) < math.Max(first.C(), first.O()) &&
+		math.Min(second.C(), second.O()) > math.Min(first.C(), first.O()) &&
+		((color(first) == 1 && color(third) == -1 && third.C() < first.O()) ||
+			(color(first) == -1 && color(third) == 1 && third.C() > first.O())) {
+		return -color(first) * 100
+	}
+	return 0
+}
+
+func threeOutsideLookback(s CandleSettings) int {
+	return 3
+}
+
+// recogniseThreeOutside recognises an engulfing candle confirmed by a third candle which closes beyond it,
+// bullish when the engulfing candle is white and bearish when black
+func recogniseThreeOutside(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if (color(second) == 1 && color(first) == -1 && second.C() > first.O() && second.O() < first.C() &&
+		third.C() > second.C()) ||
+		(color(second) == -1 && color(first) == 1 && second.O() > first.C() && second.C() < first.O() &&
+			third.C() < second.C()) {
+		return color(second) * 100
+	}
+	return 0
+}
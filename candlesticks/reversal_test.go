@@ -0,0 +1,62 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+var _ = Describe("when recognising two candle reversal patterns", func() {
+	It("should recognise a bullish engulfing candle", func() {
+		pattern := recognise(candlesticks.Engulfing, 1, newCandle(11.0, 11.2, 10.3, 10.5), newCandle(10.4, 11.3, 10.3, 11.2))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should recognise a bearish engulfing candle", func() {
+		pattern := recognise(candlesticks.Engulfing, 1, newCandle(10.5, 11.2, 10.3, 11.0), newCandle(11.1, 11.3, 10.3, 10.4))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+
+	It("should recognise a bullish harami within a long black candle", func() {
+		pattern := recognise(candlesticks.Harami, 10, newCandle(13.0, 13.1, 9.9, 10.0), newCandle(11.0, 11.5, 10.8, 11.3))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should recognise a piercing line", func() {
+		pattern := recognise(candlesticks.Piercing, 10, newCandle(13.0, 13.1, 10.9, 11.0), newCandle(10.5, 12.6, 10.4, 12.5))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should recognise a dark cloud cover", func() {
+		pattern := recognise(candlesticks.DarkCloudCover, 10, newCandle(10.0, 12.1, 9.9, 12.0), newCandle(12.3, 12.4, 10.7, 10.8))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+
+	It("should require the configured dark cloud penetration", func() {
+		settings := candlesticks.DefaultCandleSettings()
+		settings.DarkCloudPenetration = 0.7
+		pattern, _ := candlesticks.NewCandlestickPattern(candlesticks.DarkCloudCover, settings)
+		for i, candle := range append(neutralCandles(10), newCandle(10.0, 12.1, 9.9, 12.0), newCandle(12.3, 12.4, 10.7, 10.8)) {
+			pattern.ReceiveDOHLCVTick(candle, i+1)
+		}
+		Expect(latestResult(pattern)).To(Equal(int64(0)))
+	})
+
+	It("should recognise a three inside up confirming a bullish harami", func() {
+		pattern := recognise(candlesticks.ThreeInside, 10, newCandle(13.0, 13.1, 9.9, 10.0), newCandle(11.0, 11.5, 10.8, 11.3), newCandle(11.3, 13.3, 11.2, 13.2))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should not recognise a three inside whose last candle does not close beyond the first candle's open", func() {
+		pattern := recognise(candlesticks.ThreeInside, 10, newCandle(13.0, 13.1, 9.9, 10.0), newCandle(11.0, 11.5, 10.8, 11.3), newCandle(11.3, 12.9, 11.2, 12.8))
+		Expect(latestResult(pattern)).To(Equal(int64(0)))
+	})
+
+	It("should recognise a three outside down confirming a bearish engulfing candle", func() {
+		pattern := recognise(candlesticks.ThreeOutside, 1, newCandle(10.5, 11.2, 10.3, 11.0), newCandle(11.1, 11.3, 10.3, 10.4), newCandle(10.4, 10.5, 10.0, 10.1))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+})
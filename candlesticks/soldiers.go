@@ -0,0 +1,43 @@
+package candlesticks
+
+func threeWhiteSoldiersLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.ShadowVeryShort, s.BodyShort, s.Far, s.Near) + 2
+}
+
+// recogniseThreeWhiteSoldiers recognises three white candles with very short upper shadows and rising closes,
+// each opening within or near the previous real body and not much shorter than it
+func recogniseThreeWhiteSoldiers(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if color(first) == 1 && upperShadow(first) < c.average(s.ShadowVeryShort, 2) &&
+		color(second) == 1 && upperShadow(second) < c.average(s.ShadowVeryShort, 1) &&
+		color(third) == 1 && upperShadow(third) < c.average(s.ShadowVeryShort, 0) &&
+		third.C() > second.C() && second.C() > first.C() &&
+		second.O() > first.O() && second.O() <= first.C()+c.average(s.Near, 2) &&
+		third.O() > second.O() && third.O() <= second.C()+c.average(s.Near, 1) &&
+		realBody(second) > realBody(first)-c.average(s.Far, 2) &&
+		realBody(third) > realBody(second)-c.average(s.Far, 1) &&
+		realBody(third) > c.average(s.BodyShort, 0) {
+		return 100
+	}
+	return 0
+}
+
+func threeBlackCrowsLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.ShadowVeryShort) + 3
+}
+
+// recogniseThreeBlackCrows recognises a white candle followed by three black candles with very short lower shadows
+// and falling closes, each opening within the previous real body
+func recogniseThreeBlackCrows(c *candleWindow, s CandleSettings) int64 {
+	white, first, second, third := c.at(3), c.at(2), c.at(1), c.at(0)
+	if color(white) == 1 &&
+		color(first) == -1 && lowerShadow(first) < c.average(s.ShadowVeryShort, 2) &&
+		color(second) == -1 && lowerShadow(second) < c.average(s.ShadowVeryShort, 1) &&
+		color(third) == -1 && lowerShadow(third) < c.average(s.ShadowVeryShort, 0) &&
+		second.O() < first.O() && second.O() > first.C() &&
+		third.O() < second.O() && third.O() > second.C() &&
+		white.H() > first.C() && first.C() > second.C() && second.C() > third.C() {
+		return -100
+	}
+	return 0
+}
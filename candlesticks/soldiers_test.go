@@ -0,0 +1,21 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+var _ = Describe("when recognising three candle advance and decline patterns", func() {
+	It("should recognise three white soldiers", func() {
+		pattern := recognise(candlesticks.ThreeWhiteSoldiers, 10, newCandle(10.0, 11.05, 9.9, 11.0), newCandle(10.8, 12.05, 10.7, 12.0), newCandle(11.8, 13.05, 11.7, 13.0))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should recognise three black crows after a white candle", func() {
+		pattern := recognise(candlesticks.ThreeBlackCrows, 11, newCandle(11.0, 11.05, 9.95, 10.0), newCandle(10.5, 10.55, 9.45, 9.5), newCandle(10.0, 10.05, 8.95, 9.0))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+})
@@ -0,0 +1,76 @@
+package candlesticks
+
+func starLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyShort, s.BodyLong) + 2
+}
+
+// recogniseMorningStar recognises a long black candle, a short candle whose body gaps below it, then a white
+// candle which closes well into the first candle's real body
+func recogniseMorningStar(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 2) && color(first) == -1 &&
+		realBody(second) <= c.average(s.BodyShort, 1) && realBodyGapDown(second, first) &&
+		realBody(third) > c.average(s.BodyShort, 0) && color(third) == 1 &&
+		third.C() > first.C()+realBody(first)*s.StarPenetration {
+		return 100
+	}
+	return 0
+}
+
+// recogniseEveningStar recognises a long white candle, a short candle whose body gaps above it, then a black
+// candle which closes well into the first candle's real body
+func recogniseEveningStar(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 2) && color(first) == 1 &&
+		realBody(second) <= c.average(s.BodyShort, 1) && realBodyGapUp(second, first) &&
+		realBody(third) > c.average(s.BodyShort, 0) && color(third) == -1 &&
+		third.C() < first.C()-realBody(first)*s.StarPenetration {
+		return -100
+	}
+	return 0
+}
+
+func dojiStarsLookback(s CandleSettings) int {
+	return maxAveragePeriod(s.BodyDoji, s.BodyLong, s.BodyShort) + 2
+}
+
+// recogniseMorningDojiStar recognises a morning star whose middle candle is a doji
+func recogniseMorningDojiStar(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 2) && color(first) == -1 &&
+		realBody(second) <= c.average(s.BodyDoji, 1) && realBodyGapDown(second, first) &&
+		realBody(third) > c.average(s.BodyShort, 0) && color(third) == 1 &&
+		third.C() > first.C()+realBody(first)*s.StarPenetration {
+		return 100
+	}
+	return 0
+}
+
+// recogniseEveningDojiStar recognises an evening star whose middle candle is a doji
+func recogniseEveningDojiStar(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 2) && color(first) == 1 &&
+		realBody(second) <= c.average(s.BodyDoji, 1) && realBodyGapUp(second, first) &&
+		realBody(third) > c.average(s.BodyShort, 0) && color(third) == -1 &&
+		third.C() < first.C()-realBody(first)*s.StarPenetration {
+		return -100
+	}
+	return 0
+}
+
+// recogniseAbandonedBaby recognises a long candle, a doji whose whole range gaps away from it, then a candle of
+// the opposite color whose whole range gaps back and which closes well into the first candle's real body,
+// bearish after a white candle and bullish after a black candle
+func recogniseAbandonedBaby(c *candleWindow, s CandleSettings) int64 {
+	first, second, third := c.at(2), c.at(1), c.at(0)
+	if realBody(first) > c.average(s.BodyLong, 2) &&
+		realBody(second) <= c.average(s.BodyDoji, 1) &&
+		realBody(third) > c.average(s.BodyShort, 0) &&
+		((color(first) == 1 && color(third) == -1 && third.C() < first.C()-realBody(first)*s.StarPenetration &&
+			candleGapUp(second, first) && candleGapDown(third, second)) ||
+			(color(first) == -1 && color(third) == 1 && third.C() > first.C()+realBody(first)*s.StarPenetration &&
+				candleGapDown(second, first) && candleGapUp(third, second))) {
+		return color(third) * 100
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package candlesticks_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/candlesticks"
+)
+
+var _ = Describe("when recognising star patterns", func() {
+	It("should recognise a morning star", func() {
+		pattern := recognise(candlesticks.MorningStar, 10, newCandle(13.0, 13.1, 9.9, 10.0), newCandle(9.6, 9.8, 9.5, 9.7), newCandle(9.9, 12.1, 9.8, 12.0))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should recognise an evening star", func() {
+		pattern := recognise(candlesticks.EveningStar, 10, newCandle(10.0, 13.1, 9.9, 13.0), newCandle(13.3, 13.5, 13.2, 13.4), newCandle(13.1, 13.2, 10.9, 11.0))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+
+	It("should not recognise a star whose last candle does not penetrate the first", func() {
+		pattern := recognise(candlesticks.MorningStar, 10, newCandle(13.0, 13.1, 9.9, 10.0), newCandle(9.6, 9.8, 9.5, 9.7), newCandle(9.5, 10.8, 9.4, 10.8))
+		Expect(latestResult(pattern)).To(Equal(int64(0)))
+	})
+
+	It("should recognise a morning doji star", func() {
+		pattern := recognise(candlesticks.MorningDojiStar, 10, newCandle(13.0, 13.1, 9.9, 10.0), newCandle(9.6, 9.8, 9.5, 9.65), newCandle(9.9, 12.1, 9.8, 12.0))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(100)))
+	})
+
+	It("should recognise an evening doji star", func() {
+		pattern := recognise(candlesticks.EveningDojiStar, 10, newCandle(10.0, 13.1, 9.9, 13.0), newCandle(13.3, 13.5, 13.2, 13.35), newCandle(13.1, 13.2, 10.9, 11.0))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+
+	It("should not recognise a doji star whose middle candle is not a doji", func() {
+		pattern := recognise(candlesticks.EveningDojiStar, 10, newCandle(10.0, 13.1, 9.9, 13.0), newCandle(13.3, 13.8, 13.2, 13.7), newCandle(13.1, 13.2, 10.9, 11.0))
+		Expect(latestResult(pattern)).To(Equal(int64(0)))
+	})
+
+	It("should recognise a bearish abandoned baby", func() {
+		pattern := recognise(candlesticks.AbandonedBaby, 10, newCandle(10.0, 13.1, 9.9, 13.0), newCandle(13.4, 13.5, 13.3, 13.42), newCandle(13.2, 13.25, 10.9, 11.0))
+		Expect(pattern.Length()).To(Equal(1))
+		Expect(latestResult(pattern)).To(Equal(int64(-100)))
+	})
+
+	It("should not recognise an abandoned baby whose doji's range overlaps the last candle", func() {
+		pattern := recognise(candlesticks.AbandonedBaby, 10, newCandle(10.0, 13.1, 9.9, 13.0), newCandle(13.4, 13.5, 13.3, 13.42), newCandle(13.2, 13.35, 10.9, 11.0))
+		Expect(latestResult(pattern)).To(Equal(int64(0)))
+	})
+})
@@ -0,0 +1,357 @@
+package chartpatterns
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"sync"
+	"time"
+)
+
+// ChartPatternType is a geometric pattern formed by the swing points of the price
+type ChartPatternType int
+
+const (
+	// a high shoulder, a higher head and a high shoulder at about the same level as the first
+	HeadAndShoulders ChartPatternType = iota
+	// a low shoulder, a lower head and a low shoulder at about the same level as the first
+	InverseHeadAndShoulders
+	// flat highs and rising lows
+	AscendingTriangle
+	// falling highs and flat lows
+	DescendingTriangle
+	// falling highs and rising lows
+	SymmetricalTriangle
+	// rising highs and lows, the lows rising faster
+	RisingWedge
+	// falling highs and lows, the highs falling faster
+	FallingWedge
+	// flat highs and flat lows
+	Rectangle
+	// a sharp rise then a small consolidation of flat or falling parallel highs and lows
+	BullFlag
+	// a sharp fall then a small consolidation of flat or rising parallel highs and lows
+	BearFlag
+	// a sharp rise then a small consolidation of falling highs and rising lows
+	BullPennant
+	// a sharp fall then a small consolidation of falling highs and rising lows
+	BearPennant
+)
+
+var chartPatternNames = []string{"Head and Shoulders",
+	"Inverse Head and Shoulders",
+	"Ascending Triangle",
+	"Descending Triangle",
+	"Symmetrical Triangle",
+	"Rising Wedge",
+	"Falling Wedge",
+	"Rectangle",
+	"Bull Flag",
+	"Bear Flag",
+	"Bull Pennant",
+	"Bear Pennant"}
+
+func (t ChartPatternType) String() string {
+	return chartPatternNames[t]
+}
+
+// ChartPatternState is the progress of a chart pattern
+type ChartPatternState int
+
+const (
+	// the pattern has formed and the price has not yet broken out
+	ChartPatternForming ChartPatternState = iota
+	// the price has broken out of the pattern, a head and shoulders only completes through its neckline
+	ChartPatternCompleted
+	// the price has broken a head and shoulders the wrong way, beyond its head
+	ChartPatternFailed
+	// a later swing point ended the pattern before the price broke out
+	ChartPatternExpired
+)
+
+// A TrendLine is a straight line between two prices on a price stream
+type TrendLine struct {
+	StartPrice    float64
+	StartBarIndex int
+	EndPrice      float64
+	EndBarIndex   int
+}
+
+func newTrendLine(start SwingPoint, end SwingPoint) TrendLine {
+	return TrendLine{StartPrice: start.Price, StartBarIndex: start.StreamBarIndex, EndPrice: end.Price, EndBarIndex: end.StreamBarIndex}
+}
+
+// PriceAt returns the price of the line, extended if need be, at the stream bar index
+func (l TrendLine) PriceAt(streamBarIndex int) float64 {
+	if l.EndBarIndex == l.StartBarIndex {
+		return l.StartPrice
+	}
+	slope := (l.EndPrice - l.StartPrice) / float64(l.EndBarIndex-l.StartBarIndex)
+	return l.StartPrice + slope*float64(streamBarIndex-l.StartBarIndex)
+}
+
+// slope returns the change in price of the line per bar
+func (l TrendLine) slope() float64 {
+	if l.EndBarIndex == l.StartBarIndex {
+		return 0.0
+	}
+	return (l.EndPrice - l.StartPrice) / float64(l.EndBarIndex-l.StartBarIndex)
+}
+
+// A ChartPattern is a pattern formed by the latest swing points of the price
+//	- points: the swing points forming the pattern, for a flag or pennant the first point is the start of the pole
+//	- upperLine, lowerLine: the lines the price breaks out through, for a head and shoulders the lower line is the
+//	  neckline and the upper line is level with the head, for an inverse head and shoulders the reverse
+//	- breakoutRising: true when the price broke out upwards
+//	- breakoutLevel: the level of the line broken at the breakout bar
+type ChartPattern struct {
+	PatternType      ChartPatternType
+	State            ChartPatternState
+	Points           []SwingPoint
+	UpperLine        TrendLine
+	LowerLine        TrendLine
+	BreakoutRising   bool
+	BreakoutLevel    float64
+	BreakoutPrice    float64
+	BreakoutDate     time.Time
+	BreakoutBarIndex int
+}
+
+// Neckline returns the neckline of a head and shoulders or inverse head and shoulders
+func (p ChartPattern) Neckline() (neckline TrendLine, ok bool) {
+	switch p.PatternType {
+	case HeadAndShoulders:
+		return p.LowerLine, true
+	case InverseHeadAndShoulders:
+		return p.UpperLine, true
+	}
+	return TrendLine{}, false
+}
+
+// A ChartPatternEvent describes a change of state of a chart pattern, the pattern is as of the event
+type ChartPatternEvent struct {
+	Pattern      ChartPattern
+	PatternIndex int
+}
+
+type ChartPatternEventReceiver interface {
+	ReceiveChartPatternEvent(event ChartPatternEvent)
+}
+
+// A ChartPatternDetector detects the chart patterns formed by the swing points of a source data stream, publishing
+// each pattern when it forms and again when the close breaks out of it. Each new swing point ends the forming
+// pattern, if any, and the latest swing points are matched against the patterns, flags and pennants first, then
+// head and shoulders, then the triangles, wedges and rectangles. Prices within the tolerance percentage of each
+// other are treated as level, e.g. the shoulders of a head and shoulders or the highs of a rectangle.
+type ChartPatternDetector struct {
+	// private variables
+	swings              *SwingPointDetector
+	tolerancePercentage float64
+	patterns            []ChartPattern
+	forming             int
+	subscribers         []ChartPatternEventReceiver
+	subscribersMutex    sync.Mutex
+	// the state prior to the latest bar, restored when the bar is revised
+	stateBarIndex       int
+	savedPatternCount   int
+	savedForming        int
+	savedFormingPattern ChartPattern
+}
+
+// NewChartPatternDetector creates a chart pattern detector
+//	- reversalPercentage: the reversal percentage which confirms a swing point, e.g. 5.0 for 5%
+//	- tolerancePercentage: the percentage within which prices are level, e.g. 1.0 for 1%
+func NewChartPatternDetector(reversalPercentage float64, tolerancePercentage float64) (detector *ChartPatternDetector, err error) {
+	if tolerancePercentage < 0.0 {
+		return nil, errors.New("tolerancePercentage is less than the minimum (0.0)")
+	}
+
+	swings, err := NewSwingPointDetector(reversalPercentage)
+	if err != nil {
+		return nil, err
+	}
+
+	d := ChartPatternDetector{swings: swings, tolerancePercentage: tolerancePercentage, forming: -1, savedForming: -1}
+	swings.AddSwingPointSubscription(&d)
+	return &d, nil
+}
+
+// NewChartPatternDetectorForStream creates a chart pattern detector which subscribes to the source data stream
+func NewChartPatternDetectorForStream(sourceStream gotrade.DOHLCVStreamSubscriber, reversalPercentage float64, tolerancePercentage float64) (detector *ChartPatternDetector, err error) {
+	d, err := NewChartPatternDetector(reversalPercentage, tolerancePercentage)
+	if err != nil {
+		return nil, err
+	}
+	sourceStream.AddTickSubscription(d)
+	return d, nil
+}
+
+// SwingPointDetector returns the detector of the swing points the patterns are formed from
+func (d *ChartPatternDetector) SwingPointDetector() *SwingPointDetector {
+	return d.swings
+}
+
+// Patterns returns a copy of the patterns detected, the latest pattern is last
+func (d *ChartPatternDetector) Patterns() []ChartPattern {
+	patterns := make([]ChartPattern, len(d.patterns))
+	copy(patterns, d.patterns)
+	return patterns
+}
+
+// FormingPattern returns the pattern which has formed and not yet been broken out of, if any
+func (d *ChartPatternDetector) FormingPattern() (pattern ChartPattern, ok bool) {
+	if d.forming == -1 {
+		return ChartPattern{}, false
+	}
+	return d.patterns[d.forming], true
+}
+
+func (d *ChartPatternDetector) AddEventSubscription(subscriber ChartPatternEventReceiver) {
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+	d.subscribers = append(d.subscribers, subscriber)
+}
+
+func (d *ChartPatternDetector) RemoveEventSubscription(subscriber ChartPatternEventReceiver) {
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+	subscribers := make([]ChartPatternEventReceiver, 0, len(d.subscribers))
+	for _, existing := range d.subscribers {
+		if existing != subscriber {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	d.subscribers = subscribers
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, detecting whether its close breaks out of the forming
+// pattern then the swing points it confirms. The breakout is detected first as a close beyond the head of a head and
+// shoulders always confirms the next swing point, which would otherwise expire the pattern before it failed.
+func (d *ChartPatternDetector) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	d.saveState(streamBarIndex)
+	d.receiveBreakout(tickData, streamBarIndex)
+	d.swings.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, undoing the breakout,
+// expiry or pattern of the bar prior to its revision. The events of the revised bar are published again, they
+// replace the events previously published for the bar.
+func (d *ChartPatternDetector) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if d.prepareRevision(streamBarIndex) {
+		d.receiveBreakout(tickData, streamBarIndex)
+		d.swings.ReceiveDOHLCVTickUpdate(tickData, streamBarIndex)
+	}
+}
+
+// prepareRevision returns the detector to its state prior to the source data bar when it is the latest bar received.
+// Returns false when the bar precedes the latest bar and can no longer be revised.
+func (d *ChartPatternDetector) prepareRevision(streamBarIndex int) bool {
+	if streamBarIndex < d.stateBarIndex {
+		return false
+	}
+
+	if streamBarIndex == d.stateBarIndex {
+		d.restoreState()
+	}
+	return true
+}
+
+// saveState records the state of the detector prior to the source data bar, a bar only appends patterns and changes
+// the state of the forming pattern
+func (d *ChartPatternDetector) saveState(streamBarIndex int) {
+	if d.stateBarIndex == streamBarIndex {
+		return
+	}
+	d.stateBarIndex = streamBarIndex
+	d.savedPatternCount = len(d.patterns)
+	d.savedForming = d.forming
+	if d.forming != -1 {
+		d.savedFormingPattern = d.patterns[d.forming]
+	}
+}
+
+// restoreState returns the detector to the state recorded by saveState
+func (d *ChartPatternDetector) restoreState() {
+	d.patterns = d.patterns[:d.savedPatternCount]
+	d.forming = d.savedForming
+	if d.forming != -1 {
+		d.patterns[d.forming] = d.savedFormingPattern
+	}
+}
+
+// receiveBreakout completes or fails the forming pattern when the close breaks out of it
+func (d *ChartPatternDetector) receiveBreakout(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if d.forming == -1 {
+		return
+	}
+
+	pattern := &d.patterns[d.forming]
+	upper := pattern.UpperLine.PriceAt(streamBarIndex)
+	lower := pattern.LowerLine.PriceAt(streamBarIndex)
+	closePrice := tickData.C()
+
+	switch pattern.PatternType {
+	case HeadAndShoulders:
+		if closePrice < lower {
+			d.breakOut(ChartPatternCompleted, false, lower, tickData, streamBarIndex)
+		} else if closePrice > upper {
+			d.breakOut(ChartPatternFailed, true, upper, tickData, streamBarIndex)
+		}
+	case InverseHeadAndShoulders:
+		if closePrice > upper {
+			d.breakOut(ChartPatternCompleted, true, upper, tickData, streamBarIndex)
+		} else if closePrice < lower {
+			d.breakOut(ChartPatternFailed, false, lower, tickData, streamBarIndex)
+		}
+	default:
+		if closePrice > upper {
+			d.breakOut(ChartPatternCompleted, true, upper, tickData, streamBarIndex)
+		} else if closePrice < lower {
+			d.breakOut(ChartPatternCompleted, false, lower, tickData, streamBarIndex)
+		}
+	}
+}
+
+// ReceiveSwingPoint consumes a confirmed swing point, ending the forming pattern and matching the latest swing
+// points against the patterns
+func (d *ChartPatternDetector) ReceiveSwingPoint(point SwingPoint) {
+	if d.forming != -1 {
+		d.patterns[d.forming].State = ChartPatternExpired
+		d.publish(d.forming)
+		d.forming = -1
+	}
+
+	points := d.swings.points
+	pattern, ok := d.recognise(points)
+	if !ok {
+		return
+	}
+
+	d.patterns = append(d.patterns, pattern)
+	d.forming = len(d.patterns) - 1
+	d.publish(d.forming)
+}
+
+func (d *ChartPatternDetector) breakOut(state ChartPatternState, rising bool, level float64, tickData gotrade.DOHLCV, streamBarIndex int) {
+	pattern := &d.patterns[d.forming]
+	pattern.State = state
+	pattern.BreakoutRising = rising
+	pattern.BreakoutLevel = level
+	pattern.BreakoutPrice = tickData.C()
+	pattern.BreakoutDate = tickData.D()
+	pattern.BreakoutBarIndex = streamBarIndex
+
+	index := d.forming
+	d.forming = -1
+	d.publish(index)
+}
+
+func (d *ChartPatternDetector) publish(index int) {
+	d.subscribersMutex.Lock()
+	subscribers := d.subscribers
+	d.subscribersMutex.Unlock()
+
+	event := ChartPatternEvent{Pattern: d.patterns[index], PatternIndex: index}
+	for _, subscriber := range subscribers {
+		subscriber.ReceiveChartPatternEvent(event)
+	}
+}
@@ -0,0 +1,19 @@
+package chartpatterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"testing"
+	"time"
+)
+
+func TestChartPatterns(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Chart Patterns Suite")
+}
+
+// newPriceBar returns a bar on Jan <day> 2013 whose open, high, low and close are all the price
+func newPriceBar(day int, price float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Date(2013, time.January, day, 0, 0, 0, 0, time.UTC), price, price, price, price, 100.0)
+}
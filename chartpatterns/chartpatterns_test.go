@@ -0,0 +1,326 @@
+package chartpatterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/chartpatterns"
+)
+
+type chartPatternRecorder struct {
+	events []chartpatterns.ChartPatternEvent
+}
+
+func (r *chartPatternRecorder) ReceiveChartPatternEvent(event chartpatterns.ChartPatternEvent) {
+	r.events = append(r.events, event)
+}
+
+var _ = Describe("when detecting chart patterns", func() {
+	var (
+		stream   *gotrade.DOHLCVStream
+		detector *chartpatterns.ChartPatternDetector
+		recorder *chartPatternRecorder
+		err      error
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDOHLCVStream()
+		stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		// swings reverse 5% and prices within 2% are level
+		detector, err = chartpatterns.NewChartPatternDetectorForStream(stream, 5.0, 2.0)
+		recorder = &chartPatternRecorder{}
+		detector.AddEventSubscription(recorder)
+	})
+
+	receivePrices := func(prices ...float64) {
+		for day, price := range prices {
+			stream.ReceiveTick(newPriceBar(day+1, price))
+		}
+	}
+
+	It("should reject a tolerance below the minimum", func() {
+		detector, err = chartpatterns.NewChartPatternDetector(5.0, -1.0)
+		Expect(detector).To(BeNil())
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("and the swings form a head and shoulders which breaks its neckline", func() {
+		BeforeEach(func() {
+			receivePrices(100.0, 110.0, 120.0, 112.0, 110.0, 120.0, 130.0, 120.0, 110.0, 116.0, 121.0, 114.0, 109.0)
+		})
+
+		It("should report the pattern as forming once the right shoulder is confirmed", func() {
+			Expect(err).To(BeNil())
+			Expect(len(recorder.events)).To(Equal(2))
+			Expect(recorder.events[0].Pattern.PatternType).To(Equal(chartpatterns.HeadAndShoulders))
+			Expect(recorder.events[0].Pattern.State).To(Equal(chartpatterns.ChartPatternForming))
+			Expect(len(recorder.events[0].Pattern.Points)).To(Equal(5))
+		})
+
+		It("should report the neckline", func() {
+			neckline, ok := recorder.events[0].Pattern.Neckline()
+			Expect(ok).To(BeTrue())
+			Expect(neckline.PriceAt(13)).To(Equal(110.0))
+		})
+
+		It("should complete the pattern when the close breaks the neckline", func() {
+			pattern := recorder.events[1].Pattern
+			Expect(pattern.State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(pattern.BreakoutRising).To(BeFalse())
+			Expect(pattern.BreakoutLevel).To(Equal(110.0))
+			Expect(pattern.BreakoutPrice).To(Equal(109.0))
+			Expect(pattern.BreakoutBarIndex).To(Equal(13))
+			Expect(detector.Patterns()[0]).To(Equal(pattern))
+
+			_, forming := detector.FormingPattern()
+			Expect(forming).To(BeFalse())
+		})
+	})
+
+	Context("and the swings form a rectangle", func() {
+		BeforeEach(func() {
+			receivePrices(100.0, 110.0, 104.0, 100.0, 106.0, 110.0, 104.0, 100.0, 106.0)
+		})
+
+		It("should report the pattern as forming between its highs and lows", func() {
+			pattern, ok := detector.FormingPattern()
+			Expect(ok).To(BeTrue())
+			Expect(pattern.PatternType).To(Equal(chartpatterns.Rectangle))
+			Expect(pattern.UpperLine.PriceAt(10)).To(Equal(110.0))
+			Expect(pattern.LowerLine.PriceAt(10)).To(Equal(100.0))
+		})
+
+		It("should complete the pattern when the close breaks out in either direction", func() {
+			stream.ReceiveTick(newPriceBar(10, 111.0))
+			patterns := detector.Patterns()
+			Expect(patterns[len(patterns)-1].State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(patterns[len(patterns)-1].BreakoutRising).To(BeTrue())
+		})
+
+		It("should expire the pattern when a later swing point ends it", func() {
+			stream.ReceiveTick(newPriceBar(10, 109.0))
+			stream.ReceiveTick(newPriceBar(11, 103.0))
+			// the rectangle of the first four swing points expired when the fifth formed the second rectangle
+			patterns := detector.Patterns()
+			Expect(len(patterns)).To(Equal(3))
+			Expect(patterns[1].State).To(Equal(chartpatterns.ChartPatternExpired))
+			Expect(patterns[2].PatternType).To(Equal(chartpatterns.Rectangle))
+			Expect(patterns[2].State).To(Equal(chartpatterns.ChartPatternForming))
+		})
+
+		It("should undo the breakout when the breakout bar is revised back inside the pattern", func() {
+			stream.ReceiveTick(newPriceBar(10, 111.0))
+			stream.ReceiveTickUpdate(newPriceBar(10, 108.0))
+			pattern, ok := detector.FormingPattern()
+			Expect(ok).To(BeTrue())
+			Expect(pattern.PatternType).To(Equal(chartpatterns.Rectangle))
+			Expect(pattern.BreakoutBarIndex).To(Equal(0))
+			Expect(detector.Patterns()[len(detector.Patterns())-1]).To(Equal(pattern))
+		})
+
+		It("should undo the expiry and the new pattern when the bar confirming the swing point is revised", func() {
+			stream.ReceiveTick(newPriceBar(10, 109.0))
+			stream.ReceiveTick(newPriceBar(11, 103.0))
+			stream.ReceiveTickUpdate(newPriceBar(11, 108.0))
+			patterns := detector.Patterns()
+			Expect(len(patterns)).To(Equal(2))
+			Expect(patterns[1].State).To(Equal(chartpatterns.ChartPatternForming))
+
+			stream.ReceiveTickUpdate(newPriceBar(11, 103.0))
+			patterns = detector.Patterns()
+			Expect(len(patterns)).To(Equal(3))
+			Expect(patterns[1].State).To(Equal(chartpatterns.ChartPatternExpired))
+			Expect(patterns[2].State).To(Equal(chartpatterns.ChartPatternForming))
+		})
+	})
+
+	Context("and the swings form flat highs and rising lows", func() {
+		BeforeEach(func() {
+			receivePrices(100.0, 110.0, 104.0, 100.0, 106.0, 110.0, 103.0, 109.0)
+		})
+
+		It("should detect an ascending triangle", func() {
+			pattern, ok := detector.FormingPattern()
+			Expect(ok).To(BeTrue())
+			Expect(pattern.PatternType).To(Equal(chartpatterns.AscendingTriangle))
+		})
+	})
+
+	Context("and a sharp rise is followed by a small falling consolidation", func() {
+		BeforeEach(func() {
+			receivePrices(100.0, 90.0, 96.0, 120.0, 130.0, 123.0, 120.0, 127.0, 128.0, 121.0, 118.0, 124.0, 127.0)
+		})
+
+		It("should detect a bull flag which completes when the close breaks above it", func() {
+			// the start of the pole first forms an ascending triangle with the flag's first points
+			patterns := detector.Patterns()
+			Expect(len(patterns)).To(Equal(2))
+			Expect(patterns[0].PatternType).To(Equal(chartpatterns.AscendingTriangle))
+			Expect(patterns[1].PatternType).To(Equal(chartpatterns.BullFlag))
+			Expect(patterns[1].Points[0].Price).To(Equal(90.0))
+			Expect(patterns[1].State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(patterns[1].BreakoutLevel).To(Equal(126.0))
+		})
+	})
+
+	Context("and the swings form an inverse head and shoulders which breaks its neckline", func() {
+		BeforeEach(func() {
+			receivePrices(130.0, 120.0, 110.0, 118.0, 120.0, 110.0, 100.0, 110.0, 120.0, 114.0, 109.0, 116.0, 121.0)
+		})
+
+		It("should complete the pattern when the close breaks above the neckline", func() {
+			patterns := detector.Patterns()
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].PatternType).To(Equal(chartpatterns.InverseHeadAndShoulders))
+			Expect(patterns[0].UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 120.0, StartBarIndex: 5, EndPrice: 120.0, EndBarIndex: 9}))
+			Expect(patterns[0].LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 100.0, StartBarIndex: 7, EndPrice: 100.0, EndBarIndex: 7}))
+			Expect(patterns[0].State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(patterns[0].BreakoutRising).To(BeTrue())
+			Expect(patterns[0].BreakoutLevel).To(Equal(120.0))
+			Expect(patterns[0].BreakoutBarIndex).To(Equal(13))
+		})
+	})
+
+	Context("and the close breaks a head and shoulders above its head", func() {
+		BeforeEach(func() {
+			receivePrices(100.0, 110.0, 120.0, 112.0, 110.0, 120.0, 130.0, 120.0, 110.0, 116.0, 121.0, 114.0, 131.0)
+		})
+
+		It("should fail the pattern before the swing low confirmed by the close expires it", func() {
+			pattern := detector.Patterns()[0]
+			Expect(pattern.PatternType).To(Equal(chartpatterns.HeadAndShoulders))
+			Expect(pattern.UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 130.0, StartBarIndex: 7, EndPrice: 130.0, EndBarIndex: 7}))
+			Expect(pattern.LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 110.0, StartBarIndex: 5, EndPrice: 110.0, EndBarIndex: 9}))
+			Expect(pattern.State).To(Equal(chartpatterns.ChartPatternFailed))
+			Expect(pattern.BreakoutRising).To(BeTrue())
+			Expect(pattern.BreakoutLevel).To(Equal(130.0))
+			Expect(pattern.BreakoutBarIndex).To(Equal(13))
+		})
+	})
+
+	Context("and the swings form falling highs and flat lows", func() {
+		BeforeEach(func() {
+			receivePrices(120.0, 110.0, 100.0, 106.0, 112.0, 106.0, 100.0, 105.0, 101.0, 98.0)
+		})
+
+		It("should detect a descending triangle which completes when the close breaks below it", func() {
+			pattern := detector.Patterns()[0]
+			Expect(pattern.PatternType).To(Equal(chartpatterns.DescendingTriangle))
+			Expect(pattern.UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 120.0, StartBarIndex: 1, EndPrice: 112.0, EndBarIndex: 5}))
+			Expect(pattern.LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 100.0, StartBarIndex: 3, EndPrice: 100.0, EndBarIndex: 7}))
+			Expect(pattern.State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(pattern.BreakoutRising).To(BeFalse())
+			Expect(pattern.BreakoutLevel).To(Equal(100.0))
+			Expect(pattern.BreakoutBarIndex).To(Equal(10))
+		})
+	})
+
+	Context("and the swings form falling highs and rising lows", func() {
+		BeforeEach(func() {
+			receivePrices(120.0, 110.0, 100.0, 106.0, 114.0, 108.0, 104.0, 109.3, 105.0)
+		})
+
+		It("should detect a symmetrical triangle which completes when the close breaks below it", func() {
+			pattern := detector.Patterns()[0]
+			Expect(pattern.PatternType).To(Equal(chartpatterns.SymmetricalTriangle))
+			Expect(pattern.UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 120.0, StartBarIndex: 1, EndPrice: 114.0, EndBarIndex: 5}))
+			Expect(pattern.LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 100.0, StartBarIndex: 3, EndPrice: 104.0, EndBarIndex: 7}))
+			Expect(pattern.State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(pattern.BreakoutRising).To(BeFalse())
+			Expect(pattern.BreakoutLevel).To(Equal(106.0))
+			Expect(pattern.BreakoutBarIndex).To(Equal(9))
+		})
+	})
+
+	Context("and the swings form rising highs and faster rising lows", func() {
+		BeforeEach(func() {
+			receivePrices(110.0, 104.0, 100.0, 107.0, 114.0, 108.0, 106.0, 111.5, 105.0)
+		})
+
+		It("should detect a rising wedge which completes when the close breaks below it", func() {
+			pattern := detector.Patterns()[0]
+			Expect(pattern.PatternType).To(Equal(chartpatterns.RisingWedge))
+			Expect(pattern.UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 110.0, StartBarIndex: 1, EndPrice: 114.0, EndBarIndex: 5}))
+			Expect(pattern.LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 100.0, StartBarIndex: 3, EndPrice: 106.0, EndBarIndex: 7}))
+			Expect(pattern.State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(pattern.BreakoutRising).To(BeFalse())
+			Expect(pattern.BreakoutLevel).To(Equal(109.0))
+			Expect(pattern.BreakoutBarIndex).To(Equal(9))
+		})
+	})
+
+	Context("and the swings form falling lows and faster falling highs", func() {
+		BeforeEach(func() {
+			receivePrices(124.0, 116.0, 108.0, 113.5, 118.0, 111.0, 104.0, 109.5, 113.0)
+		})
+
+		It("should detect a falling wedge which completes when the close breaks above it", func() {
+			pattern := detector.Patterns()[0]
+			Expect(pattern.PatternType).To(Equal(chartpatterns.FallingWedge))
+			Expect(pattern.UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 124.0, StartBarIndex: 1, EndPrice: 118.0, EndBarIndex: 5}))
+			Expect(pattern.LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 108.0, StartBarIndex: 3, EndPrice: 104.0, EndBarIndex: 7}))
+			Expect(pattern.State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(pattern.BreakoutRising).To(BeTrue())
+			Expect(pattern.BreakoutLevel).To(Equal(112.0))
+			Expect(pattern.BreakoutBarIndex).To(Equal(9))
+		})
+	})
+
+	Context("and a sharp rise is followed by a small converging consolidation", func() {
+		BeforeEach(func() {
+			receivePrices(70.0, 100.0, 130.0, 128.0, 123.0, 110.0, 114.0, 120.0, 127.0, 125.0, 120.0, 115.0, 121.0, 126.0)
+		})
+
+		It("should detect a bull pennant which completes when the close breaks above it", func() {
+			// the start of the pole first forms a symmetrical triangle with the pennant's first points
+			patterns := detector.Patterns()
+			Expect(len(patterns)).To(Equal(2))
+			Expect(patterns[1].PatternType).To(Equal(chartpatterns.BullPennant))
+			Expect(patterns[1].Points[0].Price).To(Equal(70.0))
+			Expect(patterns[1].UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 130.0, StartBarIndex: 3, EndPrice: 127.0, EndBarIndex: 9}))
+			Expect(patterns[1].LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 110.0, StartBarIndex: 6, EndPrice: 115.0, EndBarIndex: 12}))
+			Expect(patterns[1].State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(patterns[1].BreakoutRising).To(BeTrue())
+			Expect(patterns[1].BreakoutLevel).To(Equal(124.5))
+			Expect(patterns[1].BreakoutBarIndex).To(Equal(14))
+		})
+	})
+
+	Context("and a sharp fall is followed by a small converging consolidation", func() {
+		BeforeEach(func() {
+			receivePrices(130.0, 100.0, 70.0, 72.0, 76.0, 90.0, 86.0, 80.0, 72.0, 74.0, 76.0, 85.0, 80.0, 73.0)
+		})
+
+		It("should detect a bear pennant which completes when the close breaks below it", func() {
+			patterns := detector.Patterns()
+			Expect(len(patterns)).To(Equal(2))
+			Expect(patterns[1].PatternType).To(Equal(chartpatterns.BearPennant))
+			Expect(patterns[1].Points[0].Price).To(Equal(130.0))
+			Expect(patterns[1].UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 90.0, StartBarIndex: 6, EndPrice: 85.0, EndBarIndex: 12}))
+			Expect(patterns[1].LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 70.0, StartBarIndex: 3, EndPrice: 72.0, EndBarIndex: 9}))
+			Expect(patterns[1].State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(patterns[1].BreakoutRising).To(BeFalse())
+			Expect(patterns[1].BreakoutLevel).To(BeNumerically("~", 73.6667, 0.0001))
+			Expect(patterns[1].BreakoutBarIndex).To(Equal(14))
+		})
+	})
+
+	Context("and a sharp fall is followed by a small rising consolidation", func() {
+		BeforeEach(func() {
+			receivePrices(130.0, 100.0, 70.0, 74.0, 85.0, 80.0, 75.0, 79.0, 90.0, 85.0, 79.0)
+		})
+
+		It("should detect a bear flag which completes when the close breaks below it", func() {
+			patterns := detector.Patterns()
+			Expect(len(patterns)).To(Equal(2))
+			Expect(patterns[1].PatternType).To(Equal(chartpatterns.BearFlag))
+			Expect(patterns[1].Points[0].Price).To(Equal(130.0))
+			Expect(patterns[1].UpperLine).To(Equal(chartpatterns.TrendLine{StartPrice: 85.0, StartBarIndex: 5, EndPrice: 90.0, EndBarIndex: 9}))
+			Expect(patterns[1].LowerLine).To(Equal(chartpatterns.TrendLine{StartPrice: 70.0, StartBarIndex: 3, EndPrice: 75.0, EndBarIndex: 7}))
+			Expect(patterns[1].State).To(Equal(chartpatterns.ChartPatternCompleted))
+			Expect(patterns[1].BreakoutRising).To(BeFalse())
+			Expect(patterns[1].BreakoutLevel).To(Equal(80.0))
+			Expect(patterns[1].BreakoutBarIndex).To(Equal(11))
+		})
+	})
+})
@@ -0,0 +1,157 @@
+package chartpatterns
+
+import (
+	"math"
+)
+
+// recognise matches the latest swing points against the patterns, the most specific pattern first
+func (d *ChartPatternDetector) recognise(points []SwingPoint) (pattern ChartPattern, ok bool) {
+	n := len(points)
+	if n >= 5 {
+		if pattern, ok = d.recogniseFlag(points[n-5:]); ok {
+			return pattern, true
+		}
+		if pattern, ok = d.recogniseHeadAndShoulders(points[n-5:]); ok {
+			return pattern, true
+		}
+	}
+	if n >= 4 {
+		return d.recogniseConsolidation(points[n-4:])
+	}
+	return ChartPattern{}, false
+}
+
+// recogniseHeadAndShoulders matches a shoulder, neckline point, head, neckline point and shoulder
+func (d *ChartPatternDetector) recogniseHeadAndShoulders(points []SwingPoint) (pattern ChartPattern, ok bool) {
+	leftShoulder, head, rightShoulder := points[0], points[2], points[4]
+	if !d.level(leftShoulder.Price, rightShoulder.Price) || d.level(head.Price, leftShoulder.Price) || d.level(head.Price, rightShoulder.Price) {
+		return ChartPattern{}, false
+	}
+
+	neckline := newTrendLine(points[1], points[3])
+	headLine := newTrendLine(head, head)
+	if head.High && head.Price > leftShoulder.Price && head.Price > rightShoulder.Price {
+		return d.newPattern(HeadAndShoulders, points, headLine, neckline), true
+	}
+	if !head.High && head.Price < leftShoulder.Price && head.Price < rightShoulder.Price {
+		return d.newPattern(InverseHeadAndShoulders, points, neckline, headLine), true
+	}
+	return ChartPattern{}, false
+}
+
+// recogniseFlag matches the start and end of a pole followed by a consolidation of two highs and two lows which
+// retraces no more than half of the pole
+func (d *ChartPatternDetector) recogniseFlag(points []SwingPoint) (pattern ChartPattern, ok bool) {
+	poleStart, poleEnd := points[0], points[1]
+	upper, lower := d.boundaries(points[1:])
+	pole := math.Abs(poleEnd.Price - poleStart.Price)
+
+	rising := poleEnd.High
+	var retracement float64
+	if rising {
+		retracement = poleEnd.Price - math.Min(lower.StartPrice, lower.EndPrice)
+	} else {
+		retracement = math.Max(upper.StartPrice, upper.EndPrice) - poleEnd.Price
+	}
+	if retracement > pole/2.0 {
+		return ChartPattern{}, false
+	}
+
+	upperDirection := d.direction(upper)
+	lowerDirection := d.direction(lower)
+	switch {
+	case upperDirection == -1 && lowerDirection == 1 && rising:
+		return d.newPattern(BullPennant, points, upper, lower), true
+	case upperDirection == -1 && lowerDirection == 1:
+		return d.newPattern(BearPennant, points, upper, lower), true
+	case rising && upperDirection <= 0 && lowerDirection <= 0 && d.parallel(upper, lower):
+		return d.newPattern(BullFlag, points, upper, lower), true
+	case !rising && upperDirection >= 0 && lowerDirection >= 0 && d.parallel(upper, lower):
+		return d.newPattern(BearFlag, points, upper, lower), true
+	}
+	return ChartPattern{}, false
+}
+
+// recogniseConsolidation matches two highs and two lows against the triangles, wedges and rectangles
+func (d *ChartPatternDetector) recogniseConsolidation(points []SwingPoint) (pattern ChartPattern, ok bool) {
+	upper, lower := d.boundaries(points)
+	upperDirection := d.direction(upper)
+	lowerDirection := d.direction(lower)
+	converging := upper.slope() < lower.slope()
+
+	switch {
+	case upperDirection == 0 && lowerDirection == 0:
+		return d.newPattern(Rectangle, points, upper, lower), true
+	case upperDirection == 0 && lowerDirection == 1:
+		return d.newPattern(AscendingTriangle, points, upper, lower), true
+	case upperDirection == -1 && lowerDirection == 0:
+		return d.newPattern(DescendingTriangle, points, upper, lower), true
+	case upperDirection == -1 && lowerDirection == 1:
+		return d.newPattern(SymmetricalTriangle, points, upper, lower), true
+	case upperDirection == 1 && lowerDirection == 1 && converging:
+		return d.newPattern(RisingWedge, points, upper, lower), true
+	case upperDirection == -1 && lowerDirection == -1 && converging:
+		return d.newPattern(FallingWedge, points, upper, lower), true
+	}
+	return ChartPattern{}, false
+}
+
+// boundaries returns the line through the two highs and the line through the two lows of four alternating swing points
+func (d *ChartPatternDetector) boundaries(points []SwingPoint) (upper TrendLine, lower TrendLine) {
+	var highs, lows []SwingPoint
+	for _, point := range points {
+		if point.High {
+			highs = append(highs, point)
+		} else {
+			lows = append(lows, point)
+		}
+	}
+	return newTrendLine(highs[0], highs[1]), newTrendLine(lows[0], lows[1])
+}
+
+// direction returns 1 when the line rises, -1 when it falls and 0 when its ends are level
+func (d *ChartPatternDetector) direction(line TrendLine) int {
+	switch {
+	case d.level(line.StartPrice, line.EndPrice):
+		return 0
+	case line.EndPrice > line.StartPrice:
+		return 1
+	}
+	return -1
+}
+
+// parallel returns true when the lines change by a level amount across the consolidation
+func (d *ChartPatternDetector) parallel(upper TrendLine, lower TrendLine) bool {
+	span := float64(maxInt(upper.EndBarIndex, lower.EndBarIndex) - minInt(upper.StartBarIndex, lower.StartBarIndex))
+	midpoint := (upper.StartPrice + lower.StartPrice) / 2.0
+	return d.level(midpoint+upper.slope()*span, midpoint+lower.slope()*span)
+}
+
+// level returns true when the prices are within the tolerance percentage of their average
+func (d *ChartPatternDetector) level(price float64, other float64) bool {
+	return math.Abs(price-other) <= d.tolerancePercentage/100.0*(price+other)/2.0
+}
+
+func (d *ChartPatternDetector) newPattern(patternType ChartPatternType, points []SwingPoint, upper TrendLine, lower TrendLine) ChartPattern {
+	patternPoints := make([]SwingPoint, len(points))
+	copy(patternPoints, points)
+	return ChartPattern{PatternType: patternType,
+		State:     ChartPatternForming,
+		Points:    patternPoints,
+		UpperLine: upper,
+		LowerLine: lower}
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,180 @@
+/*
+	import "github.com/thetruetrade/gotrade/chartpatterns"
+
+	Package chartpatterns detects the geometric patterns formed by the swings of a price stream, e.g. head and
+	shoulders, triangles, flags and wedges, reporting each pattern as it forms and again when the price breaks out.
+*/
+package chartpatterns
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"sync"
+	"time"
+)
+
+var (
+	ErrSwingReversalMustBeGreaterThanZero = errors.New("Swing reversal must be greater than zero")
+)
+
+// A SwingPoint is a confirmed turning point of the price, the high of a swing high or the low of a swing low
+type SwingPoint struct {
+	High           bool
+	Price          float64
+	Date           time.Time
+	StreamBarIndex int
+}
+
+type SwingPointReceiver interface {
+	ReceiveSwingPoint(point SwingPoint)
+}
+
+// A SwingPointDetector confirms the swing highs and lows of a source data stream from the pivots confirmed by a
+// percentage ZigZag. The highest high is confirmed as a swing high once the price falls the reversal percentage
+// below it, and the lowest low since is confirmed as a swing low once the price rises the reversal percentage above
+// it, so swing highs and lows alternate.
+type SwingPointDetector struct {
+	// private variables
+	zigzag           *indicators.ZigZagWithoutStorage
+	dates            []time.Time
+	firstDateIndex   int
+	points           []SwingPoint
+	subscribers      []SwingPointReceiver
+	subscribersMutex sync.Mutex
+	// the state prior to the latest bar, restored when the bar is revised
+	stateBarIndex       int
+	savedDates          []time.Time
+	savedFirstDateIndex int
+	savedPointCount     int
+}
+
+// NewSwingPointDetector creates a detector confirming swings which reverse reversalPercentage percent, e.g. 5.0 for 5%
+func NewSwingPointDetector(reversalPercentage float64) (detector *SwingPointDetector, err error) {
+	if reversalPercentage <= 0.0 {
+		return nil, ErrSwingReversalMustBeGreaterThanZero
+	}
+
+	d := SwingPointDetector{}
+	d.zigzag, err = indicators.NewZigZagWithoutStorage(reversalPercentage, d.receiveZigZagEvent)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// NewSwingPointDetectorForStream creates a swing point detector which subscribes to the source data stream
+func NewSwingPointDetectorForStream(sourceStream gotrade.DOHLCVStreamSubscriber, reversalPercentage float64) (detector *SwingPointDetector, err error) {
+	d, err := NewSwingPointDetector(reversalPercentage)
+	if err != nil {
+		return nil, err
+	}
+	sourceStream.AddTickSubscription(d)
+	return d, nil
+}
+
+// SwingPoints returns a copy of the confirmed swing points, the latest swing point is last
+func (d *SwingPointDetector) SwingPoints() []SwingPoint {
+	points := make([]SwingPoint, len(d.points))
+	copy(points, d.points)
+	return points
+}
+
+func (d *SwingPointDetector) AddSwingPointSubscription(subscriber SwingPointReceiver) {
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+	d.subscribers = append(d.subscribers, subscriber)
+}
+
+func (d *SwingPointDetector) RemoveSwingPointSubscription(subscriber SwingPointReceiver) {
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+	subscribers := make([]SwingPointReceiver, 0, len(d.subscribers))
+	for _, existing := range d.subscribers {
+		if existing != subscriber {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	d.subscribers = subscribers
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, confirming a swing point when the price reverses
+func (d *SwingPointDetector) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	d.saveState(streamBarIndex)
+	d.appendDate(tickData, streamBarIndex)
+	d.zigzag.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing a swing point
+// confirmed by the bar prior to its revision. The swing points confirmed by the revised bar are published again.
+func (d *SwingPointDetector) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if d.prepareRevision(streamBarIndex) {
+		d.appendDate(tickData, streamBarIndex)
+		d.zigzag.ReceiveDOHLCVTickUpdate(tickData, streamBarIndex)
+	}
+}
+
+// appendDate records the date of the bar, the dates of the bars since the latest swing point are kept as one of
+// them is the date of the next swing point
+func (d *SwingPointDetector) appendDate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if len(d.dates) == 0 {
+		d.firstDateIndex = streamBarIndex
+	}
+	d.dates = append(d.dates, tickData.D())
+}
+
+// prepareRevision returns the detector to its state prior to the source data bar when it is the latest bar received.
+// Returns false when the bar precedes the latest bar and can no longer be revised.
+func (d *SwingPointDetector) prepareRevision(streamBarIndex int) bool {
+	if streamBarIndex < d.stateBarIndex {
+		return false
+	}
+
+	if streamBarIndex == d.stateBarIndex {
+		d.restoreState()
+	}
+	return true
+}
+
+// saveState records the state of the detector prior to the source data bar
+func (d *SwingPointDetector) saveState(streamBarIndex int) {
+	if d.stateBarIndex == streamBarIndex {
+		return
+	}
+	d.stateBarIndex = streamBarIndex
+	d.savedDates = append(d.savedDates[:0], d.dates...)
+	d.savedFirstDateIndex = d.firstDateIndex
+	d.savedPointCount = len(d.points)
+}
+
+// restoreState returns the detector to the state recorded by saveState
+func (d *SwingPointDetector) restoreState() {
+	d.dates = append(d.dates[:0], d.savedDates...)
+	d.firstDateIndex = d.savedFirstDateIndex
+	d.points = d.points[:d.savedPointCount]
+}
+
+// receiveZigZagEvent publishes the pivots confirmed by the ZigZag as swing points
+func (d *SwingPointDetector) receiveZigZagEvent(event indicators.ZigZagEvent) {
+	if event.EventType != indicators.ZigZagPivotConfirmed {
+		return
+	}
+
+	dateIndex := event.Pivot.StreamBarIndex - d.firstDateIndex
+	point := SwingPoint{High: event.Pivot.High,
+		Price:          event.Pivot.Price,
+		Date:           d.dates[dateIndex],
+		StreamBarIndex: event.Pivot.StreamBarIndex}
+
+	d.dates = append(d.dates[:0], d.dates[dateIndex+1:]...)
+	d.firstDateIndex = point.StreamBarIndex + 1
+	d.points = append(d.points, point)
+
+	d.subscribersMutex.Lock()
+	subscribers := d.subscribers
+	d.subscribersMutex.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.ReceiveSwingPoint(point)
+	}
+}
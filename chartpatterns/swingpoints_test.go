@@ -0,0 +1,108 @@
+package chartpatterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/chartpatterns"
+)
+
+type swingPointRecorder struct {
+	points []chartpatterns.SwingPoint
+}
+
+func (r *swingPointRecorder) ReceiveSwingPoint(point chartpatterns.SwingPoint) {
+	r.points = append(r.points, point)
+}
+
+var _ = Describe("when detecting swing points", func() {
+	var (
+		stream   *gotrade.DOHLCVStream
+		detector *chartpatterns.SwingPointDetector
+		recorder *swingPointRecorder
+		err      error
+	)
+
+	It("should reject a reversal below the minimum", func() {
+		detector, err = chartpatterns.NewSwingPointDetector(0.0)
+		Expect(detector).To(BeNil())
+		Expect(err).To(Equal(chartpatterns.ErrSwingReversalMustBeGreaterThanZero))
+	})
+
+	Context("and the price reverses by the reversal percentage", func() {
+		BeforeEach(func() {
+			stream = gotrade.NewDOHLCVStream()
+			stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+			detector, err = chartpatterns.NewSwingPointDetectorForStream(stream, 10.0)
+			recorder = &swingPointRecorder{}
+			detector.AddSwingPointSubscription(recorder)
+			for day, price := range []float64{100.0, 110.0, 120.0, 115.0, 105.0, 100.0, 108.0, 112.0} {
+				stream.ReceiveTick(newPriceBar(day+1, price))
+			}
+		})
+
+		It("should confirm alternating swing highs and lows at their extremes", func() {
+			Expect(err).To(BeNil())
+			points := detector.SwingPoints()
+			Expect(len(points)).To(Equal(3))
+			Expect(points[0].High).To(BeFalse())
+			Expect(points[0].Price).To(Equal(100.0))
+			Expect(points[0].StreamBarIndex).To(Equal(1))
+			Expect(points[1].High).To(BeTrue())
+			Expect(points[1].Price).To(Equal(120.0))
+			Expect(points[1].StreamBarIndex).To(Equal(3))
+			Expect(points[2].High).To(BeFalse())
+			Expect(points[2].Price).To(Equal(100.0))
+			Expect(points[2].StreamBarIndex).To(Equal(6))
+		})
+
+		It("should publish the swing points to the subscribers", func() {
+			Expect(recorder.points).To(Equal(detector.SwingPoints()))
+		})
+	})
+
+	Context("and the bar confirming a swing point is revised", func() {
+		BeforeEach(func() {
+			stream = gotrade.NewDOHLCVStream()
+			stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+			detector, err = chartpatterns.NewSwingPointDetectorForStream(stream, 10.0)
+			recorder = &swingPointRecorder{}
+			detector.AddSwingPointSubscription(recorder)
+			for day, price := range []float64{100.0, 110.0, 120.0, 115.0, 105.0} {
+				stream.ReceiveTick(newPriceBar(day+1, price))
+			}
+		})
+
+		It("should withdraw the swing point when the revision no longer reverses", func() {
+			Expect(len(detector.SwingPoints())).To(Equal(2))
+			stream.ReceiveTickUpdate(newPriceBar(5, 112.0))
+			points := detector.SwingPoints()
+			Expect(len(points)).To(Equal(1))
+			Expect(points[0].Price).To(Equal(100.0))
+		})
+
+		It("should confirm and publish the swing point again when a later revision reverses", func() {
+			stream.ReceiveTickUpdate(newPriceBar(5, 112.0))
+			stream.ReceiveTickUpdate(newPriceBar(5, 104.0))
+			points := detector.SwingPoints()
+			Expect(len(points)).To(Equal(2))
+			Expect(points[1].High).To(BeTrue())
+			Expect(points[1].Price).To(Equal(120.0))
+			Expect(points[1].StreamBarIndex).To(Equal(3))
+			Expect(points[1].Date).To(Equal(newPriceBar(3, 120.0).D()))
+			Expect(len(recorder.points)).To(Equal(3))
+			Expect(recorder.points[2]).To(Equal(points[1]))
+		})
+
+		It("should confirm the following swing points from the revised bar", func() {
+			stream.ReceiveTickUpdate(newPriceBar(5, 112.0))
+			stream.ReceiveTick(newPriceBar(6, 100.0))
+			stream.ReceiveTick(newPriceBar(7, 115.0))
+			points := detector.SwingPoints()
+			Expect(len(points)).To(Equal(3))
+			Expect(points[1].Price).To(Equal(120.0))
+			Expect(points[2].Price).To(Equal(100.0))
+			Expect(points[2].Date).To(Equal(newPriceBar(6, 100.0).D()))
+		})
+	})
+})
@@ -0,0 +1,19 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+
+	"testing"
+	"time"
+)
+
+func TestCharts(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Charts Suite")
+}
+
+func newBar(day int, openPrice float64, highPrice float64, lowPrice float64, closePrice float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Date(2013, time.January, day, 0, 0, 0, 0, time.UTC), openPrice, highPrice, lowPrice, closePrice, 100.0)
+}
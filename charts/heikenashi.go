@@ -0,0 +1,76 @@
+package charts
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"math"
+)
+
+// A HeikinAshiStream republishes the bars of a source data stream as Heikin-Ashi candles, a stream
+// like any other to which indicators can be attached
+//	- the close is the average of the open, high, low and close
+//	- the open is the average of the previous candle's open and close, the first open is the average of the open and close
+//	- the high is the highest of the high, the candle's open and the candle's close
+//	- the low is the lowest of the low, the candle's open and the candle's close
+//	- the date and volume are those of the source bar
+type HeikinAshiStream struct {
+	*gotrade.DOHLCVStream
+
+	// private variables
+	latestBarIndex int
+	previousOpen   float64
+	previousClose  float64
+	hasPrevious    bool
+	latestOpen     float64
+	latestClose    float64
+}
+
+func NewHeikinAshiStream() *HeikinAshiStream {
+	return &HeikinAshiStream{DOHLCVStream: gotrade.NewDOHLCVStream()}
+}
+
+// NewHeikinAshiStreamForStream creates a Heikin-Ashi stream which subscribes to the source data stream
+func NewHeikinAshiStreamForStream(sourceStream gotrade.DOHLCVStreamSubscriber) *HeikinAshiStream {
+	s := NewHeikinAshiStream()
+	sourceStream.AddTickSubscription(s)
+	return s
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, publishing its Heikin-Ashi candle
+func (s *HeikinAshiStream) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if s.latestBarIndex > 0 {
+		s.previousOpen = s.latestOpen
+		s.previousClose = s.latestClose
+		s.hasPrevious = true
+	}
+	s.latestBarIndex = streamBarIndex
+	s.ReceiveTick(s.candle(tickData))
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, revising the latest candle.
+// Revisions of earlier bars are ignored.
+func (s *HeikinAshiStream) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if streamBarIndex != s.latestBarIndex {
+		if streamBarIndex > s.latestBarIndex {
+			s.ReceiveDOHLCVTick(tickData, streamBarIndex)
+		}
+		return
+	}
+	s.ReceiveTickUpdate(s.candle(tickData))
+}
+
+// candle returns the Heikin-Ashi candle of the source bar, following the candle of the previous source bar
+func (s *HeikinAshiStream) candle(tickData gotrade.DOHLCV) gotrade.DOHLCV {
+	s.latestClose = (tickData.O() + tickData.H() + tickData.L() + tickData.C()) / 4.0
+	if s.hasPrevious {
+		s.latestOpen = (s.previousOpen + s.previousClose) / 2.0
+	} else {
+		s.latestOpen = (tickData.O() + tickData.C()) / 2.0
+	}
+
+	return gotrade.NewDOHLCVDataItem(tickData.D(),
+		s.latestOpen,
+		math.Max(tickData.H(), math.Max(s.latestOpen, s.latestClose)),
+		math.Min(tickData.L(), math.Min(s.latestOpen, s.latestClose)),
+		s.latestClose,
+		tickData.V())
+}
@@ -0,0 +1,59 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/charts"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when building a heikin-ashi stream", func() {
+	var (
+		source *gotrade.InterDayDOHLCVStream
+		stream *charts.HeikinAshiStream
+		sma    *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		source = gotrade.NewDailyDOHLCVStream()
+		source.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		stream = charts.NewHeikinAshiStreamForStream(source)
+		stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		sma, _ = indicators.NewSmaForStream(stream, 2, gotrade.UseClosePrice)
+
+		source.ReceiveTick(newBar(2, 10.0, 12.0, 9.0, 11.0))
+		source.ReceiveTick(newBar(3, 11.0, 13.0, 10.0, 12.0))
+	})
+
+	It("the first candle should open at the average of the open and close", func() {
+		Expect(stream.Data[0].O()).To(Equal(10.5))
+		Expect(stream.Data[0].C()).To(Equal(10.5))
+		Expect(stream.Data[0].H()).To(Equal(12.0))
+		Expect(stream.Data[0].L()).To(Equal(9.0))
+	})
+
+	It("later candles should open at the average of the previous candle's open and close", func() {
+		Expect(stream.Data[1].O()).To(Equal(10.5))
+		Expect(stream.Data[1].C()).To(Equal(11.5))
+		Expect(stream.Data[1].D()).To(Equal(source.Data[1].D()))
+		Expect(stream.Data[1].V()).To(Equal(100.0))
+	})
+
+	It("an indicator attached to the stream should receive the candles", func() {
+		Expect(sma.Data).To(Equal([]float64{11.0}))
+	})
+
+	Context("and the latest source bar is revised", func() {
+		BeforeEach(func() {
+			source.ReceiveTickUpdate(newBar(3, 11.0, 14.0, 10.0, 14.0))
+		})
+
+		It("should revise the latest candle", func() {
+			Expect(len(stream.Data)).To(Equal(2))
+			Expect(stream.Data[1].O()).To(Equal(10.5))
+			Expect(stream.Data[1].C()).To(Equal(12.25))
+			Expect(sma.Data).To(Equal([]float64{11.375}))
+		})
+	})
+})
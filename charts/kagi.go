@@ -0,0 +1,270 @@
+package charts
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"sync"
+	"time"
+)
+
+var (
+	ErrReversalAmountMustBeGreaterThanZero = errors.New("Reversal amount must be greater than zero")
+)
+
+// KagiReversalMethod determines how the price move needed to reverse a Kagi line is measured
+type KagiReversalMethod int
+
+const (
+	// the reversal is a fixed price amount
+	FixedKagiReversal KagiReversalMethod = iota
+	// the reversal is a percentage of the price at the end of the line
+	PercentageKagiReversal
+)
+
+// A KagiReversal describes the price move against a Kagi line which starts a new line
+type KagiReversal struct {
+	// private variables
+	method KagiReversalMethod
+	amount float64
+}
+
+func NewFixedKagiReversal(amount float64) (reversal KagiReversal, err error) {
+	if amount <= 0.0 {
+		return KagiReversal{}, ErrReversalAmountMustBeGreaterThanZero
+	}
+	return KagiReversal{method: FixedKagiReversal, amount: amount}, nil
+}
+
+// NewPercentageKagiReversal creates a reversal of percentage percent of the price, e.g. 4.0 for 4%
+func NewPercentageKagiReversal(percentage float64) (reversal KagiReversal, err error) {
+	if percentage <= 0.0 {
+		return KagiReversal{}, ErrReversalAmountMustBeGreaterThanZero
+	}
+	return KagiReversal{method: PercentageKagiReversal, amount: percentage}, nil
+}
+
+// reversalAmount returns the price move against a line ending at the price which reverses it
+func (r KagiReversal) reversalAmount(price float64) float64 {
+	if r.method == PercentageKagiReversal {
+		return price * r.amount / 100.0
+	}
+	return r.amount
+}
+
+// KagiLineState is the thickness of a Kagi line
+type KagiLineState int
+
+const (
+	// a thick line, the price has risen above the previous shoulder
+	YangLine KagiLineState = iota
+	// a thin line, the price has fallen below the previous waist
+	YinLine
+)
+
+// A KagiSegment is a vertical part of a Kagi chart of one direction and one thickness. A line that changes
+// thickness where it crosses the previous shoulder or waist is made up of two segments.
+type KagiSegment struct {
+	Rising        bool
+	State         KagiLineState
+	StartPrice    float64
+	EndPrice      float64
+	StartDate     time.Time
+	EndDate       time.Time
+	StartBarIndex int
+	EndBarIndex   int
+}
+
+// KagiEventType is the type of change to a Kagi chart
+type KagiEventType int
+
+const (
+	// the price reversed and a new line was started
+	KagiNewLine KagiEventType = iota
+	// the latest segment was extended in its direction
+	KagiLineExtended
+	// the line crossed the previous shoulder or waist and a segment of the other thickness was started
+	KagiStateChanged
+)
+
+// A KagiEvent describes a change to a Kagi chart, the segment is as of the event
+type KagiEvent struct {
+	EventType    KagiEventType
+	Segment      KagiSegment
+	SegmentIndex int
+}
+
+type KagiEventReceiver interface {
+	ReceiveKagiEvent(event KagiEvent)
+}
+
+// A KagiStream builds a Kagi chart from the closes of a source data stream, publishing each change to
+// its subscribers and keeping the history of its segments. The first line is yang when rising and yin when
+// falling, a line turns yang when it rises above the top of the previous rising line, the shoulder, and
+// yin when it falls below the bottom of the previous falling line, the waist.
+type KagiStream struct {
+	// private variables
+	reversal         KagiReversal
+	segments         []KagiSegment
+	basePrice        float64
+	hasBase          bool
+	shoulder         float64
+	hasShoulder      bool
+	waist            float64
+	hasWaist         bool
+	subscribers      []KagiEventReceiver
+	subscribersMutex sync.Mutex
+}
+
+func NewKagiStream(reversal KagiReversal) *KagiStream {
+	return &KagiStream{reversal: reversal}
+}
+
+// NewKagiStreamForStream creates a Kagi stream which subscribes to the source data stream
+func NewKagiStreamForStream(sourceStream gotrade.DOHLCVStreamSubscriber, reversal KagiReversal) *KagiStream {
+	s := NewKagiStream(reversal)
+	sourceStream.AddTickSubscription(s)
+	return s
+}
+
+// Segments returns a copy of the segment history, the latest segment is last
+func (s *KagiStream) Segments() []KagiSegment {
+	segments := make([]KagiSegment, len(s.segments))
+	copy(segments, s.segments)
+	return segments
+}
+
+func (s *KagiStream) SegmentCount() int {
+	return len(s.segments)
+}
+
+// SegmentFromLatest returns the segment offset segments before the latest segment, an offset of 0 is the latest segment
+func (s *KagiStream) SegmentFromLatest(offset int) (segment KagiSegment, ok bool) {
+	if offset < 0 || offset >= len(s.segments) {
+		return KagiSegment{}, false
+	}
+	return s.segments[len(s.segments)-1-offset], true
+}
+
+func (s *KagiStream) AddEventSubscription(subscriber KagiEventReceiver) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+	s.subscribers = append(s.subscribers, subscriber)
+}
+
+func (s *KagiStream) RemoveEventSubscription(subscriber KagiEventReceiver) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+	subscribers := make([]KagiEventReceiver, 0, len(s.subscribers))
+	for _, existing := range s.subscribers {
+		if existing != subscriber {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	s.subscribers = subscribers
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, extending or reversing the latest line
+func (s *KagiStream) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	price := tickData.C()
+
+	// the first close is the base from which the first line must move the reversal amount
+	if !s.hasBase {
+		s.basePrice = price
+		s.hasBase = true
+		return
+	}
+
+	if len(s.segments) == 0 {
+		if price >= s.basePrice+s.reversal.reversalAmount(s.basePrice) {
+			s.startSegment(true, YangLine, s.basePrice, tickData, streamBarIndex)
+			s.extendSegment(KagiNewLine, price, tickData, streamBarIndex)
+		} else if price <= s.basePrice-s.reversal.reversalAmount(s.basePrice) {
+			s.startSegment(false, YinLine, s.basePrice, tickData, streamBarIndex)
+			s.extendSegment(KagiNewLine, price, tickData, streamBarIndex)
+		}
+		return
+	}
+
+	latest := s.segments[len(s.segments)-1]
+	switch {
+	case latest.Rising && price > latest.EndPrice, !latest.Rising && price < latest.EndPrice:
+		s.extendSegment(KagiLineExtended, price, tickData, streamBarIndex)
+	case latest.Rising && price <= latest.EndPrice-s.reversal.reversalAmount(latest.EndPrice):
+		s.shoulder = latest.EndPrice
+		s.hasShoulder = true
+		s.startSegment(false, latest.State, latest.EndPrice, tickData, streamBarIndex)
+		s.extendSegment(KagiNewLine, price, tickData, streamBarIndex)
+	case !latest.Rising && price >= latest.EndPrice+s.reversal.reversalAmount(latest.EndPrice):
+		s.waist = latest.EndPrice
+		s.hasWaist = true
+		s.startSegment(true, latest.State, latest.EndPrice, tickData, streamBarIndex)
+		s.extendSegment(KagiNewLine, price, tickData, streamBarIndex)
+	}
+}
+
+// startSegment starts a segment at the start price, it is published once it has been extended
+func (s *KagiStream) startSegment(rising bool, state KagiLineState, startPrice float64, tickData gotrade.DOHLCV, streamBarIndex int) {
+	s.segments = append(s.segments, KagiSegment{Rising: rising,
+		State:         state,
+		StartPrice:    startPrice,
+		EndPrice:      startPrice,
+		StartDate:     tickData.D(),
+		EndDate:       tickData.D(),
+		StartBarIndex: streamBarIndex,
+		EndBarIndex:   streamBarIndex})
+}
+
+// extendSegment extends the latest segment to the price, starting a segment of the other thickness
+// where it crosses the previous shoulder or waist, and publishes the change
+func (s *KagiStream) extendSegment(eventType KagiEventType, price float64, tickData gotrade.DOHLCV, streamBarIndex int) {
+	latest := &s.segments[len(s.segments)-1]
+
+	var crossing float64
+	crossed := false
+	if latest.Rising && latest.State == YinLine && s.hasShoulder && price > s.shoulder {
+		crossing, crossed = s.shoulder, true
+	} else if !latest.Rising && latest.State == YangLine && s.hasWaist && price < s.waist {
+		crossing, crossed = s.waist, true
+	}
+
+	if crossed && crossing != latest.StartPrice {
+		latest.EndPrice = crossing
+		latest.EndDate = tickData.D()
+		latest.EndBarIndex = streamBarIndex
+		s.publish(eventType)
+
+		state := YangLine
+		if !latest.Rising {
+			state = YinLine
+		}
+		s.startSegment(latest.Rising, state, crossing, tickData, streamBarIndex)
+		s.extendSegment(KagiStateChanged, price, tickData, streamBarIndex)
+		return
+	}
+
+	if crossed {
+		// the line crosses at its start, so the whole segment takes on the other thickness
+		latest.State = YangLine
+		if !latest.Rising {
+			latest.State = YinLine
+		}
+	}
+
+	latest.EndPrice = price
+	latest.EndDate = tickData.D()
+	latest.EndBarIndex = streamBarIndex
+	s.publish(eventType)
+}
+
+func (s *KagiStream) publish(eventType KagiEventType) {
+	s.subscribersMutex.Lock()
+	subscribers := s.subscribers
+	s.subscribersMutex.Unlock()
+
+	event := KagiEvent{EventType: eventType,
+		Segment:      s.segments[len(s.segments)-1],
+		SegmentIndex: len(s.segments) - 1}
+	for _, subscriber := range subscribers {
+		subscriber.ReceiveKagiEvent(event)
+	}
+}
@@ -0,0 +1,99 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/charts"
+)
+
+type kagiEventRecorder struct {
+	events []charts.KagiEvent
+}
+
+func (r *kagiEventRecorder) ReceiveKagiEvent(event charts.KagiEvent) {
+	r.events = append(r.events, event)
+}
+
+var _ = Describe("when building a kagi stream", func() {
+	var (
+		source   *gotrade.InterDayDOHLCVStream
+		stream   *charts.KagiStream
+		recorder *kagiEventRecorder
+	)
+
+	BeforeEach(func() {
+		source = gotrade.NewDailyDOHLCVStream()
+		source.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		recorder = &kagiEventRecorder{}
+	})
+
+	It("should reject a reversal amount below the minimum", func() {
+		_, err := charts.NewFixedKagiReversal(0.0)
+		Expect(err).To(Equal(charts.ErrReversalAmountMustBeGreaterThanZero))
+		_, err = charts.NewPercentageKagiReversal(-1.0)
+		Expect(err).To(Equal(charts.ErrReversalAmountMustBeGreaterThanZero))
+	})
+
+	Context("and the reversal is a fixed amount", func() {
+		BeforeEach(func() {
+			reversal, _ := charts.NewFixedKagiReversal(2.0)
+			stream = charts.NewKagiStreamForStream(source, reversal)
+			stream.AddEventSubscription(recorder)
+			for day, closePrice := range []float64{10.0, 13.0, 14.0, 11.0, 9.0, 12.0, 10.0, 8.0, 13.0} {
+				source.ReceiveTick(newBar(day+1, closePrice, closePrice, closePrice, closePrice))
+			}
+		})
+
+		It("should reverse the line once the price moves the reversal amount against it", func() {
+			segments := stream.Segments()
+			Expect(len(segments)).To(Equal(7))
+			Expect(segments[0].Rising).To(BeTrue())
+			Expect(segments[0].StartPrice).To(Equal(10.0))
+			Expect(segments[0].EndPrice).To(Equal(14.0))
+			Expect(segments[1].Rising).To(BeFalse())
+			Expect(segments[1].EndPrice).To(Equal(9.0))
+		})
+
+		It("should turn yin where the line falls below the previous waist", func() {
+			Expect(stream.Segments()[3].State).To(Equal(charts.YangLine))
+			Expect(stream.Segments()[3].EndPrice).To(Equal(9.0))
+			Expect(stream.Segments()[4].State).To(Equal(charts.YinLine))
+			Expect(stream.Segments()[4].StartPrice).To(Equal(9.0))
+			Expect(stream.Segments()[4].EndPrice).To(Equal(8.0))
+		})
+
+		It("should turn yang where the line rises above the previous shoulder", func() {
+			previous, _ := stream.SegmentFromLatest(1)
+			latest, _ := stream.SegmentFromLatest(0)
+			Expect(previous.State).To(Equal(charts.YinLine))
+			Expect(previous.EndPrice).To(Equal(12.0))
+			Expect(latest.State).To(Equal(charts.YangLine))
+			Expect(latest.EndPrice).To(Equal(13.0))
+			Expect(latest.EndBarIndex).To(Equal(9))
+		})
+
+		It("should publish every new line, extension and change of thickness", func() {
+			Expect(len(recorder.events)).To(Equal(10))
+			Expect(recorder.events[0].EventType).To(Equal(charts.KagiNewLine))
+			Expect(recorder.events[1].EventType).To(Equal(charts.KagiLineExtended))
+			Expect(recorder.events[7].EventType).To(Equal(charts.KagiStateChanged))
+			Expect(recorder.events[7].SegmentIndex).To(Equal(4))
+		})
+	})
+
+	Context("and the reversal is a percentage", func() {
+		BeforeEach(func() {
+			reversal, _ := charts.NewPercentageKagiReversal(10.0)
+			stream = charts.NewKagiStreamForStream(source, reversal)
+			source.ReceiveTick(newBar(1, 100.0, 100.0, 100.0, 100.0))
+			source.ReceiveTick(newBar(2, 105.0, 105.0, 105.0, 105.0))
+		})
+
+		It("should not start a line until the price moves the percentage", func() {
+			Expect(stream.SegmentCount()).To(Equal(0))
+			source.ReceiveTick(newBar(3, 111.0, 111.0, 111.0, 111.0))
+			Expect(stream.SegmentCount()).To(Equal(1))
+		})
+	})
+})
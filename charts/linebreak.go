@@ -0,0 +1,190 @@
+package charts
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"math"
+	"sync"
+	"time"
+)
+
+// A LineBreakLine is a line of a line break chart, running from the open to the close
+type LineBreakLine struct {
+	Rising         bool
+	Open           float64
+	Close          float64
+	Date           time.Time
+	StreamBarIndex int
+}
+
+// A LineBreakEvent describes a line added to a line break chart
+//	- reversal: true when the line is in the opposite direction to the previous line
+type LineBreakEvent struct {
+	Line      LineBreakLine
+	LineIndex int
+	Reversal  bool
+}
+
+type LineBreakEventReceiver interface {
+	ReceiveLineBreakEvent(event LineBreakEvent)
+}
+
+// A LineBreakStream builds an N line break chart from the closes of a source data stream. A close beyond the
+// latest line adds a line in the same direction, a close beyond the extreme of the latest N lines adds a line
+// in the opposite direction. Each line is published to the event subscribers and republished as a DOHLCV bar,
+// so that indicators can be attached to the stream like any other.
+//	- the open and close are the line's prices
+//	- the high and low are the highest and lowest of the line's prices
+//	- the date is the date of the source bar which added the line
+//	- the volume is the volume since the previous line
+type LineBreakStream struct {
+	*gotrade.DOHLCVStream
+
+	// private variables
+	lineCount        int
+	lines            []LineBreakLine
+	basePrice        float64
+	hasBase          bool
+	volume           float64
+	subscribers      []LineBreakEventReceiver
+	subscribersMutex sync.Mutex
+}
+
+// NewLineBreakStream creates a line break stream which reverses beyond the extreme of the latest lineCount lines
+func NewLineBreakStream(lineCount int) (stream *LineBreakStream, err error) {
+	// the minimum lineCount is 1
+	if lineCount < 1 {
+		return nil, errors.New("lineCount is less than the minimum (1)")
+	}
+	return &LineBreakStream{DOHLCVStream: gotrade.NewDOHLCVStream(), lineCount: lineCount}, nil
+}
+
+// NewDefaultLineBreakStream creates a three line break stream
+func NewDefaultLineBreakStream() (stream *LineBreakStream, err error) {
+	return NewLineBreakStream(3)
+}
+
+// NewLineBreakStreamForStream creates a line break stream which subscribes to the source data stream
+func NewLineBreakStreamForStream(sourceStream gotrade.DOHLCVStreamSubscriber, lineCount int) (stream *LineBreakStream, err error) {
+	s, err := NewLineBreakStream(lineCount)
+	if err != nil {
+		return s, err
+	}
+	sourceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+func NewDefaultLineBreakStreamForStream(sourceStream gotrade.DOHLCVStreamSubscriber) (stream *LineBreakStream, err error) {
+	return NewLineBreakStreamForStream(sourceStream, 3)
+}
+
+func (s *LineBreakStream) LineCount() int {
+	return s.lineCount
+}
+
+// Lines returns a copy of the line history, the latest line is last
+func (s *LineBreakStream) Lines() []LineBreakLine {
+	lines := make([]LineBreakLine, len(s.lines))
+	copy(lines, s.lines)
+	return lines
+}
+
+// LineFromLatest returns the line offset lines before the latest line, an offset of 0 is the latest line
+func (s *LineBreakStream) LineFromLatest(offset int) (line LineBreakLine, ok bool) {
+	if offset < 0 || offset >= len(s.lines) {
+		return LineBreakLine{}, false
+	}
+	return s.lines[len(s.lines)-1-offset], true
+}
+
+func (s *LineBreakStream) AddEventSubscription(subscriber LineBreakEventReceiver) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+	s.subscribers = append(s.subscribers, subscriber)
+}
+
+func (s *LineBreakStream) RemoveEventSubscription(subscriber LineBreakEventReceiver) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+	subscribers := make([]LineBreakEventReceiver, 0, len(s.subscribers))
+	for _, existing := range s.subscribers {
+		if existing != subscriber {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	s.subscribers = subscribers
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, adding a line if the close breaks out
+func (s *LineBreakStream) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	price := tickData.C()
+
+	// the first close is the base from which the first line is drawn
+	if !s.hasBase {
+		s.basePrice = price
+		s.hasBase = true
+		return
+	}
+	s.volume += tickData.V()
+
+	if len(s.lines) == 0 {
+		if price != s.basePrice {
+			s.addLine(price > s.basePrice, s.basePrice, tickData, streamBarIndex)
+		}
+		return
+	}
+
+	latest := s.lines[len(s.lines)-1]
+	top := math.Max(latest.Open, latest.Close)
+	bottom := math.Min(latest.Open, latest.Close)
+	switch {
+	case latest.Rising && price > top:
+		s.addLine(true, top, tickData, streamBarIndex)
+	case !latest.Rising && price < bottom:
+		s.addLine(false, bottom, tickData, streamBarIndex)
+	case latest.Rising && price < s.extreme(false):
+		s.addLine(false, bottom, tickData, streamBarIndex)
+	case !latest.Rising && price > s.extreme(true):
+		s.addLine(true, top, tickData, streamBarIndex)
+	}
+}
+
+// extreme returns the highest top, or lowest bottom, of the latest lines which a reversal must break
+func (s *LineBreakStream) extreme(highest bool) float64 {
+	first := len(s.lines) - s.lineCount
+	if first < 0 {
+		first = 0
+	}
+
+	extreme := s.lines[first].Close
+	for _, line := range s.lines[first:] {
+		if highest {
+			extreme = math.Max(extreme, math.Max(line.Open, line.Close))
+		} else {
+			extreme = math.Min(extreme, math.Min(line.Open, line.Close))
+		}
+	}
+	return extreme
+}
+
+func (s *LineBreakStream) addLine(rising bool, openPrice float64, tickData gotrade.DOHLCV, streamBarIndex int) {
+	reversal := len(s.lines) > 0 && s.lines[len(s.lines)-1].Rising != rising
+	line := LineBreakLine{Rising: rising,
+		Open:           openPrice,
+		Close:          tickData.C(),
+		Date:           tickData.D(),
+		StreamBarIndex: streamBarIndex}
+	s.lines = append(s.lines, line)
+
+	s.subscribersMutex.Lock()
+	subscribers := s.subscribers
+	s.subscribersMutex.Unlock()
+
+	event := LineBreakEvent{Line: line, LineIndex: len(s.lines) - 1, Reversal: reversal}
+	for _, subscriber := range subscribers {
+		subscriber.ReceiveLineBreakEvent(event)
+	}
+
+	s.ReceiveTick(gotrade.NewDOHLCVDataItem(line.Date, line.Open, math.Max(line.Open, line.Close), math.Min(line.Open, line.Close), line.Close, s.volume))
+	s.volume = 0.0
+}
@@ -0,0 +1,79 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/charts"
+)
+
+type lineBreakEventRecorder struct {
+	events []charts.LineBreakEvent
+}
+
+func (r *lineBreakEventRecorder) ReceiveLineBreakEvent(event charts.LineBreakEvent) {
+	r.events = append(r.events, event)
+}
+
+var _ = Describe("when building a line break stream", func() {
+	var (
+		source   *gotrade.InterDayDOHLCVStream
+		stream   *charts.LineBreakStream
+		recorder *lineBreakEventRecorder
+		err      error
+	)
+
+	BeforeEach(func() {
+		source = gotrade.NewDailyDOHLCVStream()
+		source.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		recorder = &lineBreakEventRecorder{}
+	})
+
+	It("should reject a line count below the minimum", func() {
+		stream, err = charts.NewLineBreakStreamForStream(source, 0)
+		Expect(stream).To(BeNil())
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("and the stream is a three line break", func() {
+		BeforeEach(func() {
+			stream, err = charts.NewDefaultLineBreakStreamForStream(source)
+			stream.AddEventSubscription(recorder)
+			for day, closePrice := range []float64{10.0, 11.0, 12.0, 13.0, 11.5, 9.5, 10.5} {
+				source.ReceiveTick(newBar(day+1, closePrice, closePrice, closePrice, closePrice))
+			}
+		})
+
+		It("should add a line for every close beyond the latest line", func() {
+			Expect(err).To(BeNil())
+			lines := stream.Lines()
+			Expect(len(lines)).To(Equal(4))
+			Expect(lines[2].Rising).To(BeTrue())
+			Expect(lines[2].Open).To(Equal(12.0))
+			Expect(lines[2].Close).To(Equal(13.0))
+		})
+
+		It("should only reverse once the close breaks the extreme of the latest three lines", func() {
+			latest, _ := stream.LineFromLatest(0)
+			Expect(latest.Rising).To(BeFalse())
+			Expect(latest.Open).To(Equal(12.0))
+			Expect(latest.Close).To(Equal(9.5))
+			Expect(latest.StreamBarIndex).To(Equal(6))
+		})
+
+		It("should publish each line, flagging reversals", func() {
+			Expect(len(recorder.events)).To(Equal(4))
+			Expect(recorder.events[2].Reversal).To(BeFalse())
+			Expect(recorder.events[3].Reversal).To(BeTrue())
+			Expect(recorder.events[3].LineIndex).To(Equal(3))
+		})
+
+		It("should republish each line as a bar", func() {
+			Expect(len(stream.Data)).To(Equal(4))
+			Expect(stream.Data[3].O()).To(Equal(12.0))
+			Expect(stream.Data[3].H()).To(Equal(12.0))
+			Expect(stream.Data[3].L()).To(Equal(9.5))
+			Expect(stream.Data[3].V()).To(Equal(200.0))
+		})
+	})
+})
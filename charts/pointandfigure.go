@@ -0,0 +1,79 @@
+// Package charts lays out the price streams of gotrade for display
+package charts
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/thetruetrade/gotrade"
+)
+
+// A PointAndFigureChart lays out the columns of a point and figure stream as a grid of boxes, one row per
+// box level from the highest box to the lowest and one column per point and figure column
+type PointAndFigureChart struct {
+	// private variables
+	stream *gotrade.PnFStream
+}
+
+func NewPointAndFigureChart(stream *gotrade.PnFStream) *PointAndFigureChart {
+	return &PointAndFigureChart{stream: stream}
+}
+
+// BoxRange returns the lowest and highest box levels of the chart, ok is false when the chart has no columns
+func (c *PointAndFigureChart) BoxRange() (lowBox int, highBox int, ok bool) {
+	columns := c.stream.Columns()
+	if len(columns) == 0 {
+		return 0, 0, false
+	}
+
+	lowBox, highBox = columns[0].LowBox, columns[0].HighBox
+	for _, column := range columns[1:] {
+		if column.LowBox < lowBox {
+			lowBox = column.LowBox
+		}
+		if column.HighBox > highBox {
+			highBox = column.HighBox
+		}
+	}
+	return lowBox, highBox, true
+}
+
+// Grid returns the rows of the chart from the highest box level to the lowest, each row holds an X, O or
+// space for every column
+func (c *PointAndFigureChart) Grid() [][]rune {
+	lowBox, highBox, ok := c.BoxRange()
+	if !ok {
+		return nil
+	}
+
+	columns := c.stream.Columns()
+	grid := make([][]rune, highBox-lowBox+1)
+	for row := range grid {
+		box := highBox - row
+		grid[row] = make([]rune, len(columns))
+		for i, column := range columns {
+			switch {
+			case box < column.LowBox || box > column.HighBox:
+				grid[row][i] = ' '
+			case column.Direction == gotrade.XColumn:
+				grid[row][i] = 'X'
+			default:
+				grid[row][i] = 'O'
+			}
+		}
+	}
+	return grid
+}
+
+// String renders the chart as text, each row labelled with the price of its box level
+func (c *PointAndFigureChart) String() string {
+	highBox := 0
+	if _, high, ok := c.BoxRange(); ok {
+		highBox = high
+	}
+
+	var buffer bytes.Buffer
+	for row, boxes := range c.Grid() {
+		fmt.Fprintf(&buffer, "%10.2f |%s\n", c.stream.BoxPrice(highBox-row), string(boxes))
+	}
+	return buffer.String()
+}
@@ -0,0 +1,38 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/charts"
+)
+
+var _ = Describe("when laying out a point and figure chart", func() {
+	var (
+		chart *charts.PointAndFigureChart
+	)
+
+	BeforeEach(func() {
+		boxSize, _ := gotrade.NewFixedPnFBoxSize(1.0)
+		stream, _ := gotrade.NewPnFStream(boxSize, 2, gotrade.PnFCloseOnly)
+		for day, closePrice := range []float64{10.0, 12.0, 10.0} {
+			stream.ReceiveDOHLCVTick(newBar(day+1, closePrice, closePrice, closePrice, closePrice), day+1)
+		}
+		chart = charts.NewPointAndFigureChart(stream)
+	})
+
+	It("should span the boxes of every column", func() {
+		lowBox, highBox, ok := chart.BoxRange()
+		Expect(ok).To(BeTrue())
+		Expect(lowBox).To(Equal(10))
+		Expect(highBox).To(Equal(12))
+	})
+
+	It("should lay out a row for each box level from the highest", func() {
+		Expect(chart.Grid()).To(Equal([][]rune{[]rune("X "), []rune("XO"), []rune(" O")}))
+	})
+
+	It("should label each row with its price", func() {
+		Expect(chart.String()).To(Equal("     12.00 |X \n     11.00 |XO\n     10.00 | O\n"))
+	})
+})
@@ -0,0 +1,145 @@
+package charts
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var (
+	ErrBrickSizeMustBeGreaterThanZero = errors.New("Brick size must be greater than zero")
+)
+
+// A RenkoStream republishes the closes of a source data stream as Renko bricks, a stream like any other
+// to which indicators can be attached. A brick is added when the close moves a brick size beyond the top
+// or bottom of the latest brick, so a reversal needs a move of two brick sizes from the latest brick's close.
+//	- the open and close are the brick's prices
+//	- the high and low are the brick's prices, with wicks a rising brick's low and a falling brick's high are the
+//	  extremes reached since the previous brick
+//	- the date is the date of the source bar which completed the brick, a further brick completed by the same bar
+//	  is dated a nanosecond after the previous brick, so the bricks pass the stream's bar validation
+//	- the volume is the volume since the previous brick, on the first brick completed by a source bar
+type RenkoStream struct {
+	*gotrade.DOHLCVStream
+
+	// private variables
+	brickSize   float64
+	showWicks   bool
+	atr         *indicators.AtrWithoutStorage
+	brickTop    float64
+	brickBottom float64
+	hasBase     bool
+	highest     float64
+	lowest      float64
+	volume      float64
+	latestDate  time.Time
+}
+
+// NewRenkoStream creates a Renko stream whose bricks are of the fixed brick size, with or without wicks
+func NewRenkoStream(brickSize float64, showWicks bool) (stream *RenkoStream, err error) {
+	if brickSize <= 0.0 {
+		return nil, ErrBrickSizeMustBeGreaterThanZero
+	}
+	return &RenkoStream{DOHLCVStream: gotrade.NewDOHLCVStream(), brickSize: brickSize, showWicks: showWicks}, nil
+}
+
+// NewAtrRenkoStream creates a Renko stream whose bricks are the size of the first value of the average true range
+// of the source data, no bricks are published until it is known
+func NewAtrRenkoStream(atrPeriod int, showWicks bool) (stream *RenkoStream, err error) {
+	s := RenkoStream{DOHLCVStream: gotrade.NewDOHLCVStream(), showWicks: showWicks}
+	s.atr, err = indicators.NewAtrWithoutStorage(atrPeriod, func(dataItem float64, streamBarIndex int) {
+		if s.brickSize == 0.0 {
+			s.brickSize = dataItem
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// NewRenkoStreamForStream creates a Renko stream of fixed size bricks which subscribes to the source data stream
+func NewRenkoStreamForStream(sourceStream gotrade.DOHLCVStreamSubscriber, brickSize float64, showWicks bool) (stream *RenkoStream, err error) {
+	s, err := NewRenkoStream(brickSize, showWicks)
+	if err != nil {
+		return s, err
+	}
+	sourceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+// NewAtrRenkoStreamForStream creates a Renko stream of average true range sized bricks which subscribes to the source data stream
+func NewAtrRenkoStreamForStream(sourceStream gotrade.DOHLCVStreamSubscriber, atrPeriod int, showWicks bool) (stream *RenkoStream, err error) {
+	s, err := NewAtrRenkoStream(atrPeriod, showWicks)
+	if err != nil {
+		return s, err
+	}
+	sourceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+// BrickSize returns the size of the bricks, 0 while an average true range brick size is not yet known
+func (s *RenkoStream) BrickSize() float64 {
+	return s.brickSize
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, publishing the bricks it completes
+func (s *RenkoStream) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if s.brickSize == 0.0 {
+		s.atr.ReceiveDOHLCVTick(tickData, streamBarIndex)
+		if s.brickSize == 0.0 {
+			return
+		}
+	}
+
+	// the first close is the base from which the first brick must move
+	if !s.hasBase {
+		s.brickTop = tickData.C()
+		s.brickBottom = tickData.C()
+		s.highest = tickData.C()
+		s.lowest = tickData.C()
+		s.hasBase = true
+		return
+	}
+
+	s.highest = math.Max(s.highest, tickData.H())
+	s.lowest = math.Min(s.lowest, tickData.L())
+	s.volume += tickData.V()
+
+	for tickData.C() >= s.brickTop+s.brickSize {
+		s.publishBrick(s.brickTop, s.brickTop+s.brickSize, tickData)
+	}
+
+	for tickData.C() <= s.brickBottom-s.brickSize {
+		s.publishBrick(s.brickBottom, s.brickBottom-s.brickSize, tickData)
+	}
+}
+
+func (s *RenkoStream) publishBrick(openPrice float64, closePrice float64, tickData gotrade.DOHLCV) {
+	high := math.Max(openPrice, closePrice)
+	low := math.Min(openPrice, closePrice)
+	if s.showWicks {
+		// the wick shows how far the price retraced against the brick's direction while it formed
+		if closePrice > openPrice {
+			low = math.Min(low, s.lowest)
+		} else {
+			high = math.Max(high, s.highest)
+		}
+	}
+
+	date := tickData.D()
+	if !date.After(s.latestDate) && !s.latestDate.IsZero() {
+		date = s.latestDate.Add(time.Nanosecond)
+	}
+	s.latestDate = date
+
+	s.ReceiveTick(gotrade.NewDOHLCVDataItem(date, openPrice, high, low, closePrice, s.volume))
+
+	s.brickTop = math.Max(openPrice, closePrice)
+	s.brickBottom = math.Min(openPrice, closePrice)
+	s.highest = closePrice
+	s.lowest = closePrice
+	s.volume = 0.0
+}
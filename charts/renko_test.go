@@ -0,0 +1,102 @@
+package charts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/charts"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when building a renko stream", func() {
+	var (
+		source *gotrade.InterDayDOHLCVStream
+		stream *charts.RenkoStream
+		err    error
+	)
+
+	BeforeEach(func() {
+		source = gotrade.NewDailyDOHLCVStream()
+		source.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+	})
+
+	It("should reject a brick size below the minimum", func() {
+		stream, err = charts.NewRenkoStreamForStream(source, 0.0, false)
+		Expect(stream).To(BeNil())
+		Expect(err).To(Equal(charts.ErrBrickSizeMustBeGreaterThanZero))
+	})
+
+	Context("and the bricks are a fixed size with wicks", func() {
+		var (
+			sma *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			stream, err = charts.NewRenkoStreamForStream(source, 1.0, true)
+			stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+			// the bricks completed by a single bar must not be rejected as duplicates
+			stream.SetBarValidationPolicy(gotrade.RejectInvalidBars)
+			sma, _ = indicators.NewSmaForStream(stream, 2, gotrade.UseClosePrice)
+			source.ReceiveTick(newBar(1, 10.0, 10.0, 10.0, 10.0))
+			source.ReceiveTick(newBar(2, 10.0, 11.5, 9.5, 11.2))
+			source.ReceiveTick(newBar(3, 11.2, 13.2, 11.0, 13.1))
+			source.ReceiveTick(newBar(4, 11.5, 11.8, 11.4, 11.5))
+			source.ReceiveTick(newBar(7, 10.9, 11.0, 10.8, 10.9))
+		})
+
+		It("should add a brick for every brick size the close moves", func() {
+			Expect(err).To(BeNil())
+			Expect(len(stream.Data)).To(Equal(4))
+			Expect(stream.Data[0].O()).To(Equal(10.0))
+			Expect(stream.Data[0].C()).To(Equal(11.0))
+			Expect(stream.Data[2].C()).To(Equal(13.0))
+		})
+
+		It("should only reverse once the close moves two bricks from the latest brick's close", func() {
+			Expect(stream.Data[3].O()).To(Equal(12.0))
+			Expect(stream.Data[3].C()).To(Equal(11.0))
+			Expect(stream.Data[3].D()).To(Equal(source.Data[4].D()))
+		})
+
+		It("should show how far the price retraced while the brick formed", func() {
+			Expect(stream.Data[0].L()).To(Equal(9.5))
+			Expect(stream.Data[0].H()).To(Equal(11.0))
+			Expect(stream.Data[3].H()).To(Equal(13.0))
+		})
+
+		It("should date each brick and give it the volume since the previous brick", func() {
+			Expect(stream.Data[1].D()).To(Equal(source.Data[2].D()))
+			Expect(stream.Data[1].V()).To(Equal(100.0))
+			Expect(stream.Data[2].V()).To(Equal(0.0))
+			Expect(stream.Data[3].V()).To(Equal(200.0))
+		})
+
+		It("should date a further brick completed by the same bar a nanosecond after the previous brick", func() {
+			Expect(stream.Data[2].D()).To(Equal(source.Data[2].D().Add(time.Nanosecond)))
+		})
+
+		It("an indicator attached to the stream should receive the bricks", func() {
+			Expect(sma.Data).To(Equal([]float64{11.5, 12.5, 12.0}))
+		})
+	})
+
+	Context("and the bricks are sized by the average true range", func() {
+		BeforeEach(func() {
+			stream, err = charts.NewAtrRenkoStreamForStream(source, 2, false)
+			source.ReceiveTick(newBar(1, 10.0, 11.0, 9.0, 10.0))
+		})
+
+		It("should not know the brick size before the average true range is available", func() {
+			Expect(err).To(BeNil())
+			Expect(stream.BrickSize()).To(Equal(0.0))
+		})
+
+		It("should size the bricks by the first average true range", func() {
+			for day := 2; day <= 4; day++ {
+				source.ReceiveTick(newBar(day, 10.0, 11.0, 9.0, 10.0))
+			}
+			Expect(stream.BrickSize()).To(Equal(2.0))
+		})
+	})
+})
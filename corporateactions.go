@@ -0,0 +1,206 @@
+package gotrade
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrSplitRatioMustBeGreaterThanZero     = errors.New("Split ratio must be greater than zero")
+	ErrDividendAmountMustBeGreaterThanZero = errors.New("Dividend amount must be greater than zero")
+	ErrNewSymbolIsEmpty                    = errors.New("A new symbol is required")
+)
+
+type CorporateActionType int
+
+const (
+	SplitAction CorporateActionType = iota
+	CashDividendAction
+	SymbolChangeAction
+)
+
+// A CorporateAction is an event on its ex date which changes the meaning of an instrument's prices or its symbol
+//	- split: ratio new shares for each old share, e.g. 2 for a 2 for 1 split, 0.1 for a 1 for 10 consolidation
+//	- cash dividend: amount paid per share, in the price currency before the ex date
+//	- symbol change: the symbol the instrument trades under from the ex date
+type CorporateAction struct {
+	// private variables
+	exDate     time.Time
+	actionType CorporateActionType
+	ratio      float64
+	amount     float64
+	newSymbol  string
+}
+
+func NewSplit(exDate time.Time, ratio float64) (action *CorporateAction, err error) {
+	if ratio <= 0.0 {
+		return nil, ErrSplitRatioMustBeGreaterThanZero
+	}
+	return &CorporateAction{exDate: exDate, actionType: SplitAction, ratio: ratio}, nil
+}
+
+func NewCashDividend(exDate time.Time, amount float64) (action *CorporateAction, err error) {
+	if amount <= 0.0 {
+		return nil, ErrDividendAmountMustBeGreaterThanZero
+	}
+	return &CorporateAction{exDate: exDate, actionType: CashDividendAction, amount: amount}, nil
+}
+
+func NewSymbolChange(exDate time.Time, newSymbol string) (action *CorporateAction, err error) {
+	if newSymbol == "" {
+		return nil, ErrNewSymbolIsEmpty
+	}
+	return &CorporateAction{exDate: exDate, actionType: SymbolChangeAction, newSymbol: newSymbol}, nil
+}
+
+func (a *CorporateAction) ExDate() time.Time {
+	return a.exDate
+}
+
+func (a *CorporateAction) ActionType() CorporateActionType {
+	return a.actionType
+}
+
+func (a *CorporateAction) Ratio() float64 {
+	return a.ratio
+}
+
+func (a *CorporateAction) Amount() float64 {
+	return a.amount
+}
+
+func (a *CorporateAction) NewSymbol() string {
+	return a.newSymbol
+}
+
+type corporateActionsByExDate []*CorporateAction
+
+func (s corporateActionsByExDate) Len() int           { return len(s) }
+func (s corporateActionsByExDate) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s corporateActionsByExDate) Less(i, j int) bool { return s[i].exDate.Before(s[j].exDate) }
+
+type AdjustmentMode int
+
+const (
+	// prices before each action are adjusted to be comparable with the latest prices, the bars are held until
+	// the adjuster is flushed as a later action changes the adjustment of every bar before it
+	BackAdjust AdjustmentMode = iota
+	// prices from each action onwards are adjusted to be comparable with the earliest prices, the bars are
+	// passed on as they are received
+	ForwardAdjust
+)
+
+// A CorporateActionAdjuster adjusts the bars it receives for an instrument's splits and cash dividends before
+// passing them on to its target, e.g. a DOHLCVStream with indicators attached. Prices are adjusted by the split
+// ratio and by the proportion of the close before the ex date paid as a dividend, volumes by the split ratio.
+// The bars must be received in date order.
+type CorporateActionAdjuster struct {
+	// private variables
+	target           DOHLCVStreamTickReceiver
+	actions          []*CorporateAction
+	mode             AdjustmentMode
+	symbol           string
+	nextAction       int
+	priceFactor      float64
+	volumeFactor     float64
+	previousClose    float64
+	hasPreviousClose bool
+	pending          []DOHLCV
+}
+
+// NewCorporateActionAdjuster creates an adjuster for the actions of the instrument trading under the symbol
+// on the date of the first bar
+func NewCorporateActionAdjuster(symbol string, actions []*CorporateAction, mode AdjustmentMode, target DOHLCVStreamTickReceiver) *CorporateActionAdjuster {
+	sorted := make([]*CorporateAction, len(actions))
+	copy(sorted, actions)
+	sort.Stable(corporateActionsByExDate(sorted))
+
+	return &CorporateActionAdjuster{target: target,
+		actions:      sorted,
+		mode:         mode,
+		symbol:       symbol,
+		priceFactor:  1.0,
+		volumeFactor: 1.0}
+}
+
+// Symbol returns the symbol the instrument traded under on the date of the latest bar received
+func (a *CorporateActionAdjuster) Symbol() string {
+	return a.symbol
+}
+
+// ReceiveTick adjusts the bar, passing it on to the target when forward adjusting and holding it until
+// the adjuster is flushed when back adjusting
+func (a *CorporateActionAdjuster) ReceiveTick(tickData DOHLCV) {
+	for ; a.nextAction < len(a.actions) && !tickData.D().Before(a.actions[a.nextAction].exDate); a.nextAction++ {
+		action := a.actions[a.nextAction]
+		switch action.actionType {
+		case SymbolChangeAction:
+			a.symbol = action.newSymbol
+		case SplitAction:
+			a.priceFactor *= action.ratio
+			a.volumeFactor /= action.ratio
+		case CashDividendAction:
+			// a dividend before the first bar, or larger than the close, has no meaningful adjustment
+			if a.hasPreviousClose && action.amount < a.previousClose {
+				a.priceFactor *= a.previousClose / (a.previousClose - action.amount)
+			}
+		}
+	}
+
+	a.previousClose = tickData.C()
+	a.hasPreviousClose = true
+
+	if a.mode == BackAdjust {
+		a.pending = append(a.pending, tickData)
+		return
+	}
+	a.target.ReceiveTick(adjustBar(tickData, a.priceFactor, a.volumeFactor))
+}
+
+// Flush back adjusts the bars held since the last flush for the actions on or before the latest bar,
+// passing them on to the target in date order. Forward adjusted bars are never held.
+func (a *CorporateActionAdjuster) Flush() {
+	if len(a.pending) == 0 {
+		return
+	}
+
+	// walk back from the latest bar, accumulating the adjustment of each action as its ex date is passed
+	adjusted := make([]DOHLCV, len(a.pending))
+	priceFactor := 1.0
+	volumeFactor := 1.0
+	action := a.nextAction - 1
+	for i := len(a.pending) - 1; i >= 0; i-- {
+		bar := a.pending[i]
+		for ; action >= 0 && bar.D().Before(a.actions[action].exDate); action-- {
+			switch a.actions[action].actionType {
+			case SplitAction:
+				priceFactor /= a.actions[action].ratio
+				volumeFactor *= a.actions[action].ratio
+			case CashDividendAction:
+				if a.actions[action].amount < bar.C() {
+					priceFactor *= 1.0 - a.actions[action].amount/bar.C()
+				}
+			}
+		}
+		adjusted[i] = adjustBar(bar, priceFactor, volumeFactor)
+	}
+
+	a.pending = nil
+	for _, bar := range adjusted {
+		a.target.ReceiveTick(bar)
+	}
+}
+
+func adjustBar(tickData DOHLCV, priceFactor float64, volumeFactor float64) DOHLCV {
+	if priceFactor == 1.0 && volumeFactor == 1.0 {
+		return tickData
+	}
+
+	return NewDOHLCVDataItem(tickData.D(),
+		tickData.O()*priceFactor,
+		tickData.H()*priceFactor,
+		tickData.L()*priceFactor,
+		tickData.C()*priceFactor,
+		tickData.V()*volumeFactor)
+}
@@ -0,0 +1,100 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+)
+
+var _ = Describe("when adjusting bars for corporate actions", func() {
+	var (
+		stream   *gotrade.InterDayDOHLCVStream
+		adjuster *gotrade.CorporateActionAdjuster
+		actions  []*gotrade.CorporateAction
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		split, _ := gotrade.NewSplit(newDatedTick(3, 0.0).D(), 2.0)
+		dividend, _ := gotrade.NewCashDividend(newDatedTick(5, 0.0).D(), 5.0)
+		symbolChange, _ := gotrade.NewSymbolChange(newDatedTick(4, 0.0).D(), "NEW")
+		actions = []*gotrade.CorporateAction{dividend, symbolChange, split}
+	})
+
+	It("should reject an invalid split ratio", func() {
+		_, err := gotrade.NewSplit(newDatedTick(3, 0.0).D(), 0.0)
+		Expect(err).To(Equal(gotrade.ErrSplitRatioMustBeGreaterThanZero))
+	})
+
+	It("should reject an invalid dividend amount", func() {
+		_, err := gotrade.NewCashDividend(newDatedTick(3, 0.0).D(), -1.0)
+		Expect(err).To(Equal(gotrade.ErrDividendAmountMustBeGreaterThanZero))
+	})
+
+	Context("and the bars are back adjusted", func() {
+		BeforeEach(func() {
+			adjuster = gotrade.NewCorporateActionAdjuster("OLD", actions, gotrade.BackAdjust, stream)
+			adjuster.ReceiveTick(newDatedTick(1, 100.0))
+			adjuster.ReceiveTick(newDatedTick(2, 100.0))
+			adjuster.ReceiveTick(newDatedTick(3, 50.0))
+			adjuster.ReceiveTick(newDatedTick(4, 50.0))
+			adjuster.ReceiveTick(newDatedTick(5, 45.0))
+		})
+
+		It("should hold the bars until flushed", func() {
+			Expect(stream.Data).To(BeEmpty())
+		})
+
+		It("should take on the symbol change", func() {
+			Expect(adjuster.Symbol()).To(Equal("NEW"))
+		})
+
+		Context("and the adjuster is flushed", func() {
+			BeforeEach(func() {
+				adjuster.Flush()
+			})
+
+			It("should leave the latest bar unadjusted", func() {
+				Expect(len(stream.Data)).To(Equal(5))
+				Expect(stream.Data[4].C()).To(Equal(45.0))
+			})
+
+			It("should adjust the bars before the dividend by the proportion paid", func() {
+				Expect(stream.Data[3].C()).To(BeNumerically("~", 45.0, 1e-9))
+			})
+
+			It("should adjust the bars before the split by the split ratio", func() {
+				Expect(stream.Data[1].C()).To(BeNumerically("~", 45.0, 1e-9))
+				Expect(stream.Data[1].V()).To(Equal(200.0))
+				Expect(stream.Data[2].V()).To(Equal(100.0))
+			})
+		})
+	})
+
+	Context("and the bars are forward adjusted", func() {
+		BeforeEach(func() {
+			adjuster = gotrade.NewCorporateActionAdjuster("OLD", actions, gotrade.ForwardAdjust, stream)
+			adjuster.ReceiveTick(newDatedTick(2, 100.0))
+			adjuster.ReceiveTick(newDatedTick(3, 50.0))
+			adjuster.ReceiveTick(newDatedTick(4, 50.0))
+			adjuster.ReceiveTick(newDatedTick(5, 45.0))
+		})
+
+		It("should pass the bars on as they are received", func() {
+			Expect(len(stream.Data)).To(Equal(4))
+		})
+
+		It("should leave the bars before the first action unadjusted", func() {
+			Expect(stream.Data[0].C()).To(Equal(100.0))
+		})
+
+		It("should adjust the bars from the split by the split ratio", func() {
+			Expect(stream.Data[1].C()).To(Equal(100.0))
+			Expect(stream.Data[1].V()).To(Equal(50.0))
+		})
+
+		It("should adjust the bars from the dividend by the proportion paid", func() {
+			Expect(stream.Data[3].C()).To(BeNumerically("~", 100.0, 1e-9))
+		})
+	})
+})
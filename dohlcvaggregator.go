@@ -0,0 +1,202 @@
+package gotrade
+
+import (
+	"math"
+	"time"
+)
+
+// PartialBarPolicy determines what happens to aggregated bars which do not cover their whole period,
+// i.e. the first and last bars built from a source stream that starts or ends part way through a period.
+type PartialBarPolicy int
+
+const (
+	// partial bars are published like any other bar
+	EmitPartialBars PartialBarPolicy = iota
+	// partial bars at the edges of the source data are discarded
+	DropPartialBars
+)
+
+// A DOHLCVBarAggregator builds inter day bars of a longer period, e.g. weekly or monthly bars,
+// from the bars of a finer grained source stream and publishes each completed bar to a target.
+//	- the open is the first open of the period
+//	- the high is the maximum high of the period
+//	- the low is the minimum low of the period
+//	- the close is the last close of the period
+//	- the volume is the sum of the volumes of the period
+//	- the date is the date of the last source bar in the period
+// Weeks are ISO weeks running from Monday to Sunday, months run to the last day of the calendar month.
+type DOHLCVBarAggregator struct {
+	// private variables
+	streamBarType      interDayBarType
+	partialBarPolicy   PartialBarPolicy
+	calendar           *TradingCalendar
+	target             DOHLCVStreamTickReceiver
+	currentBar         *DOHLCVDataItem
+	currentPeriodStart time.Time
+	currentFirstDate   time.Time
+	firstPeriodHandled bool
+
+	// the bar under construction prior to the latest source bar, for revising the latest source bar
+	latestBarIndex     int
+	barBeforeLatest    DOHLCVDataItem
+	hasBarBeforeLatest bool
+}
+
+// NewDOHLCVBarAggregator creates a DOHLCVBarAggregator which publishes bars of the streamBarType to the target
+func NewDOHLCVBarAggregator(streamBarType interDayBarType, target DOHLCVStreamTickReceiver) *DOHLCVBarAggregator {
+	agg := DOHLCVBarAggregator{streamBarType: streamBarType,
+		partialBarPolicy: EmitPartialBars,
+		target:           target}
+	return &agg
+}
+
+// NewDOHLCVBarAggregatorForStream creates a DOHLCVBarAggregator which consumes the bars of a source data stream
+func NewDOHLCVBarAggregatorForStream(sourceStream DOHLCVStreamSubscriber, streamBarType interDayBarType, target DOHLCVStreamTickReceiver) *DOHLCVBarAggregator {
+	agg := NewDOHLCVBarAggregator(streamBarType, target)
+	sourceStream.AddTickSubscription(agg)
+	return agg
+}
+
+// SetPartialBarPolicy sets how bars that do not cover their whole period are handled, the default is EmitPartialBars
+func (agg *DOHLCVBarAggregator) SetPartialBarPolicy(policy PartialBarPolicy) {
+	agg.partialBarPolicy = policy
+}
+
+// SetTradingCalendar sets the calendar used to decide if a bar covers its whole period, so that a period
+// starting or ending on a holiday is not partial. By default every weekday is a trading day.
+func (agg *DOHLCVBarAggregator) SetTradingCalendar(calendar *TradingCalendar) {
+	agg.calendar = calendar
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (agg *DOHLCVBarAggregator) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	periodStart := agg.periodStart(tickData.D())
+
+	// a tick from a different period completes the bar under construction
+	if agg.currentBar != nil && !periodStart.Equal(agg.currentPeriodStart) {
+		agg.publish(false)
+	}
+
+	agg.latestBarIndex = streamBarIndex
+	agg.hasBarBeforeLatest = agg.currentBar != nil
+	if agg.hasBarBeforeLatest {
+		agg.barBeforeLatest = *agg.currentBar
+	}
+
+	agg.merge(tickData, periodStart)
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, revising the bar under construction.
+// The revision is expected to fall in the same period as the tick it revises, a revision of an earlier tick is ignored.
+func (agg *DOHLCVBarAggregator) ReceiveDOHLCVTickUpdate(tickData DOHLCV, streamBarIndex int) {
+	if streamBarIndex != agg.latestBarIndex || agg.currentBar == nil {
+		if streamBarIndex > agg.latestBarIndex {
+			agg.ReceiveDOHLCVTick(tickData, streamBarIndex)
+		}
+		return
+	}
+
+	if agg.hasBarBeforeLatest {
+		bar := agg.barBeforeLatest
+		agg.currentBar = &bar
+	} else {
+		agg.currentBar = nil
+	}
+	agg.merge(tickData, agg.currentPeriodStart)
+}
+
+// merge adds the source bar to the bar under construction, starting a new bar if there is none
+func (agg *DOHLCVBarAggregator) merge(tickData DOHLCV, periodStart time.Time) {
+	if agg.currentBar == nil {
+		agg.currentPeriodStart = periodStart
+		agg.currentFirstDate = tickData.D()
+		agg.currentBar = NewDOHLCVDataItem(tickData.D(), tickData.O(), tickData.H(), tickData.L(), tickData.C(), tickData.V())
+		return
+	}
+
+	agg.currentBar.date = tickData.D()
+	agg.currentBar.highPrice = math.Max(agg.currentBar.highPrice, tickData.H())
+	agg.currentBar.lowPrice = math.Min(agg.currentBar.lowPrice, tickData.L())
+	agg.currentBar.closePrice = tickData.C()
+	agg.currentBar.volumePrice += tickData.V()
+}
+
+// Flush publishes the bar under construction, for use once the source data is exhausted
+func (agg *DOHLCVBarAggregator) Flush() {
+	if agg.currentBar != nil {
+		agg.publish(true)
+	}
+}
+
+func (agg *DOHLCVBarAggregator) publish(isLastBar bool) {
+	bar := agg.currentBar
+	agg.currentBar = nil
+
+	// only the bar of the first period can start part way through its period, whether or not it is published
+	isFirstBar := !agg.firstPeriodHandled
+	agg.firstPeriodHandled = true
+
+	if agg.partialBarPolicy == DropPartialBars {
+		// the first bar is partial when the source data starts after the first trading day of the period
+		if isFirstBar && agg.hasTradingDayBetween(agg.currentPeriodStart, startOfDay(agg.currentFirstDate).AddDate(0, 0, -1)) {
+			return
+		}
+
+		// the last bar is partial when the source data ends before the last trading day of the period
+		if isLastBar && agg.hasTradingDayBetween(startOfDay(bar.date).AddDate(0, 0, 1), agg.periodEnd(agg.currentPeriodStart)) {
+			return
+		}
+	}
+
+	agg.target.ReceiveTick(bar)
+}
+
+// periodStart returns the first day of the period containing the date
+func (agg *DOHLCVBarAggregator) periodStart(date time.Time) time.Time {
+	day := startOfDay(date)
+	switch agg.streamBarType {
+	case WeeklyBar:
+		// iso weeks start on a monday
+		daysSinceMonday := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -daysSinceMonday)
+	case MonthlyBar:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// periodEnd returns the last day of the period starting on periodStart
+func (agg *DOHLCVBarAggregator) periodEnd(periodStart time.Time) time.Time {
+	switch agg.streamBarType {
+	case WeeklyBar:
+		return periodStart.AddDate(0, 0, 6)
+	case MonthlyBar:
+		return periodStart.AddDate(0, 1, -1)
+	}
+	return periodStart
+}
+
+// startOfDay returns midnight of the date in the date's location
+func startOfDay(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, date.Location())
+}
+
+// hasTradingDayBetween returns true if there is a trading day in the inclusive date range, every weekday
+// is a trading day without a trading calendar
+func (agg *DOHLCVBarAggregator) hasTradingDayBetween(from time.Time, to time.Time) bool {
+	if agg.calendar != nil {
+		return agg.calendar.hasTradingDayBetween(from, to)
+	}
+	return hasWeekdayBetween(from, to)
+}
+
+// hasWeekdayBetween returns true if there is a weekday in the inclusive date range
+func hasWeekdayBetween(from time.Time, to time.Time) bool {
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+var _ = Describe("when aggregating a daily stream into longer bars", func() {
+	var (
+		dailyStream  *gotrade.InterDayDOHLCVStream
+		weeklyStream *gotrade.InterDayDOHLCVStream
+		dailyData    []gotrade.DOHLCV
+	)
+
+	BeforeEach(func() {
+		dailyData = nil
+		// tuesday the 1st of january 2013 to friday the 11th of january 2013
+		date := time.Date(2013, time.January, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 11; i++ {
+			day := date.AddDate(0, 0, i)
+			if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+				continue
+			}
+			price := float64(10 + i)
+			dailyData = append(dailyData, gotrade.NewDOHLCVDataItem(day, price, price+2.0, price-1.0, price+1.0, 100.0))
+		}
+
+		dailyStream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Context("into a weekly stream", func() {
+		BeforeEach(func() {
+			weeklyStream = gotrade.NewWeeklyDOHLCVStreamForStream(dailyStream)
+			for i := range dailyData {
+				dailyStream.ReceiveTick(dailyData[i])
+			}
+		})
+
+		It("should only publish the weeks that have completed", func() {
+			Expect(len(weeklyStream.Data)).To(Equal(1))
+		})
+
+		It("should build the first week from the first open, max high, min low, last close and summed volume", func() {
+			week := weeklyStream.Data[0]
+			Expect(week.O()).To(Equal(10.0))
+			Expect(week.H()).To(Equal(15.0))
+			Expect(week.L()).To(Equal(9.0))
+			Expect(week.C()).To(Equal(14.0))
+			Expect(week.V()).To(Equal(400.0))
+			Expect(week.D()).To(Equal(time.Date(2013, time.January, 4, 0, 0, 0, 0, time.UTC)))
+		})
+
+		Context("and the stream is flushed", func() {
+			BeforeEach(func() {
+				weeklyStream.Flush()
+			})
+
+			It("should publish the last week", func() {
+				Expect(len(weeklyStream.Data)).To(Equal(2))
+				Expect(weeklyStream.Data[1].O()).To(Equal(16.0))
+				Expect(weeklyStream.Data[1].C()).To(Equal(21.0))
+				Expect(weeklyStream.Data[1].V()).To(Equal(500.0))
+			})
+		})
+	})
+
+	Context("into a weekly stream while the daily bars are being revised", func() {
+		BeforeEach(func() {
+			weeklyStream = gotrade.NewWeeklyDOHLCVStreamForStream(dailyStream)
+			for i := range dailyData {
+				bar := dailyData[i]
+				dailyStream.ReceiveTick(gotrade.NewDOHLCVDataItem(bar.D(), bar.O(), bar.H()+5.0, bar.L()-5.0, bar.C(), bar.V()*3.0))
+				dailyStream.ReceiveTickUpdate(bar)
+			}
+			weeklyStream.Flush()
+		})
+
+		It("should build each week from the final value of each daily bar", func() {
+			Expect(len(weeklyStream.Data)).To(Equal(2))
+			Expect(weeklyStream.Data[0].H()).To(Equal(15.0))
+			Expect(weeklyStream.Data[0].L()).To(Equal(9.0))
+			Expect(weeklyStream.Data[0].V()).To(Equal(400.0))
+			Expect(weeklyStream.Data[1].O()).To(Equal(16.0))
+			Expect(weeklyStream.Data[1].V()).To(Equal(500.0))
+		})
+	})
+
+	Context("into a weekly stream which drops partial bars", func() {
+		BeforeEach(func() {
+			weeklyStream = gotrade.NewWeeklyDOHLCVStreamForStream(dailyStream)
+			weeklyStream.SetPartialBarPolicy(gotrade.DropPartialBars)
+			for i := range dailyData {
+				dailyStream.ReceiveTick(dailyData[i])
+			}
+			weeklyStream.Flush()
+		})
+
+		It("should drop the first week which starts after the monday", func() {
+			Expect(len(weeklyStream.Data)).To(Equal(1))
+			Expect(weeklyStream.Data[0].D()).To(Equal(time.Date(2013, time.January, 11, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Context("into a monthly stream which drops partial bars", func() {
+		var monthlyStream *gotrade.InterDayDOHLCVStream
+
+		BeforeEach(func() {
+			monthlyStream = gotrade.NewMonthlyDOHLCVStreamForStream(dailyStream)
+			monthlyStream.SetPartialBarPolicy(gotrade.DropPartialBars)
+			for i := range dailyData {
+				dailyStream.ReceiveTick(dailyData[i])
+			}
+			monthlyStream.Flush()
+		})
+
+		It("should drop the month which ends before the month end", func() {
+			Expect(monthlyStream.Data).To(BeEmpty())
+		})
+	})
+
+	Context("into a weekly stream which drops partial bars and a later week starts after its monday", func() {
+		BeforeEach(func() {
+			weeklyStream = gotrade.NewWeeklyDOHLCVStreamForStream(dailyStream)
+			weeklyStream.SetPartialBarPolicy(gotrade.DropPartialBars)
+			// wednesday the 2nd of january 2013 to friday the 18th of january 2013 without monday the 7th
+			date := time.Date(2013, time.January, 2, 0, 0, 0, 0, time.UTC)
+			for i := 0; i < 17; i++ {
+				day := date.AddDate(0, 0, i)
+				if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || day.Day() == 7 {
+					continue
+				}
+				dailyStream.ReceiveTick(gotrade.NewDOHLCVDataItem(day, 10.0, 12.0, 9.0, 11.0, 100.0))
+			}
+			weeklyStream.Flush()
+		})
+
+		It("should only drop the first week, a missing bar within the series does not make a week partial", func() {
+			Expect(len(weeklyStream.Data)).To(Equal(2))
+			Expect(weeklyStream.Data[0].D()).To(Equal(time.Date(2013, time.January, 11, 0, 0, 0, 0, time.UTC)))
+			Expect(weeklyStream.Data[0].V()).To(Equal(400.0))
+			Expect(weeklyStream.Data[1].D()).To(Equal(time.Date(2013, time.January, 18, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Context("into a weekly stream across the end of an iso week year", func() {
+		BeforeEach(func() {
+			weeklyStream = gotrade.NewWeeklyDOHLCVStreamForStream(dailyStream)
+			// monday the 28th of december 2020 to friday the 8th of january 2021, the 28th starts iso week 53 of 2020
+			date := time.Date(2020, time.December, 28, 0, 0, 0, 0, time.UTC)
+			for i := 0; i < 12; i++ {
+				day := date.AddDate(0, 0, i)
+				if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+					continue
+				}
+				dailyStream.ReceiveTick(gotrade.NewDOHLCVDataItem(day, float64(10+i), float64(12+i), float64(9+i), float64(11+i), 100.0))
+			}
+			weeklyStream.Flush()
+		})
+
+		It("should build iso week 53 from the days either side of the new year", func() {
+			Expect(len(weeklyStream.Data)).To(Equal(2))
+			week := weeklyStream.Data[0]
+			year, isoWeek := week.D().ISOWeek()
+			Expect(year).To(Equal(2020))
+			Expect(isoWeek).To(Equal(53))
+			Expect(week.D()).To(Equal(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(week.O()).To(Equal(10.0))
+			Expect(week.C()).To(Equal(15.0))
+			Expect(week.V()).To(Equal(500.0))
+		})
+
+		It("should start the next week on monday the 4th of january", func() {
+			year, isoWeek := weeklyStream.Data[1].D().ISOWeek()
+			Expect(year).To(Equal(2021))
+			Expect(isoWeek).To(Equal(1))
+			Expect(weeklyStream.Data[1].O()).To(Equal(17.0))
+			Expect(weeklyStream.Data[1].V()).To(Equal(500.0))
+		})
+	})
+})
@@ -6,6 +6,7 @@ package gotrade
 import (
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,10 +14,18 @@ type DOHLCVStreamTickReceiver interface {
 	ReceiveTick(tickData DOHLCV)
 }
 
+type DOHLCVStreamTickUpdateReceiver interface {
+	ReceiveTickUpdate(tickData DOHLCV)
+}
+
 type DOHLCVStreamSubscriber interface {
 	AddTickSubscription(subscriber DOHLCVTickReceiver)
 }
 
+type DOHLCVStreamUnsubscriber interface {
+	RemoveTickSubscription(subscriber DOHLCVTickReceiver)
+}
+
 type DataStreamHolder interface {
 	MinValue() float64
 	MaxValue() float64
@@ -39,16 +48,76 @@ const (
 )
 
 type DOHLCVStream struct {
-	Data           []DOHLCV
-	subscribers    []DOHLCVTickReceiver
-	streamBarIndex int
-	minValue       float64
-	maxValue       float64
+	Data             []DOHLCV
+	subscribers      []*DOHLCVTickSubscription
+	subscribersMutex sync.Mutex
+	dispatcher       TickDispatcher
+	history          *DOHLCVRingBuffer
+	streamBarIndex   int
+	minValue         float64
+	maxValue         float64
+	previousMinValue float64
+	previousMaxValue float64
+	validationPolicy BarValidationPolicy
+	instrument       *Instrument
+	calendar         *TradingCalendar
+	gapPolicy        TradingGapPolicy
+	tradingGaps      []time.Time
+}
+
+// A DOHLCVTickSubscription is the handle to a subscriber's subscription to a DOHLCVStream
+type DOHLCVTickSubscription struct {
+	stream     *DOHLCVStream
+	subscriber DOHLCVTickReceiver
+	active     int32
+}
+
+// Subscriber returns the receiver of the subscription's ticks
+func (s *DOHLCVTickSubscription) Subscriber() DOHLCVTickReceiver {
+	return s.subscriber
+}
+
+// IsActive returns true until the subscription has been unsubscribed
+func (s *DOHLCVTickSubscription) IsActive() bool {
+	return atomic.LoadInt32(&s.active) == 1
+}
+
+// ReceiveDOHLCVTick passes the tick on to the subscriber while the subscription is active
+func (s *DOHLCVTickSubscription) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	if s.IsActive() {
+		s.subscriber.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// ReceiveDOHLCVTickUpdate passes the revised tick on to the subscriber while the subscription is active,
+// subscribers which do not implement DOHLCVTickUpdateReceiver ignore revisions
+func (s *DOHLCVTickSubscription) ReceiveDOHLCVTickUpdate(tickData DOHLCV, streamBarIndex int) {
+	if s.IsActive() {
+		if receiver, ok := s.subscriber.(DOHLCVTickUpdateReceiver); ok {
+			receiver.ReceiveDOHLCVTickUpdate(tickData, streamBarIndex)
+		}
+	}
+}
+
+// Unsubscribe stops the subscriber receiving any further ticks, it is safe to call while
+// the stream is notifying its subscribers, including from within the subscriber itself
+func (s *DOHLCVTickSubscription) Unsubscribe() {
+	if atomic.CompareAndSwapInt32(&s.active, 1, 0) {
+		s.stream.removeSubscription(s)
+	}
+}
+
+// NewDOHLCVStream creates a stream of bars which are received directly, e.g. bars derived from another stream
+func NewDOHLCVStream() *DOHLCVStream {
+	return &DOHLCVStream{streamBarIndex: 0,
+		minValue: math.MaxFloat64,
+		maxValue: math.SmallestNonzeroFloat64}
 }
 
 type InterDayDOHLCVStream struct {
 	*DOHLCVStream
 	streamBarType interDayBarType
+	aggregator    *DOHLCVBarAggregator
 }
 
 func NewInterDayDOHLCVStream(streamBarType interDayBarType) *InterDayDOHLCVStream {
@@ -56,6 +125,7 @@ func NewInterDayDOHLCVStream(streamBarType interDayBarType) *InterDayDOHLCVStrea
 		minValue: math.MaxFloat64,
 		maxValue: math.SmallestNonzeroFloat64},
 		streamBarType: streamBarType}
+	s.aggregator = NewDOHLCVBarAggregator(streamBarType, s.DOHLCVStream)
 	return &s
 }
 
@@ -71,10 +141,125 @@ func NewMonthlyDOHLCVStream() *InterDayDOHLCVStream {
 	return NewInterDayDOHLCVStream(MonthlyBar)
 }
 
+// NewInterDayDOHLCVStreamForInstrument creates an inter day stream of the instrument's bars
+func NewInterDayDOHLCVStreamForInstrument(instrument *Instrument, streamBarType interDayBarType) *InterDayDOHLCVStream {
+	s := NewInterDayDOHLCVStream(streamBarType)
+	s.instrument = instrument
+	return s
+}
+
+func NewDailyDOHLCVStreamForInstrument(instrument *Instrument) *InterDayDOHLCVStream {
+	return NewInterDayDOHLCVStreamForInstrument(instrument, DailyBar)
+}
+
+func NewWeeklyDOHLCVStreamForInstrument(instrument *Instrument) *InterDayDOHLCVStream {
+	return NewInterDayDOHLCVStreamForInstrument(instrument, WeeklyBar)
+}
+
+func NewMonthlyDOHLCVStreamForInstrument(instrument *Instrument) *InterDayDOHLCVStream {
+	return NewInterDayDOHLCVStreamForInstrument(instrument, MonthlyBar)
+}
+
+// NewInterDayDOHLCVStreamForStream creates an inter day stream which aggregates the bars of a finer grained source data stream.
+// The stream takes on the instrument of a source data stream which implements InstrumentHolder.
+func NewInterDayDOHLCVStreamForStream(sourceStream DOHLCVStreamSubscriber, streamBarType interDayBarType) *InterDayDOHLCVStream {
+	s := NewInterDayDOHLCVStream(streamBarType)
+	if holder, ok := sourceStream.(InstrumentHolder); ok {
+		s.instrument = holder.Instrument()
+	}
+	sourceStream.AddTickSubscription(s)
+	return s
+}
+
+// NewWeeklyDOHLCVStreamForStream creates a weekly stream which aggregates the bars of a daily source data stream
+func NewWeeklyDOHLCVStreamForStream(sourceStream DOHLCVStreamSubscriber) *InterDayDOHLCVStream {
+	return NewInterDayDOHLCVStreamForStream(sourceStream, WeeklyBar)
+}
+
+// NewMonthlyDOHLCVStreamForStream creates a monthly stream which aggregates the bars of a daily or weekly source data stream
+func NewMonthlyDOHLCVStreamForStream(sourceStream DOHLCVStreamSubscriber) *InterDayDOHLCVStream {
+	return NewInterDayDOHLCVStreamForStream(sourceStream, MonthlyBar)
+}
+
+// ReceiveDOHLCVTick consumes a bar from a finer grained source data stream, aggregating it into the bar type of this stream.
+// Completed bars are received by the stream as a tick once the first source bar of the following period arrives.
+func (p *InterDayDOHLCVStream) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	p.aggregator.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest bar from the source data stream, revising the bar being aggregated
+func (p *InterDayDOHLCVStream) ReceiveDOHLCVTickUpdate(tickData DOHLCV, streamBarIndex int) {
+	p.aggregator.ReceiveDOHLCVTickUpdate(tickData, streamBarIndex)
+}
+
+// Flush completes the bar being aggregated from the source data stream, for use once the source data is exhausted
+func (p *InterDayDOHLCVStream) Flush() {
+	p.aggregator.Flush()
+}
+
+// SetPartialBarPolicy sets how aggregated bars that do not cover their whole period are handled
+func (p *InterDayDOHLCVStream) SetPartialBarPolicy(policy PartialBarPolicy) {
+	p.aggregator.SetPartialBarPolicy(policy)
+}
+
+// ReceiveTick receives a new bar, bars rejected by the stream's BarValidationPolicy are dropped.
+// Use ValidateAndReceiveTick to learn why a bar was rejected.
 func (p *DOHLCVStream) ReceiveTick(tickData DOHLCV) {
+	p.ValidateAndReceiveTick(tickData)
+}
+
+func (p *DOHLCVStream) receiveTick(tickData DOHLCV) {
+	if p.calendar != nil && p.gapPolicy != IgnoreTradingGaps {
+		p.handleTradingGaps(tickData)
+	}
+	p.appendTick(tickData)
+}
+
+// appendTick adds the bar to the stream and notifies the subscribers
+func (p *DOHLCVStream) appendTick(tickData DOHLCV) {
 	p.streamBarIndex++
-	p.Data = append(p.Data, tickData)
+	if p.history != nil {
+		p.history.Push(tickData)
+		p.Data = p.history.Values()
+	} else {
+		p.Data = append(p.Data, tickData)
+	}
+
+	p.previousMinValue = p.minValue
+	p.previousMaxValue = p.maxValue
+	p.updateMinMax(tickData)
+
+	// notify all the subscribers and wait, subscriptions removed since the snapshot was taken are skipped
+	subscriptions, dispatcher := p.dispatchTargets()
+	dispatcher.Dispatch(tickData, p.streamBarIndex, subscriptions)
+}
+
+// ReceiveTickUpdate replaces the latest bar with a revision of it, e.g. the forming bar of a live feed, and notifies
+// the subscribers which implement DOHLCVTickUpdateReceiver so that they can revise their latest results.
+// A stream without any bars receives the tick as a new bar.
+func (p *DOHLCVStream) ReceiveTickUpdate(tickData DOHLCV) {
+	if p.streamBarIndex == 0 {
+		p.receiveTick(tickData)
+		return
+	}
+
+	if p.history != nil {
+		p.history.ReplaceLatest(tickData)
+		p.Data = p.history.Values()
+	} else {
+		p.Data[len(p.Data)-1] = tickData
+	}
 
+	// the bounds are recalculated from the bounds prior to the latest bar
+	p.minValue = p.previousMinValue
+	p.maxValue = p.previousMaxValue
+	p.updateMinMax(tickData)
+
+	subscriptions, dispatcher := p.dispatchTargets()
+	dispatcher.DispatchUpdate(tickData, p.streamBarIndex, subscriptions)
+}
+
+func (p *DOHLCVStream) updateMinMax(tickData DOHLCV) {
 	if p.minValue > tickData.L() {
 		p.minValue = tickData.L()
 	}
@@ -82,21 +267,77 @@ func (p *DOHLCVStream) ReceiveTick(tickData DOHLCV) {
 	if p.maxValue < tickData.H() {
 		p.maxValue = tickData.H()
 	}
+}
 
-	var waitGroup sync.WaitGroup
+// dispatchTargets returns a snapshot of the subscriptions and the dispatcher with which to notify them
+func (p *DOHLCVStream) dispatchTargets() (subscriptions []*DOHLCVTickSubscription, dispatcher TickDispatcher) {
+	// the subscriber list is copied on write, so this snapshot is unaffected by changes during the notification
+	p.subscribersMutex.Lock()
+	subscriptions = p.subscribers
+	dispatcher = p.dispatcher
+	p.subscribersMutex.Unlock()
 
-	// notify all the subscribers and wait
-	for subscriberIndex := range p.subscribers {
-		waitGroup.Add(1)
-		var subscriber DOHLCVTickReceiver = p.subscribers[subscriberIndex]
-		go func(subscriber DOHLCVTickReceiver) {
-			defer waitGroup.Done()
-			subscriber.ReceiveDOHLCVTick(tickData, p.streamBarIndex)
+	if dispatcher == nil {
+		dispatcher = defaultTickDispatcher
+	}
+	return subscriptions, dispatcher
+}
+
+// SetTickDispatcher sets how the stream notifies its subscribers of each tick, the default is a FanOutTickDispatcher
+func (p *DOHLCVStream) SetTickDispatcher(dispatcher TickDispatcher) {
+	p.subscribersMutex.Lock()
+	defer p.subscribersMutex.Unlock()
+	p.dispatcher = dispatcher
+}
 
-		}(subscriber)
+// SetMaxHistory caps the bars retained by the stream to the most recent maxHistory bars, keeping them in a ring buffer.
+// Data then holds at most maxHistory bars, oldest first, and is overwritten as new bars arrive. A maxHistory of 0
+// removes the cap, from which point bars are retained indefinitely.
+func (p *DOHLCVStream) SetMaxHistory(maxHistory int) {
+	if maxHistory <= 0 {
+		p.history = nil
+		p.Data = append([]DOHLCV(nil), p.Data...)
+		return
 	}
 
-	waitGroup.Wait()
+	p.history = NewDOHLCVRingBuffer(maxHistory)
+	for _, bar := range p.Data {
+		p.history.Push(bar)
+	}
+	p.Data = p.history.Values()
+}
+
+// MaxHistory returns the maximum number of bars retained by the stream, 0 when uncapped
+func (p *DOHLCVStream) MaxHistory() int {
+	if p.history == nil {
+		return 0
+	}
+	return p.history.Capacity()
+}
+
+// StreamBarCount returns the number of bars the stream has received, including those no longer retained
+func (p *DOHLCVStream) StreamBarCount() int {
+	return p.streamBarIndex
+}
+
+// BarFromLatest returns the bar offset bars before the latest bar, an offset of 0 is the latest bar.
+// ok is false when the bar has not been received or is no longer retained.
+func (p *DOHLCVStream) BarFromLatest(offset int) (bar DOHLCV, ok bool) {
+	if offset < 0 || offset >= len(p.Data) {
+		return nil, false
+	}
+	return p.Data[len(p.Data)-1-offset], true
+}
+
+// BarAt returns the bar with the stream bar index, the first bar received is bar 1.
+// ok is false when the bar has not been received or is no longer retained.
+func (p *DOHLCVStream) BarAt(streamBarIndex int) (bar DOHLCV, ok bool) {
+	return p.BarFromLatest(p.streamBarIndex - streamBarIndex)
+}
+
+// Instrument returns the instrument the stream's data represents, nil when the stream was created without one
+func (p *DOHLCVStream) Instrument() *Instrument {
+	return p.instrument
 }
 
 func (p *DOHLCVStream) MinDate() time.Time {
@@ -118,22 +359,171 @@ func (p *DOHLCVStream) MaxValue() float64 {
 }
 
 func (p *DOHLCVStream) AddTickSubscription(subscriber DOHLCVTickReceiver) {
-	p.subscribers = append(p.subscribers, subscriber)
+	p.Subscribe(subscriber)
 }
 
+// Subscribe adds a subscriber to the stream, returning a handle with which to unsubscribe
+func (p *DOHLCVStream) Subscribe(subscriber DOHLCVTickReceiver) *DOHLCVTickSubscription {
+	subscription := &DOHLCVTickSubscription{stream: p, subscriber: subscriber, active: 1}
+
+	p.subscribersMutex.Lock()
+	defer p.subscribersMutex.Unlock()
+
+	subscribers := make([]*DOHLCVTickSubscription, len(p.subscribers), len(p.subscribers)+1)
+	copy(subscribers, p.subscribers)
+	p.subscribers = append(subscribers, subscription)
+
+	return subscription
+}
+
+// RemoveTickSubscription removes every subscription of the subscriber from the stream.
+// Indicators which feed *WithoutStorage children of their own stop feeding them as soon as they are removed.
 func (p *DOHLCVStream) RemoveTickSubscription(subscriber DOHLCVTickReceiver) {
+	p.subscribersMutex.Lock()
+	var removed []*DOHLCVTickSubscription
+	for _, subscription := range p.subscribers {
+		if subscription.subscriber == subscriber {
+			removed = append(removed, subscription)
+		}
+	}
+	p.subscribersMutex.Unlock()
+
+	for _, subscription := range removed {
+		subscription.Unsubscribe()
+	}
+}
+
+// SubscriptionCount returns the number of active subscriptions to the stream
+func (p *DOHLCVStream) SubscriptionCount() int {
+	p.subscribersMutex.Lock()
+	defer p.subscribersMutex.Unlock()
+	return len(p.subscribers)
+}
+
+func (p *DOHLCVStream) removeSubscription(subscription *DOHLCVTickSubscription) {
+	p.subscribersMutex.Lock()
+	defer p.subscribersMutex.Unlock()
 
+	subscribers := make([]*DOHLCVTickSubscription, 0, len(p.subscribers))
+	for _, existing := range p.subscribers {
+		if existing != subscription {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	p.subscribers = subscribers
 }
 
 type IntraDayDOHLCVStream struct {
 	*DOHLCVStream
 	intraDayBarInterval int
+	barBuilder          *IntraDayBarBuilder
 }
 
+// NewIntraDayDOHLCVStream creates an intra day stream whose trades are built into bars aligned to midnight
+// and closed on the time boundary
 func NewIntraDayDOHLCVStream(barIntervalInMins int) *IntraDayDOHLCVStream {
+	s, _ := NewIntraDayDOHLCVStreamForSession(barIntervalInMins, 0, BarCloseOnTimeBoundary)
+	return s
+}
+
+// NewIntraDayDOHLCVStreamForSession creates an intra day stream whose trades are built into bars aligned
+// to the session start, an offset from midnight, and closed according to the bar close policy
+func NewIntraDayDOHLCVStreamForSession(barIntervalInMins int, sessionStart time.Duration, barClosePolicy BarClosePolicy) (stream *IntraDayDOHLCVStream, err error) {
 	s := IntraDayDOHLCVStream{DOHLCVStream: &DOHLCVStream{streamBarIndex: 0,
 		minValue: math.MaxFloat64,
 		maxValue: math.SmallestNonzeroFloat64},
 		intraDayBarInterval: barIntervalInMins}
-	return &s
+	s.barBuilder, err = NewIntraDayBarBuilder(barIntervalInMins, sessionStart, barClosePolicy, s.DOHLCVStream)
+	return &s, err
+}
+
+// NewIntraDayDOHLCVStreamForInstrument creates an intra day stream of the instrument's trades, whose bars are aligned
+// to the session start in the instrument's trading timezone and closed according to the bar close policy
+func NewIntraDayDOHLCVStreamForInstrument(instrument *Instrument, barIntervalInMins int, sessionStart time.Duration, barClosePolicy BarClosePolicy) (stream *IntraDayDOHLCVStream, err error) {
+	s, err := NewIntraDayDOHLCVStreamForSession(barIntervalInMins, sessionStart, barClosePolicy)
+	if err != nil {
+		return s, err
+	}
+
+	s.instrument = instrument
+	s.barBuilder.SetLocation(instrument.Location())
+	return s, nil
+}
+
+// ReceiveTradeTick consumes a trade, completed bars are received by the stream as a tick
+func (p *IntraDayDOHLCVStream) ReceiveTradeTick(tickData Trade) {
+	if p.barBuilder != nil {
+		p.barBuilder.ReceiveTradeTick(tickData)
+	}
+}
+
+// ReceiveClockTick advances the stream's clock, completing the bar under construction when it closes on the time boundary
+func (p *IntraDayDOHLCVStream) ReceiveClockTick(now time.Time) {
+	if p.barBuilder != nil {
+		p.barBuilder.ReceiveClockTick(now)
+	}
+}
+
+// Flush completes the bar under construction, for use at the end of a session or of the source data
+func (p *IntraDayDOHLCVStream) Flush() {
+	if p.barBuilder != nil {
+		p.barBuilder.Flush()
+	}
+}
+
+type InformationDOHLCVStream struct {
+	*DOHLCVStream
+	barBuilder *InformationBarBuilder
+}
+
+// NewInformationDOHLCVStream creates a stream whose trades, or finer grained source bars, are built into bars which
+// each cover the threshold amount of the bar type's activity
+func NewInformationDOHLCVStream(barType InformationBarType, threshold float64) (stream *InformationDOHLCVStream, err error) {
+	s := InformationDOHLCVStream{DOHLCVStream: NewDOHLCVStream()}
+	s.barBuilder, err = NewInformationBarBuilder(barType, threshold, s.DOHLCVStream)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func NewVolumeBarDOHLCVStream(volumePerBar float64) (stream *InformationDOHLCVStream, err error) {
+	return NewInformationDOHLCVStream(VolumeBar, volumePerBar)
+}
+
+func NewTickBarDOHLCVStream(ticksPerBar int) (stream *InformationDOHLCVStream, err error) {
+	return NewInformationDOHLCVStream(TickBar, float64(ticksPerBar))
+}
+
+func NewRangeBarDOHLCVStream(rangePerBar float64) (stream *InformationDOHLCVStream, err error) {
+	return NewInformationDOHLCVStream(RangeBar, rangePerBar)
+}
+
+func NewDollarBarDOHLCVStream(dollarsPerBar float64) (stream *InformationDOHLCVStream, err error) {
+	return NewInformationDOHLCVStream(DollarBar, dollarsPerBar)
+}
+
+// NewInformationDOHLCVStreamForStream creates an information bar stream which aggregates the bars of a finer grained source data stream
+func NewInformationDOHLCVStreamForStream(sourceStream DOHLCVStreamSubscriber, barType InformationBarType, threshold float64) (stream *InformationDOHLCVStream, err error) {
+	s, err := NewInformationDOHLCVStream(barType, threshold)
+	if err != nil {
+		return s, err
+	}
+	sourceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+// ReceiveTradeTick consumes a trade, completed bars are received by the stream as a tick
+func (p *InformationDOHLCVStream) ReceiveTradeTick(tickData Trade) {
+	p.barBuilder.ReceiveTradeTick(tickData)
+}
+
+// ReceiveDOHLCVTick consumes a bar from a finer grained source data stream, completed bars are received by the stream as a tick
+func (p *InformationDOHLCVStream) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	p.barBuilder.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// Flush completes the bar under construction, for use once the source data is exhausted
+func (p *InformationDOHLCVStream) Flush() {
+	p.barBuilder.Flush()
 }
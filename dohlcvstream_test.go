@@ -1 +1,246 @@
 package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"sync"
+	"time"
+)
+
+type countingTickReceiver struct {
+	mutex         sync.Mutex
+	ticksReceived int
+}
+
+func (r *countingTickReceiver) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.ticksReceived++
+}
+
+func (r *countingTickReceiver) TicksReceived() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.ticksReceived
+}
+
+type selfRemovingTickReceiver struct {
+	countingTickReceiver
+	stream *gotrade.InterDayDOHLCVStream
+}
+
+func (r *selfRemovingTickReceiver) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	r.countingTickReceiver.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	r.stream.RemoveTickSubscription(r)
+}
+
+func newStreamTick(price float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Now(), price, price+1.0, price-1.0, price, 100.0)
+}
+
+var _ = Describe("when managing the subscriptions of a DOHLCVStream", func() {
+	var (
+		stream   *gotrade.InterDayDOHLCVStream
+		receiver *countingTickReceiver
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		receiver = &countingTickReceiver{}
+	})
+
+	Context("and a subscriber is removed", func() {
+		BeforeEach(func() {
+			stream.AddTickSubscription(receiver)
+			stream.ReceiveTick(newStreamTick(10.0))
+			stream.RemoveTickSubscription(receiver)
+			stream.ReceiveTick(newStreamTick(11.0))
+		})
+
+		It("the subscriber should not receive any further ticks", func() {
+			Expect(receiver.TicksReceived()).To(Equal(1))
+			Expect(stream.SubscriptionCount()).To(Equal(0))
+		})
+	})
+
+	Context("and a subscription handle is unsubscribed", func() {
+		var subscription *gotrade.DOHLCVTickSubscription
+
+		BeforeEach(func() {
+			subscription = stream.Subscribe(receiver)
+			stream.ReceiveTick(newStreamTick(10.0))
+			subscription.Unsubscribe()
+			subscription.Unsubscribe()
+			stream.ReceiveTick(newStreamTick(11.0))
+		})
+
+		It("the subscription should be inactive", func() {
+			Expect(subscription.IsActive()).To(BeFalse())
+		})
+
+		It("the subscriber should not receive any further ticks", func() {
+			Expect(receiver.TicksReceived()).To(Equal(1))
+		})
+	})
+
+	Context("and a subscriber removes itself while receiving a tick", func() {
+		var selfRemoving *selfRemovingTickReceiver
+
+		BeforeEach(func() {
+			selfRemoving = &selfRemovingTickReceiver{stream: stream}
+			stream.AddTickSubscription(selfRemoving)
+			stream.AddTickSubscription(receiver)
+			stream.ReceiveTick(newStreamTick(10.0))
+			stream.ReceiveTick(newStreamTick(11.0))
+		})
+
+		It("the subscriber should only receive the current tick", func() {
+			Expect(selfRemoving.TicksReceived()).To(Equal(1))
+		})
+
+		It("the other subscribers should be unaffected", func() {
+			Expect(receiver.TicksReceived()).To(Equal(2))
+		})
+	})
+
+	Context("and subscribers are added and removed while ticks are being received", func() {
+		It("every remaining subscriber should keep receiving ticks", func() {
+			var waitGroup sync.WaitGroup
+			stream.AddTickSubscription(receiver)
+
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				for i := 0; i < 100; i++ {
+					other := &countingTickReceiver{}
+					subscription := stream.Subscribe(other)
+					subscription.Unsubscribe()
+				}
+			}()
+
+			for i := 0; i < 100; i++ {
+				stream.ReceiveTick(newStreamTick(float64(i)))
+			}
+			waitGroup.Wait()
+
+			Expect(receiver.TicksReceived()).To(Equal(100))
+			Expect(stream.SubscriptionCount()).To(Equal(1))
+		})
+	})
+
+	Context("and an indicator with children without storage is removed", func() {
+		var macd *indicators.Macd
+
+		BeforeEach(func() {
+			macd, _ = indicators.NewMacdForStream(stream, 3, 5, 2, gotrade.UseClosePrice)
+			for i := 0; i < 10; i++ {
+				stream.ReceiveTick(newStreamTick(float64(i)))
+			}
+			stream.RemoveTickSubscription(macd)
+			for i := 0; i < 10; i++ {
+				stream.ReceiveTick(newStreamTick(float64(i)))
+			}
+		})
+
+		It("the indicator and its children should stop receiving ticks", func() {
+			Expect(macd.Length()).To(Equal(10 - macd.GetLookbackPeriod()))
+			Expect(len(macd.Macd)).To(Equal(macd.Length()))
+		})
+	})
+})
+
+var _ = Describe("when capping the history of a DOHLCVStream", func() {
+	var stream *gotrade.InterDayDOHLCVStream
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		stream.SetMaxHistory(5)
+		for i := 1; i <= 12; i++ {
+			stream.ReceiveTick(newStreamTick(float64(i)))
+		}
+	})
+
+	It("should only retain the most recent bars", func() {
+		Expect(len(stream.Data)).To(Equal(5))
+		Expect(stream.Data[0].C()).To(Equal(8.0))
+		Expect(stream.Data[4].C()).To(Equal(12.0))
+		Expect(stream.StreamBarCount()).To(Equal(12))
+	})
+
+	It("should return bars by offset from the latest bar", func() {
+		bar, ok := stream.BarFromLatest(1)
+		Expect(ok).To(BeTrue())
+		Expect(bar.C()).To(Equal(11.0))
+
+		_, ok = stream.BarFromLatest(5)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should return bars by stream bar index", func() {
+		bar, ok := stream.BarAt(9)
+		Expect(ok).To(BeTrue())
+		Expect(bar.C()).To(Equal(9.0))
+
+		_, ok = stream.BarAt(7)
+		Expect(ok).To(BeFalse())
+
+		_, ok = stream.BarAt(13)
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("when revising the latest bar of a DOHLCVStream", func() {
+	var (
+		stream   *gotrade.InterDayDOHLCVStream
+		receiver *countingTickReceiver
+		sma      *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		receiver = &countingTickReceiver{}
+		stream.AddTickSubscription(receiver)
+		sma, _ = indicators.NewSmaForStream(stream, 2, gotrade.UseClosePrice)
+	})
+
+	Context("and the stream has received bars", func() {
+		BeforeEach(func() {
+			stream.ReceiveTick(newStreamTick(10.0))
+			stream.ReceiveTick(newStreamTick(20.0))
+			stream.ReceiveTickUpdate(newStreamTick(30.0))
+			stream.ReceiveTickUpdate(newStreamTick(12.0))
+		})
+
+		It("should replace the latest bar", func() {
+			Expect(len(stream.Data)).To(Equal(2))
+			Expect(stream.Data[1].C()).To(Equal(12.0))
+			Expect(stream.StreamBarCount()).To(Equal(2))
+		})
+
+		It("should recalculate the bounds without the revised values", func() {
+			Expect(stream.MaxValue()).To(Equal(13.0))
+		})
+
+		It("should revise the latest result of the subscribed indicators", func() {
+			Expect(sma.Data).To(Equal([]float64{11.0}))
+			Expect(sma.MaxValue()).To(Equal(11.0))
+		})
+
+		It("should not notify subscribers which do not support revisions", func() {
+			Expect(receiver.TicksReceived()).To(Equal(2))
+		})
+	})
+
+	Context("and the stream has not received any bars", func() {
+		BeforeEach(func() {
+			stream.ReceiveTickUpdate(newStreamTick(10.0))
+		})
+
+		It("should receive the revision as a new bar", func() {
+			Expect(len(stream.Data)).To(Equal(1))
+			Expect(receiver.TicksReceived()).To(Equal(1))
+		})
+	})
+})
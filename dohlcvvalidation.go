@@ -0,0 +1,90 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrBarOutOfOrder   = errors.New("Bar is dated before the latest bar of the stream")
+	ErrDuplicateBar    = errors.New("Bar has the same date as the latest bar of the stream")
+	ErrInconsistentBar = errors.New("Bar high, low, open and close prices are inconsistent")
+	ErrNonTradingDay   = errors.New("Bar is dated on a day the trading calendar does not trade")
+)
+
+// BarValidationPolicy determines how a DOHLCVStream handles bars which are out of order, duplicated or inconsistent.
+// A bar is inconsistent when:
+//	- the high is below the open, close or low
+//	- the low is above the open, close or high
+//	- the volume is negative
+// A bar is also invalid when the stream has a trading calendar and the bar is dated on a non trading day.
+type BarValidationPolicy int
+
+const (
+	// every bar is received without validation
+	AcceptAllBars BarValidationPolicy = iota
+	// invalid bars are rejected with an error
+	RejectInvalidBars
+	// invalid bars are dropped without an error
+	DropInvalidBars
+	// a bar with the same date as the latest bar replaces it as a revision, other invalid bars are rejected with an
+	// error. Only the latest bar can be revised, a bar dated the same as an older bar is rejected as out of order.
+	ReplaceLatestDuplicateBars
+)
+
+// DOHLCVStreamValidatingTickReceiver is a DOHLCVStreamTickReceiver which reports the bars it rejects
+type DOHLCVStreamValidatingTickReceiver interface {
+	ValidateAndReceiveTick(tickData DOHLCV) error
+}
+
+// SetBarValidationPolicy sets how the stream handles invalid bars, the default is AcceptAllBars
+func (p *DOHLCVStream) SetBarValidationPolicy(policy BarValidationPolicy) {
+	p.validationPolicy = policy
+}
+
+// ValidateAndReceiveTick validates the bar according to the stream's BarValidationPolicy and receives it
+// if it is valid. Returns the reason the bar was rejected, a dropped bar is not an error.
+func (p *DOHLCVStream) ValidateAndReceiveTick(tickData DOHLCV) error {
+	if p.validationPolicy == AcceptAllBars {
+		p.receiveTick(tickData)
+		return nil
+	}
+
+	err := p.validateTick(tickData)
+	switch {
+	case err == nil:
+		p.receiveTick(tickData)
+	case err == ErrDuplicateBar && p.validationPolicy == ReplaceLatestDuplicateBars:
+		p.ReceiveTickUpdate(tickData)
+	case p.validationPolicy == DropInvalidBars:
+	default:
+		return err
+	}
+	return nil
+}
+
+func (p *DOHLCVStream) validateTick(tickData DOHLCV) error {
+	if tickData.H() < math.Max(tickData.O(), tickData.C()) ||
+		tickData.L() > math.Min(tickData.O(), tickData.C()) ||
+		tickData.V() < 0.0 {
+		return ErrInconsistentBar
+	}
+
+	if p.calendar != nil && !p.calendar.IsTradingDay(tickData.D()) {
+		return ErrNonTradingDay
+	}
+
+	latest, ok := p.BarFromLatest(0)
+	if !ok {
+		return nil
+	}
+
+	if tickData.D().Before(latest.D()) {
+		return ErrBarOutOfOrder
+	}
+
+	if tickData.D().Equal(latest.D()) {
+		return ErrDuplicateBar
+	}
+	return nil
+}
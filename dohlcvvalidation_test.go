@@ -0,0 +1,101 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+// newDatedTick creates a consistent bar on the specified day of January 2013
+func newDatedTick(day int, price float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Date(2013, time.January, day, 0, 0, 0, 0, time.UTC), price, price+1.0, price-1.0, price, 100.0)
+}
+
+var _ = Describe("when validating the bars received by a stream", func() {
+	var (
+		stream *gotrade.InterDayDOHLCVStream
+		err    error
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Context("and the stream accepts all bars", func() {
+		BeforeEach(func() {
+			stream.ReceiveTick(newDatedTick(2, 10.0))
+			err = stream.ValidateAndReceiveTick(newDatedTick(1, 11.0))
+		})
+
+		It("should receive an out of order bar", func() {
+			Expect(err).To(BeNil())
+			Expect(len(stream.Data)).To(Equal(2))
+		})
+	})
+
+	Context("and the stream rejects invalid bars", func() {
+		BeforeEach(func() {
+			stream.SetBarValidationPolicy(gotrade.RejectInvalidBars)
+			stream.ReceiveTick(newDatedTick(2, 10.0))
+		})
+
+		It("should reject an out of order bar", func() {
+			Expect(stream.ValidateAndReceiveTick(newDatedTick(1, 11.0))).To(Equal(gotrade.ErrBarOutOfOrder))
+			Expect(len(stream.Data)).To(Equal(1))
+		})
+
+		It("should reject a duplicate bar", func() {
+			Expect(stream.ValidateAndReceiveTick(newDatedTick(2, 11.0))).To(Equal(gotrade.ErrDuplicateBar))
+			Expect(len(stream.Data)).To(Equal(1))
+		})
+
+		It("should reject an inconsistent bar", func() {
+			bar := gotrade.NewDOHLCVDataItem(newDatedTick(3, 0.0).D(), 10.0, 9.0, 8.0, 9.5, 100.0)
+			Expect(stream.ValidateAndReceiveTick(bar)).To(Equal(gotrade.ErrInconsistentBar))
+			Expect(len(stream.Data)).To(Equal(1))
+		})
+
+		It("should receive a valid bar", func() {
+			Expect(stream.ValidateAndReceiveTick(newDatedTick(3, 11.0))).To(BeNil())
+			Expect(len(stream.Data)).To(Equal(2))
+		})
+	})
+
+	Context("and the stream drops invalid bars", func() {
+		BeforeEach(func() {
+			stream.SetBarValidationPolicy(gotrade.DropInvalidBars)
+			stream.ReceiveTick(newDatedTick(2, 10.0))
+			err = stream.ValidateAndReceiveTick(newDatedTick(1, 11.0))
+		})
+
+		It("should drop the bar without an error", func() {
+			Expect(err).To(BeNil())
+			Expect(len(stream.Data)).To(Equal(1))
+		})
+	})
+
+	Context("and the stream replaces latest duplicate bars", func() {
+		BeforeEach(func() {
+			stream.SetBarValidationPolicy(gotrade.ReplaceLatestDuplicateBars)
+			stream.ReceiveTick(newDatedTick(2, 10.0))
+			err = stream.ValidateAndReceiveTick(newDatedTick(2, 11.0))
+		})
+
+		It("should replace the latest bar", func() {
+			Expect(err).To(BeNil())
+			Expect(len(stream.Data)).To(Equal(1))
+			Expect(stream.Data[0].C()).To(Equal(11.0))
+		})
+
+		It("should still reject an out of order bar", func() {
+			Expect(stream.ValidateAndReceiveTick(newDatedTick(1, 11.0))).To(Equal(gotrade.ErrBarOutOfOrder))
+		})
+
+		It("should reject a duplicate of an older bar as out of order", func() {
+			Expect(stream.ValidateAndReceiveTick(newDatedTick(3, 12.0))).To(BeNil())
+			Expect(stream.ValidateAndReceiveTick(newDatedTick(2, 13.0))).To(Equal(gotrade.ErrBarOutOfOrder))
+			Expect(stream.Data[0].C()).To(Equal(11.0))
+		})
+	})
+})
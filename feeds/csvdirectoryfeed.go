@@ -0,0 +1,53 @@
+package feeds
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// A CSVDirectoryFeed feeds a universe from a directory holding a DOHLCV format CSV file per symbol,
+// the symbol is the file name without its extension, e.g. J200.csv holds the bars of J200
+type CSVDirectoryFeed struct {
+	directory  string
+	dateParser TextDateParser
+}
+
+func NewCSVDirectoryFeed(directory string, dateParser TextDateParser) *CSVDirectoryFeed {
+	return &CSVDirectoryFeed{directory: directory, dateParser: dateParser}
+}
+
+// FillUniverse buffers the bars of every CSV file in the directory for replay by the universe, adding the symbols
+// which are not yet in the universe. The first record that can not be parsed, or that has the same date as an
+// earlier record of its file, stops the feed with a CSVRecordError.
+func (csvDF *CSVDirectoryFeed) FillUniverse(universe *gotrade.Universe) (err error) {
+	files, err := ioutil.ReadDir(csvDF.directory)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.EqualFold(filepath.Ext(file.Name()), ".csv") {
+			continue
+		}
+
+		symbol := strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))
+		if _, ok := universe.Stream(symbol); !ok {
+			if _, err = universe.AddSymbol(symbol); err != nil {
+				return err
+			}
+		}
+
+		symbolFeed, err := universe.SymbolFeed(symbol)
+		if err != nil {
+			return err
+		}
+
+		csvFeed := NewCSVFileFeedWithDOHLCVFormat(filepath.Join(csvDF.directory, file.Name()), csvDF.dateParser)
+		if err = csvFeed.FillDOHLCVStream(symbolFeed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
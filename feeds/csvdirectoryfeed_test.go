@@ -0,0 +1,48 @@
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/feeds"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+var _ = Describe("when filling a universe from a directory of csv files", func() {
+	var (
+		directory string
+		universe  *gotrade.Universe
+		err       error
+	)
+
+	BeforeEach(func() {
+		directory, _ = ioutil.TempDir("", "csvdirectoryfeed")
+		ioutil.WriteFile(filepath.Join(directory, "ABC.csv"), []byte("2013-01-02,10.0,11.0,9.0,10.5,100\n"+
+			"2013-01-04,10.5,12.0,10.0,11.5,100\n"), 0644)
+		ioutil.WriteFile(filepath.Join(directory, "XYZ.csv"), []byte("2013-01-03,20.0,21.0,19.0,20.5,100\n"), 0644)
+		ioutil.WriteFile(filepath.Join(directory, "README.txt"), []byte("not a feed"), 0644)
+
+		universe = gotrade.NewDailyUniverse()
+		csvFeed := feeds.NewCSVDirectoryFeed(directory, feeds.DashedYearDayMonthDateParser())
+		err = csvFeed.FillUniverse(universe)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(directory)
+	})
+
+	It("should add a symbol for each csv file", func() {
+		Expect(err).To(BeNil())
+		Expect(universe.Symbols()).To(Equal([]string{"ABC", "XYZ"}))
+	})
+
+	It("should replay the bars of each file to its symbol's stream", func() {
+		universe.Replay()
+		abc, _ := universe.Stream("ABC")
+		xyz, _ := universe.Stream("XYZ")
+		Expect(len(abc.Data)).To(Equal(2))
+		Expect(len(xyz.Data)).To(Equal(1))
+	})
+})
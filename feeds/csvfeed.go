@@ -2,11 +2,31 @@ package feeds
 
 import (
 	"encoding/csv"
+	"fmt"
 	"github.com/thetruetrade/gotrade"
 	"io"
 	"os"
+	"time"
 )
 
+// A CSVRecordError reports the line number of the record a CSVFileFeed failed to parse or the stream rejected
+type CSVRecordError struct {
+	FileName   string
+	LineNumber int
+	Err        error
+}
+
+func (e *CSVRecordError) Error() string {
+	return fmt.Sprintf("%s line %d: %s", e.FileName, e.LineNumber, e.Err.Error())
+}
+
+// A CSVTradingGap reports a trading day missing from a CSV file before the record at the line number
+type CSVTradingGap struct {
+	FileName   string
+	LineNumber int
+	Date       time.Time
+}
+
 type CSVFileFeed struct {
 	*CSVDOHLCVRecordParser
 	fileName              string
@@ -17,6 +37,8 @@ type CSVFileFeed struct {
 	closePriceColumnIndex int
 	volumeColumnIndex     int
 	dateParser            TextDateParser
+	calendar              *gotrade.TradingCalendar
+	tradingGaps           []CSVTradingGap
 }
 
 func NewCSVFileFeedWithDOHLCVFormat(fileName string,
@@ -30,7 +52,9 @@ func NewCSVFileFeedWithDOHLCVFormat(fileName string,
 		3,
 		4,
 		5,
-		dateParser}
+		dateParser,
+		nil,
+		nil}
 }
 
 func NewCSVFileFeed(fileName string,
@@ -50,9 +74,23 @@ func NewCSVFileFeed(fileName string,
 		lowPriceColumnIndex,
 		closePriceColumnIndex,
 		volumeColumnIndex,
-		dateParser}
+		dateParser,
+		nil,
+		nil}
+}
+
+// SetTradingCalendar sets the calendar used to detect the trading days missing between records, see TradingGaps
+func (csvFPSF *CSVFileFeed) SetTradingCalendar(calendar *gotrade.TradingCalendar) {
+	csvFPSF.calendar = calendar
 }
 
+// TradingGaps returns the trading days missing between the records of the last fill, nil without a trading calendar
+func (csvFPSF *CSVFileFeed) TradingGaps() []CSVTradingGap {
+	return csvFPSF.tradingGaps
+}
+
+// FillDOHLCVStream reads every record of the file into the stream. The first record that can not be parsed, or that is
+// rejected by a stream implementing DOHLCVStreamValidatingTickReceiver, stops the feed with a CSVRecordError.
 func (csvFPSF *CSVFileFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTickReceiver) (err error) {
 
 	file, err := os.Open(csvFPSF.fileName)
@@ -62,6 +100,8 @@ func (csvFPSF *CSVFileFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTic
 	defer file.Close()
 	reader := csv.NewReader(file)
 	var lineNumber int = 0
+	var previousDate time.Time
+	csvFPSF.tradingGaps = nil
 	for {
 
 		// increment the linenumbers
@@ -84,9 +124,23 @@ func (csvFPSF *CSVFileFeed) FillDOHLCVStream(priceStream gotrade.DOHLCVStreamTic
 			csvFPSF.dateParser)
 
 		if err != nil {
-			return err
+			return &CSVRecordError{FileName: csvFPSF.fileName, LineNumber: lineNumber, Err: err}
+		}
+
+		if csvFPSF.calendar != nil && !previousDate.IsZero() {
+			for _, day := range csvFPSF.calendar.TradingDaysBetween(previousDate, dohlcv.D()) {
+				csvFPSF.tradingGaps = append(csvFPSF.tradingGaps, CSVTradingGap{FileName: csvFPSF.fileName, LineNumber: lineNumber, Date: day})
+			}
+		}
+		previousDate = dohlcv.D()
+
+		if validatingStream, ok := priceStream.(gotrade.DOHLCVStreamValidatingTickReceiver); ok {
+			if err = validatingStream.ValidateAndReceiveTick(dohlcv); err != nil {
+				return &CSVRecordError{FileName: csvFPSF.fileName, LineNumber: lineNumber, Err: err}
+			}
+		} else {
+			priceStream.ReceiveTick(dohlcv)
 		}
-		priceStream.ReceiveTick(dohlcv)
 	}
 	return nil
 }
@@ -1 +1,65 @@
-package feeds_test
\ No newline at end of file
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/feeds"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+var _ = Describe("when filling a stream from a csv file", func() {
+	var (
+		fileName string
+		stream   *gotrade.InterDayDOHLCVStream
+		err      error
+	)
+
+	BeforeEach(func() {
+		file, _ := ioutil.TempFile("", "csvfeed")
+		file.WriteString("2013-01-02,10.0,11.0,9.0,10.5,100\n" +
+			"2013-01-03,10.5,12.0,10.0,11.5,100\n" +
+			"2013-01-02,11.5,12.0,11.0,11.5,100\n" +
+			"2013-01-04,11.5,13.0,11.0,12.5,100\n")
+		file.Close()
+		fileName = file.Name()
+		stream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	AfterEach(func() {
+		os.Remove(fileName)
+	})
+
+	Context("and the stream accepts all bars", func() {
+		BeforeEach(func() {
+			csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat(fileName, feeds.DashedYearDayMonthDateParserForLocation(time.UTC))
+			err = csvFeed.FillDOHLCVStream(stream)
+		})
+
+		It("should receive every record", func() {
+			Expect(err).To(BeNil())
+			Expect(len(stream.Data)).To(Equal(4))
+		})
+	})
+
+	Context("and the stream rejects invalid bars", func() {
+		BeforeEach(func() {
+			stream.SetBarValidationPolicy(gotrade.RejectInvalidBars)
+			csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat(fileName, feeds.DashedYearDayMonthDateParserForLocation(time.UTC))
+			err = csvFeed.FillDOHLCVStream(stream)
+		})
+
+		It("should report the record which was rejected", func() {
+			recordError, ok := err.(*feeds.CSVRecordError)
+			Expect(ok).To(BeTrue())
+			Expect(recordError.LineNumber).To(Equal(3))
+			Expect(recordError.Err).To(Equal(gotrade.ErrBarOutOfOrder))
+		})
+
+		It("should stop at the rejected record", func() {
+			Expect(len(stream.Data)).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package feeds
+
+import (
+	"encoding/csv"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// A CSVHolidayFeed fills a trading calendar from a CSV file of holidays and half days
+//	- a holiday record is: date,name
+//	- a half day record is: date,name,close time formatted as hh:mm
+type CSVHolidayFeed struct {
+	fileName   string
+	dateParser TextDateParser
+}
+
+func NewCSVHolidayFeed(fileName string, dateParser TextDateParser) *CSVHolidayFeed {
+	return &CSVHolidayFeed{fileName: fileName, dateParser: dateParser}
+}
+
+// FillTradingCalendar adds every holiday and half day of the file to the calendar. The first record that can
+// not be parsed stops the feed with a CSVRecordError.
+func (csvHF *CSVHolidayFeed) FillTradingCalendar(calendar *gotrade.TradingCalendar) (err error) {
+	file, err := os.Open(csvHF.fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	var lineNumber int = 0
+	for {
+		lineNumber++
+
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		date, err := csvHF.dateParser(strings.TrimSpace(record[0]))
+		if err != nil {
+			return &CSVRecordError{FileName: csvHF.fileName, LineNumber: lineNumber, Err: err}
+		}
+
+		var name string
+		if len(record) > 1 {
+			name = strings.TrimSpace(record[1])
+		}
+
+		if len(record) < 3 || strings.TrimSpace(record[2]) == "" {
+			calendar.AddHoliday(date, name)
+			continue
+		}
+
+		closeTime, err := time.Parse("15:04", strings.TrimSpace(record[2]))
+		if err != nil {
+			return &CSVRecordError{FileName: csvHF.fileName, LineNumber: lineNumber, Err: err}
+		}
+		calendar.AddHalfDay(date, time.Duration(closeTime.Hour())*time.Hour+time.Duration(closeTime.Minute())*time.Minute)
+	}
+	return nil
+}
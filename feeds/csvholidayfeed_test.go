@@ -0,0 +1,78 @@
+package feeds_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/feeds"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+var _ = Describe("when filling a trading calendar from a csv file of holidays", func() {
+	var (
+		fileName string
+		calendar *gotrade.TradingCalendar
+		err      error
+	)
+
+	BeforeEach(func() {
+		file, _ := ioutil.TempFile("", "csvholidayfeed")
+		file.WriteString("2013-01-01,New Year's Day\n" +
+			"2013-01-04,Early Close,12:30\n")
+		file.Close()
+		fileName = file.Name()
+
+		calendar = gotrade.NewTradingCalendar(time.UTC)
+		err = feeds.NewCSVHolidayFeed(fileName, feeds.DashedYearDayMonthDateParser()).FillTradingCalendar(calendar)
+	})
+
+	AfterEach(func() {
+		os.Remove(fileName)
+	})
+
+	It("should add the holidays", func() {
+		Expect(err).To(BeNil())
+		name, ok := calendar.Holiday(time.Date(2013, time.January, 1, 0, 0, 0, 0, time.UTC))
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("New Year's Day"))
+	})
+
+	It("should add the half days", func() {
+		closeTime, ok := calendar.HalfDay(time.Date(2013, time.January, 4, 0, 0, 0, 0, time.UTC))
+		Expect(ok).To(BeTrue())
+		Expect(closeTime).To(Equal(12*time.Hour + 30*time.Minute))
+	})
+
+	Context("and a csv feed is checked against the calendar", func() {
+		var (
+			dataFileName string
+			csvFeed      *feeds.CSVFileFeed
+		)
+
+		BeforeEach(func() {
+			file, _ := ioutil.TempFile("", "csvfeed")
+			file.WriteString("2012-12-31,10.0,11.0,9.0,10.5,100\n" +
+				"2013-01-02,10.5,12.0,10.0,11.5,100\n" +
+				"2013-01-07,11.5,13.0,11.0,12.5,100\n")
+			file.Close()
+			dataFileName = file.Name()
+
+			csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat(dataFileName, feeds.DashedYearDayMonthDateParser())
+			csvFeed.SetTradingCalendar(calendar)
+			err = csvFeed.FillDOHLCVStream(gotrade.NewDailyDOHLCVStream())
+		})
+
+		AfterEach(func() {
+			os.Remove(dataFileName)
+		})
+
+		It("should report the missing trading days but not the holiday or weekend", func() {
+			Expect(err).To(BeNil())
+			Expect(csvFeed.TradingGaps()).To(Equal([]feeds.CSVTradingGap{
+				{FileName: dataFileName, LineNumber: 3, Date: time.Date(2013, time.January, 3, 0, 0, 0, 0, time.UTC)},
+				{FileName: dataFileName, LineNumber: 3, Date: time.Date(2013, time.January, 4, 0, 0, 0, 0, time.UTC)}}))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package feeds
 
 import (
+	"github.com/thetruetrade/gotrade"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +21,11 @@ func DashedYearDayMonthDateParserForLocation(location *time.Location) TextDatePa
 	}
 }
 
+// DashedYearDayMonthDateParserForInstrument parses dates in the trading timezone of the instrument
+func DashedYearDayMonthDateParserForInstrument(instrument *gotrade.Instrument) TextDateParser {
+	return DashedYearDayMonthDateParserForLocation(instrument.Location())
+}
+
 func dashedYearDayMonthDateParser(textDate string, location *time.Location) (date time.Time, err error) {
 	splits := strings.Split(textDate, "-")
 	year, err := strconv.ParseInt(splits[0], 10, 0)
@@ -65,6 +65,35 @@ func (di *DOHLCVDataItem) V() float64 {
 	return di.volumePrice
 }
 
+// A trade, the price and size of a transaction at a point in time
+type Trade interface {
+	D() time.Time
+	P() float64
+	S() float64
+}
+
+type TradeDataItem struct {
+	date  time.Time
+	price float64
+	size  float64
+}
+
+func NewTradeDataItem(date time.Time, price float64, size float64) *TradeDataItem {
+	return &TradeDataItem{date, price, size}
+}
+
+func (ti *TradeDataItem) D() time.Time {
+	return ti.date
+}
+
+func (ti *TradeDataItem) P() float64 {
+	return ti.price
+}
+
+func (ti *TradeDataItem) S() float64 {
+	return ti.size
+}
+
 // A function that selects which data property to use from a DOHLCV data structure
 type DOHLCVDataSelectionFunc func(dataItem DOHLCV) float64
 
@@ -98,7 +127,24 @@ type DOHLCVTickReceiver interface {
 	ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int)
 }
 
+// Consumer of revisions to the latest DOHLCV Tick, e.g. the forming bar of a live feed.
+// A revision has the streamBarIndex of the latest tick and supersedes it, results already
+// published for that bar are published again for the revised tick.
+type DOHLCVTickUpdateReceiver interface {
+	ReceiveDOHLCVTickUpdate(tickData DOHLCV, streamBarIndex int)
+}
+
+// Consumer of trade ticks
+type TradeTickReceiver interface {
+	ReceiveTradeTick(tickData Trade)
+}
+
 // Consumer of a float tick
 type TickReceiver interface {
 	ReceiveTick(tickData float64, streamBarIndex int)
 }
+
+// Consumer of revisions to the latest float tick
+type TickUpdateReceiver interface {
+	ReceiveTickUpdate(tickData float64, streamBarIndex int)
+}
@@ -10,6 +10,9 @@ type AdlWithoutStorage struct {
 
 	// private variables
 	previousAdl float64
+
+	// the state prior to the latest source data bar
+	savedState *AdlWithoutStorage
 }
 
 // NewAdlWithoutStorage creates an Accumulation Distribution Line Indicator (Adl) without storage
@@ -40,9 +43,13 @@ type Adl struct {
 func NewAdl() (indicator *Adl, err error) {
 	ind := Adl{}
 	ind.AdlWithoutStorage, err = NewAdlWithoutStorage(func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -69,6 +76,7 @@ func NewAdlForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStre
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AdlWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
 	moneyFlowMultiplier := ((tickData.C() - tickData.L()) - (tickData.H() - tickData.C())) / (tickData.H() - tickData.L())
 	moneyFlowVolume := moneyFlowMultiplier * tickData.V()
@@ -78,3 +86,31 @@ func (ind *AdlWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamB
 
 	ind.previousAdl = result
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *AdlWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *AdlWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(AdlWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *AdlWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
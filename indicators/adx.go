@@ -16,6 +16,9 @@ type AdxWithoutStorage struct {
 	sumDX         float64
 	previousAdx   float64
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *AdxWithoutStorage
 }
 
 // NewAdxWithoutStorage creates an Average Directional Index (Adx) without storage
@@ -89,9 +92,13 @@ func NewAdx(timePeriod int) (indicator *Adx, err error) {
 	ind := Adx{}
 	ind.AdxWithoutStorage, err = NewAdxWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -156,5 +163,37 @@ func NewDefaultAdxForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOH
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AdxWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.dx.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *AdxWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *AdxWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.dx.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(AdxWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *AdxWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.dx.restoreState()
+	*ind = *ind.savedState
+}
@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -12,9 +11,12 @@ type AdxrWithoutStorage struct {
 
 	// private variables
 	periodCounter int
-	periodHistory *list.List
+	periodHistory *gotrade.FloatRingBuffer
 	adx           *AdxWithoutStorage
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *AdxrWithoutStorage
 }
 
 // NewAdxrWithoutStorage creates an Average Directional Index Rating (Adxr) without storage
@@ -37,24 +39,19 @@ func NewAdxrWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAc
 
 	ind := AdxrWithoutStorage{
 		periodCounter: 0,
-		periodHistory: list.New(),
+		periodHistory: gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:    timePeriod,
 	}
 
 	ind.adx, err = NewAdxWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.periodHistory.PushBack(dataItem)
+		ind.periodHistory.Push(dataItem)
 
-		if ind.periodCounter > ind.GetLookbackPeriod() {
-			adxN := ind.periodHistory.Front().Value.(float64)
+		if ind.periodCounter > ind.lookbackPeriod {
+			adxN := ind.periodHistory.Oldest()
 			result := (dataItem + adxN) / 2.0
 
 			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 		}
-
-		if ind.periodHistory.Len() >= timePeriod {
-			first := ind.periodHistory.Front()
-			ind.periodHistory.Remove(first)
-		}
 	})
 
 	var lookback int = 3
@@ -79,9 +76,13 @@ func NewAdxr(timePeriod int) (indicator *Adxr, err error) {
 	ind := Adxr{}
 	ind.AdxrWithoutStorage, err = NewAdxrWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -146,6 +147,40 @@ func NewDefaultAdxrForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DO
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AdxrWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 	ind.adx.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *AdxrWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *AdxrWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+	ind.adx.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(AdxrWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *AdxrWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	ind.adx.restoreState()
+	*ind = *ind.savedState
+}
@@ -0,0 +1,248 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"reflect"
+	"sort"
+	"time"
+)
+
+var (
+	ErrAlignedSeriesBarsIsNil = errors.New("The bars of an aligned series are required")
+	ErrStrNotAFloatOutput     = "is not a float output of the indicator"
+	ErrStrNotAnIntOutput      = "is not an int output of the indicator"
+)
+
+// SeriesValueState is the state of an indicator's value at a source data bar
+type SeriesValueState int
+
+const (
+	// the indicator has a value for the bar
+	SeriesValueValid SeriesValueState = iota
+	// the bar has been received but precedes the bar from which the indicator is valid
+	SeriesValueNotYetValid
+	// the bar has not been received, or its value is no longer retained by the indicator or its bar by the stream
+	SeriesValueNotAvailable
+)
+
+// AlignableIndicator is an indicator whose stored results can be looked up by source data bar
+type AlignableIndicator interface {
+	Indicator
+	DataIndexForBar(streamBarIndex int) (index int, ok bool)
+	Output(name string) (output *IndicatorOutput, ok bool)
+}
+
+// BarSource is the source data stream of an aligned series, e.g. a DOHLCVStream
+type BarSource interface {
+	// the number of bars received, including those no longer retained
+	StreamBarCount() int
+	// the bar with the stream bar index, ok is false when it has not been received or is no longer retained
+	BarAt(streamBarIndex int) (bar gotrade.DOHLCV, ok bool)
+}
+
+// An AlignedSeries is a view of one output of an indicator aligned with the bars of its source data stream, so that
+// its values can be looked up by stream bar index or date without offsetting for the indicator's ValidFromBar
+type AlignedSeries struct {
+	// private variables
+	name      string
+	indicator AlignableIndicator
+	floatData *[]float64
+	intData   *[]int64
+	bars      BarSource
+}
+
+// NewAlignedSeries creates an aligned series of a float output of the indicator, named after the output
+//	- outputName: the name of the output, e.g. "Up" for the Up line of an Aroon
+//	- bars: the source data stream of the indicator
+func NewAlignedSeries(indicator AlignableIndicator, outputName string, bars BarSource) (series *AlignedSeries, err error) {
+	data, ok := outputData(indicator, outputName).(*[]float64)
+	if !ok {
+		return nil, errors.New(outputName + " " + ErrStrNotAFloatOutput)
+	}
+
+	if bars == nil {
+		return nil, ErrAlignedSeriesBarsIsNil
+	}
+
+	return &AlignedSeries{name: outputName, indicator: indicator, floatData: data, bars: bars}, nil
+}
+
+// NewAlignedIntSeries creates an aligned series of an int output of the indicator, e.g. the Data of a HhvBars,
+// whose values are looked up as floats
+func NewAlignedIntSeries(indicator AlignableIndicator, outputName string, bars BarSource) (series *AlignedSeries, err error) {
+	data, ok := outputData(indicator, outputName).(*[]int64)
+	if !ok {
+		return nil, errors.New(outputName + " " + ErrStrNotAnIntOutput)
+	}
+
+	if bars == nil {
+		return nil, ErrAlignedSeriesBarsIsNil
+	}
+
+	return &AlignedSeries{name: outputName, indicator: indicator, intData: data, bars: bars}, nil
+}
+
+// outputData returns a pointer to the indicator's field storing the results of the named output, or nil when the
+// indicator has no such output. The outputs are named after the fields storing their results.
+func outputData(indicator AlignableIndicator, outputName string) interface{} {
+	if indicator == nil {
+		return nil
+	}
+
+	if _, ok := indicator.Output(outputName); !ok {
+		return nil
+	}
+
+	value := reflect.ValueOf(indicator)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	field := value.Elem().FieldByName(outputName)
+	if !field.IsValid() || field.Kind() != reflect.Slice {
+		return nil
+	}
+	return field.Addr().Interface()
+}
+
+// Name returns the name of the series, the name of its output unless set
+func (s *AlignedSeries) Name() string {
+	return s.name
+}
+
+// SetName sets the name of the series, e.g. to distinguish the columns of a table joining the outputs of several
+// indicators named "Data"
+func (s *AlignedSeries) SetName(name string) {
+	s.name = name
+}
+
+// ValueAt returns the value of the series at the stream bar index, the first bar received is bar 1
+func (s *AlignedSeries) ValueAt(streamBarIndex int) (value float64, state SeriesValueState) {
+	if streamBarIndex < 1 || streamBarIndex > s.bars.StreamBarCount() {
+		return 0.0, SeriesValueNotAvailable
+	}
+
+	validFromBar := s.indicator.ValidFromBar()
+	if validFromBar == -1 || streamBarIndex < validFromBar {
+		return 0.0, SeriesValueNotYetValid
+	}
+
+	index, ok := s.indicator.DataIndexForBar(streamBarIndex)
+	if !ok {
+		return 0.0, SeriesValueNotAvailable
+	}
+
+	if s.intData != nil {
+		return float64((*s.intData)[index]), SeriesValueValid
+	}
+	return (*s.floatData)[index], SeriesValueValid
+}
+
+// ValueAtDate returns the value of the series at the bar with the date
+func (s *AlignedSeries) ValueAtDate(date time.Time) (value float64, state SeriesValueState) {
+	streamBarIndex, ok := s.BarIndexForDate(date)
+	if !ok {
+		return 0.0, SeriesValueNotAvailable
+	}
+	return s.ValueAt(streamBarIndex)
+}
+
+// BarIndexForDate returns the stream bar index of the retained bar with the date
+func (s *AlignedSeries) BarIndexForDate(date time.Time) (streamBarIndex int, ok bool) {
+	first, last := s.retainedBars()
+	if first > last {
+		return -1, false
+	}
+
+	// the bars are in date order
+	offset := sort.Search(last-first+1, func(i int) bool {
+		bar, _ := s.bars.BarAt(first + i)
+		return !bar.D().Before(date)
+	})
+	if offset > last-first {
+		return -1, false
+	}
+
+	bar, _ := s.bars.BarAt(first + offset)
+	if !bar.D().Equal(date) {
+		return -1, false
+	}
+	return first + offset, true
+}
+
+// retainedBars returns the stream bar indexes of the first and last bars retained by the stream
+func (s *AlignedSeries) retainedBars() (first int, last int) {
+	last = s.bars.StreamBarCount()
+
+	// the stream retains its latest bars
+	first = 1 + sort.Search(last, func(i int) bool {
+		_, ok := s.bars.BarAt(i + 1)
+		return ok
+	})
+	return first, last
+}
+
+// An AlignedRow is the values of the joined series at a date, in the order of the table's columns
+type AlignedRow struct {
+	Date   time.Time
+	Values []float64
+	States []SeriesValueState
+}
+
+// An AlignedTable is several aligned series joined by date, with a row for each date of the bars retained by any
+// of their streams, in date order
+type AlignedTable struct {
+	Columns []string
+	Rows    []AlignedRow
+}
+
+// JoinAlignedSeries joins the series by the dates of their bars, the series may be of different streams, e.g. of
+// daily and weekly bars, in which case a series without a bar at a date is not available at it
+func JoinAlignedSeries(series ...*AlignedSeries) *AlignedTable {
+	table := AlignedTable{Columns: make([]string, len(series))}
+
+	var dates []time.Time
+	seen := make(map[int64]bool)
+	for i, s := range series {
+		table.Columns[i] = s.Name()
+
+		first, last := s.retainedBars()
+		for streamBarIndex := first; streamBarIndex <= last; streamBarIndex++ {
+			bar, _ := s.bars.BarAt(streamBarIndex)
+			if key := bar.D().UnixNano(); !seen[key] {
+				seen[key] = true
+				dates = append(dates, bar.D())
+			}
+		}
+	}
+	sort.Sort(timesByDate(dates))
+
+	table.Rows = make([]AlignedRow, len(dates))
+	for i, date := range dates {
+		row := AlignedRow{Date: date, Values: make([]float64, len(series)), States: make([]SeriesValueState, len(series))}
+		for j, s := range series {
+			row.Values[j], row.States[j] = s.ValueAtDate(date)
+		}
+		table.Rows[i] = row
+	}
+
+	return &table
+}
+
+// RowAt returns the row of the table with the date
+func (t *AlignedTable) RowAt(date time.Time) (row AlignedRow, ok bool) {
+	index := sort.Search(len(t.Rows), func(i int) bool {
+		return !t.Rows[i].Date.Before(date)
+	})
+	if index == len(t.Rows) || !t.Rows[index].Date.Equal(date) {
+		return AlignedRow{}, false
+	}
+	return t.Rows[index], true
+}
+
+type timesByDate []time.Time
+
+func (t timesByDate) Len() int           { return len(t) }
+func (t timesByDate) Less(i, j int) bool { return t[i].Before(t[j]) }
+func (t timesByDate) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
@@ -0,0 +1,232 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+func alignedDate(day int) time.Time {
+	return time.Date(2014, time.January, day, 0, 0, 0, 0, time.UTC)
+}
+
+func newAlignedStream() *gotrade.DOHLCVStream {
+	stream := gotrade.NewDOHLCVStream()
+	stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+	return stream
+}
+
+func receiveAlignedBars(stream *gotrade.DOHLCVStream, firstDay int, days int) {
+	for day := firstDay; day < firstDay+days; day++ {
+		price := 10.0 + float64(day%5)
+		stream.ReceiveTick(gotrade.NewDOHLCVDataItem(alignedDate(day), price, price+1.0, price-1.0, price, 1000.0))
+	}
+}
+
+var _ = Describe("when creating an aligned series", func() {
+	It("should be named after the output", func() {
+		aroon, _ := indicators.NewAroon(4)
+		series, err := indicators.NewAlignedSeries(aroon, "Up", gotrade.NewDOHLCVStream())
+		Expect(err).To(BeNil())
+		Expect(series.Name()).To(Equal("Up"))
+		series.SetName("Aroon Up")
+		Expect(series.Name()).To(Equal("Aroon Up"))
+	})
+
+	It("should require an output of the indicator", func() {
+		sma, _ := indicators.NewSma(3, gotrade.UseClosePrice)
+		series, err := indicators.NewAlignedSeries(sma, "Up", gotrade.NewDOHLCVStream())
+		Expect(series).To(BeNil())
+		Expect(err).To(MatchError("Up " + indicators.ErrStrNotAFloatOutput))
+	})
+
+	It("should require an output of the type of the series", func() {
+		hhvBars, _ := indicators.NewHhvBars(3, gotrade.UseClosePrice)
+		series, err := indicators.NewAlignedSeries(hhvBars, "Data", gotrade.NewDOHLCVStream())
+		Expect(series).To(BeNil())
+		Expect(err).To(MatchError("Data " + indicators.ErrStrNotAFloatOutput))
+
+		sma, _ := indicators.NewSma(3, gotrade.UseClosePrice)
+		series, err = indicators.NewAlignedIntSeries(sma, "Data", gotrade.NewDOHLCVStream())
+		Expect(series).To(BeNil())
+		Expect(err).To(MatchError("Data " + indicators.ErrStrNotAnIntOutput))
+	})
+
+	It("should require the bars of the source data stream", func() {
+		sma, _ := indicators.NewSma(3, gotrade.UseClosePrice)
+		series, err := indicators.NewAlignedSeries(sma, "Data", nil)
+		Expect(series).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrAlignedSeriesBarsIsNil))
+	})
+})
+
+var _ = Describe("when looking up the values of an aligned series", func() {
+	var (
+		stream *gotrade.DOHLCVStream
+		sma    *indicators.Sma
+		aroon  *indicators.Aroon
+		series *indicators.AlignedSeries
+		down   *indicators.AlignedSeries
+	)
+
+	BeforeEach(func() {
+		stream = newAlignedStream()
+		sma, _ = indicators.NewSmaForStream(stream, 3, gotrade.UseClosePrice)
+		aroon, _ = indicators.NewAroonForStream(stream, 4)
+		series, _ = indicators.NewAlignedSeries(sma, "Data", stream)
+		down, _ = indicators.NewAlignedSeries(aroon, "Down", stream)
+	})
+
+	Context("and the indicator has not yet produced a value", func() {
+		BeforeEach(func() {
+			receiveAlignedBars(stream, 1, 2)
+		})
+
+		It("should not yet be valid at the received bars", func() {
+			_, state := series.ValueAt(2)
+			Expect(state).To(Equal(indicators.SeriesValueNotYetValid))
+		})
+
+		It("should not be available at bars which have not been received", func() {
+			_, state := series.ValueAt(3)
+			Expect(state).To(Equal(indicators.SeriesValueNotAvailable))
+			_, state = series.ValueAt(0)
+			Expect(state).To(Equal(indicators.SeriesValueNotAvailable))
+		})
+	})
+
+	Context("and the indicator has produced values", func() {
+		BeforeEach(func() {
+			receiveAlignedBars(stream, 1, 10)
+		})
+
+		It("should not yet be valid before the indicator's valid from bar", func() {
+			_, state := series.ValueAt(sma.ValidFromBar() - 1)
+			Expect(state).To(Equal(indicators.SeriesValueNotYetValid))
+		})
+
+		It("should return the indicator's value for each bar from its valid from bar", func() {
+			for streamBarIndex := sma.ValidFromBar(); streamBarIndex <= 10; streamBarIndex++ {
+				value, state := series.ValueAt(streamBarIndex)
+				Expect(state).To(Equal(indicators.SeriesValueValid))
+				Expect(value).To(Equal(sma.Data[streamBarIndex-sma.ValidFromBar()]))
+			}
+		})
+
+		It("should align each output of a multi output indicator", func() {
+			value, state := down.ValueAt(10)
+			Expect(state).To(Equal(indicators.SeriesValueValid))
+			Expect(value).To(Equal(aroon.Down[len(aroon.Down)-1]))
+			_, state = down.ValueAt(aroon.ValidFromBar() - 1)
+			Expect(state).To(Equal(indicators.SeriesValueNotYetValid))
+		})
+
+		It("should return the value at the bar with the date", func() {
+			value, state := series.ValueAtDate(alignedDate(7))
+			Expect(state).To(Equal(indicators.SeriesValueValid))
+			Expect(value).To(Equal(sma.Data[7-sma.ValidFromBar()]))
+		})
+
+		It("should not be available at a date without a bar", func() {
+			_, state := series.ValueAtDate(alignedDate(7).Add(time.Hour))
+			Expect(state).To(Equal(indicators.SeriesValueNotAvailable))
+			_, state = series.ValueAtDate(alignedDate(11))
+			Expect(state).To(Equal(indicators.SeriesValueNotAvailable))
+		})
+	})
+
+	Context("and the indicator and stream retain a capped history", func() {
+		BeforeEach(func() {
+			stream = newAlignedStream()
+			stream.SetMaxHistory(4)
+			sma, _ = indicators.NewSmaForStream(stream, 3, gotrade.UseClosePrice)
+			sma.SetMaxHistory(2)
+			series, _ = indicators.NewAlignedSeries(sma, "Data", stream)
+			receiveAlignedBars(stream, 1, 10)
+		})
+
+		It("should return the retained values", func() {
+			value, state := series.ValueAt(10)
+			Expect(state).To(Equal(indicators.SeriesValueValid))
+			Expect(value).To(Equal(sma.Data[1]))
+			value, state = series.ValueAtDate(alignedDate(9))
+			Expect(state).To(Equal(indicators.SeriesValueValid))
+			Expect(value).To(Equal(sma.Data[0]))
+		})
+
+		It("should not be available at values which are no longer retained", func() {
+			_, state := series.ValueAt(8)
+			Expect(state).To(Equal(indicators.SeriesValueNotAvailable))
+			_, state = series.ValueAtDate(alignedDate(6))
+			Expect(state).To(Equal(indicators.SeriesValueNotAvailable))
+		})
+	})
+})
+
+var _ = Describe("when joining aligned series", func() {
+	var (
+		daily       *gotrade.DOHLCVStream
+		other       *gotrade.DOHLCVStream
+		sma         *indicators.Sma
+		hhvBars     *indicators.HhvBars
+		otherSma    *indicators.Sma
+		table       *indicators.AlignedTable
+		smaSeries   *indicators.AlignedSeries
+		hhvSeries   *indicators.AlignedSeries
+		otherSeries *indicators.AlignedSeries
+	)
+
+	BeforeEach(func() {
+		daily = newAlignedStream()
+		other = newAlignedStream()
+		sma, _ = indicators.NewSmaForStream(daily, 3, gotrade.UseClosePrice)
+		hhvBars, _ = indicators.NewHhvBarsForStream(daily, 2, gotrade.UseClosePrice)
+		otherSma, _ = indicators.NewSmaForStream(other, 2, gotrade.UseClosePrice)
+		smaSeries, _ = indicators.NewAlignedSeries(sma, "Data", daily)
+		smaSeries.SetName("Sma")
+		hhvSeries, _ = indicators.NewAlignedIntSeries(hhvBars, "Data", daily)
+		hhvSeries.SetName("HhvBars")
+		otherSeries, _ = indicators.NewAlignedSeries(otherSma, "Data", other)
+		otherSeries.SetName("Other Sma")
+
+		receiveAlignedBars(daily, 1, 6)
+		receiveAlignedBars(other, 4, 6)
+
+		table = indicators.JoinAlignedSeries(smaSeries, hhvSeries, otherSeries)
+	})
+
+	It("should have a column for each series", func() {
+		Expect(table.Columns).To(Equal([]string{"Sma", "HhvBars", "Other Sma"}))
+	})
+
+	It("should have a row for each date of any of the streams in date order", func() {
+		Expect(table.Rows).To(HaveLen(9))
+		for i, row := range table.Rows {
+			Expect(row.Date).To(Equal(alignedDate(i + 1)))
+		}
+	})
+
+	It("should align the values of each series by date", func() {
+		row, ok := table.RowAt(alignedDate(5))
+		Expect(ok).To(BeTrue())
+		Expect(row.States).To(Equal([]indicators.SeriesValueState{indicators.SeriesValueValid, indicators.SeriesValueValid, indicators.SeriesValueValid}))
+		smaValue, _ := smaSeries.ValueAt(5)
+		hhvValue, _ := hhvSeries.ValueAt(5)
+		otherValue, _ := otherSeries.ValueAt(2)
+		Expect(row.Values).To(Equal([]float64{smaValue, hhvValue, otherValue}))
+	})
+
+	It("should mark the values which are not yet valid or not available", func() {
+		row, _ := table.RowAt(alignedDate(2))
+		Expect(row.States).To(Equal([]indicators.SeriesValueState{indicators.SeriesValueNotYetValid, indicators.SeriesValueValid, indicators.SeriesValueNotAvailable}))
+		row, _ = table.RowAt(alignedDate(9))
+		Expect(row.States).To(Equal([]indicators.SeriesValueState{indicators.SeriesValueNotAvailable, indicators.SeriesValueNotAvailable, indicators.SeriesValueValid}))
+	})
+
+	It("should not have a row for a date without a bar", func() {
+		_, ok := table.RowAt(alignedDate(10))
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -1,10 +1,8 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
 )
 
 // An Aroon (Aroon), no storage, for use in other indicators
@@ -12,11 +10,14 @@ type AroonWithoutStorage struct {
 	*baseIndicatorWithFloatBoundsAroon
 
 	// private variables
-	periodCounter     int
-	periodHighHistory *list.List
-	periodLowHistory  *list.List
-	aroonFactor       float64
-	timePeriod        int
+	periodCounter int
+	periodHigh    *RollingExtreme
+	periodLow     *RollingExtreme
+	aroonFactor   float64
+	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *AroonWithoutStorage
 }
 
 // NewAroonWithoutStorage creates an Aroon (Aroon) without storage
@@ -41,8 +42,8 @@ func NewAroonWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableA
 	ind := AroonWithoutStorage{
 		baseIndicatorWithFloatBoundsAroon: newBaseIndicatorWithFloatBoundsAroon(lookback, valueAvailableAction),
 		periodCounter:                     (timePeriod + 1) * -1,
-		periodHighHistory:                 list.New(),
-		periodLowHistory:                  list.New(),
+		periodHigh:                        NewRollingMax(timePeriod+1, true),
+		periodLow:                         NewRollingMin(timePeriod+1, true),
 		aroonFactor:                       100.0 / float64(timePeriod),
 	}
 
@@ -63,9 +64,14 @@ func NewAroon(timePeriod int) (indicator *Aroon, err error) {
 	ind := Aroon{}
 	ind.AroonWithoutStorage, err = NewAroonWithoutStorage(timePeriod,
 		func(dataItemAroonUp float64, dataItemAroonDown float64, streamBarIndex int) {
-			ind.Up = append(ind.Up, dataItemAroonUp)
-			ind.Down = append(ind.Down, dataItemAroonDown)
+			ind.Up = ind.storeFloat(0, ind.Up, dataItemAroonUp)
+			ind.Down = ind.storeFloat(1, ind.Down, dataItemAroonDown)
 		})
+
+	if err == nil {
+		ind.nameOutputs("Up", "Down")
+	}
+
 	return &ind, err
 }
 
@@ -132,51 +138,52 @@ func NewDefaultAroonForStreamWithSrcLen(sourceLength uint, priceStream gotrade.D
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AroonWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
-	ind.periodHighHistory.PushBack(tickData.H())
-	ind.periodLowHistory.PushBack(tickData.L())
-
-	if ind.periodHighHistory.Len() > (1 + ind.GetLookbackPeriod()) {
-		var first = ind.periodHighHistory.Front()
-		ind.periodHighHistory.Remove(first)
-		first = ind.periodLowHistory.Front()
-		ind.periodLowHistory.Remove(first)
-	}
+	ind.periodHigh.Push(tickData.H())
+	ind.periodLow.Push(tickData.L())
 
 	if ind.periodCounter >= 0 {
-		var aroonUp float64
-		var aroonDwn float64
-
-		var highValue float64 = math.SmallestNonzeroFloat64
-		var highIdx int = -1
-		var i int = (1 + ind.GetLookbackPeriod())
-		for e := ind.periodHighHistory.Front(); e != nil; e = e.Next() {
-			i--
-			var value float64 = e.Value.(float64)
-			if highValue <= value {
-				highValue = value
-				highIdx = i
-			}
-		}
-		var daysSinceHigh = highIdx
-
-		var lowValue float64 = math.MaxFloat64
-		var lowIdx int = -1
-		i = (1 + ind.GetLookbackPeriod())
-		for e := ind.periodLowHistory.Front(); e != nil; e = e.Next() {
-			i--
-			var value float64 = e.Value.(float64)
-			if lowValue >= value {
-				lowValue = value
-				lowIdx = i
-			}
-
-		}
-		var daysSinceLow = lowIdx
-
-		aroonUp = ind.aroonFactor * float64(ind.GetLookbackPeriod()-daysSinceHigh)
-		aroonDwn = ind.aroonFactor * float64(ind.GetLookbackPeriod()-daysSinceLow)
+		// of equal highs, or lows, the most recent is used
+		var daysSinceHigh = ind.periodHigh.Age()
+		var daysSinceLow = ind.periodLow.Age()
+
+		aroonUp := ind.aroonFactor * float64(ind.lookbackPeriod-daysSinceHigh)
+		aroonDwn := ind.aroonFactor * float64(ind.lookbackPeriod-daysSinceLow)
 
 		ind.UpdateIndicatorWithNewValue(aroonUp, aroonDwn, streamBarIndex)
 	}
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *AroonWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *AroonWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHigh.SaveState()
+	ind.periodLow.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(AroonWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *AroonWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHigh.RestoreState()
+	ind.periodLow.RestoreState()
+	*ind = *ind.savedState
+}
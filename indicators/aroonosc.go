@@ -10,6 +10,9 @@ type AroonOscWithoutStorage struct {
 
 	//private variables
 	aroon *AroonWithoutStorage
+
+	// the state prior to the latest source data bar
+	savedState *AroonOscWithoutStorage
 }
 
 func NewAroonOscWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *AroonOscWithoutStorage, err error) {
@@ -58,9 +61,13 @@ func NewAroonOsc(timePeriod int) (indicator *AroonOsc, err error) {
 	ind := AroonOsc{}
 	ind.AroonOscWithoutStorage, err = NewAroonOscWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -125,5 +132,37 @@ func NewDefaultAroonOscForStreamWithSrcLen(sourceLength uint, priceStream gotrad
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AroonOsc) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.aroon.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *AroonOsc) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *AroonOscWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.aroon.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(AroonOscWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *AroonOscWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.aroon.restoreState()
+	*ind = *ind.savedState
+}
@@ -15,6 +15,9 @@ type AtrWithoutStorage struct {
 	previousAvgTrueRange float64
 	multiplier           float64
 	timePeriod           int
+
+	// the state prior to the latest source data bar
+	savedState *AtrWithoutStorage
 }
 
 // NewAtrWithoutStorage creates an Average True Range Indicator (Atr) without storage
@@ -79,9 +82,13 @@ type Atr struct {
 func NewAtr(timePeriod int) (indicator *Atr, err error) {
 	ind := Atr{}
 	ind.AtrWithoutStorage, err = NewAtrWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -146,6 +153,40 @@ func NewDefaultAtrForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOH
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AtrWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	// update the current true range
 	ind.trueRange.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *AtrWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *AtrWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.trueRange.saveState(streamBarIndex)
+	ind.sma.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(AtrWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *AtrWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.trueRange.restoreState()
+	ind.sma.restoreState()
+	*ind = *ind.savedState
+}
@@ -7,6 +7,9 @@ import (
 // An Average Price (AvgPrice), no storage, for use in other indicators
 type AvgPriceWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
+
+	// the state prior to the latest source data bar
+	savedState *AvgPriceWithoutStorage
 }
 
 // NewAvgPriceWithoutStorage creates an Average Price(AvgPrice) without storage
@@ -37,9 +40,13 @@ type AvgPrice struct {
 func NewAvgPrice() (indicator *AvgPrice, err error) {
 	ind := AvgPrice{}
 	ind.AvgPriceWithoutStorage, err = NewAvgPriceWithoutStorage(func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -67,8 +74,37 @@ func NewAvgPriceForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLC
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AvgPriceWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
 	result := (tickData.O() + tickData.H() + tickData.L() + tickData.C()) / float64(4.0)
 
 	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *AvgPriceWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *AvgPriceWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(AvgPriceWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *AvgPriceWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
@@ -0,0 +1,83 @@
+/*
+	import "github.com/thetruetrade/gotrade/indicators/batch"
+
+	Package batch calculates the indicators of the indicators package over arrays of source data in a single call,
+	after the function API of TA-Lib, e.g.
+
+		sma, err := batch.Sma(closePrices, 10)
+		macd, signal, histogram, err := batch.Macd(closePrices, 12, 26, 9)
+
+	Each function is a loop over its input arrays rather than a stream of ticks and callbacks, for research and
+	bulk screening where the source data is already in memory. The results are identical, bit for bit, to the
+	results stored by the equivalent streaming indicator fed the same source data:
+		- the result arrays start at the first valid result, their length is the source data length less the
+		  indicator's lookback period
+		- the parameters are validated by the streaming indicator, so the errors are the same
+		- multiple input arrays, e.g. the high, low and close prices, must have the same length
+
+	Use SplitDOHLCV to split DOHLCV source data into its price and volume arrays.
+*/
+package batch
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+)
+
+var (
+	ErrInputLengthsDiffer = errors.New("The input arrays must have the same length")
+)
+
+// SplitDOHLCV splits DOHLCV source data into its open, high, low, close and volume arrays
+func SplitDOHLCV(data []gotrade.DOHLCV) (openPrices []float64, highPrices []float64, lowPrices []float64, closePrices []float64, volumes []float64) {
+	openPrices = make([]float64, len(data))
+	highPrices = make([]float64, len(data))
+	lowPrices = make([]float64, len(data))
+	closePrices = make([]float64, len(data))
+	volumes = make([]float64, len(data))
+
+	for i, bar := range data {
+		openPrices[i] = bar.O()
+		highPrices[i] = bar.H()
+		lowPrices[i] = bar.L()
+		closePrices[i] = bar.C()
+		volumes[i] = bar.V()
+	}
+
+	return openPrices, highPrices, lowPrices, closePrices, volumes
+}
+
+// checkLengths returns ErrInputLengthsDiffer unless the input arrays have the same length
+func checkLengths(inputs ...[]float64) error {
+	for _, input := range inputs[1:] {
+		if len(input) != len(inputs[0]) {
+			return ErrInputLengthsDiffer
+		}
+	}
+	return nil
+}
+
+// newOutput creates an empty result array with the capacity for the results of the source data
+func newOutput(sourceLength int, lookbackPeriod int) []float64 {
+	if sourceLength <= lookbackPeriod {
+		return make([]float64, 0)
+	}
+	return make([]float64, 0, sourceLength-lookbackPeriod)
+}
+
+// newIntOutput creates an empty int result array with the capacity for the results of the source data
+func newIntOutput(sourceLength int, lookbackPeriod int) []int64 {
+	if sourceLength <= lookbackPeriod {
+		return make([]int64, 0)
+	}
+	return make([]int64, 0, sourceLength-lookbackPeriod)
+}
+
+// the value available actions of the streaming indicators which validate the parameters, their results are unused
+func discardFloat(dataItem float64, streamBarIndex int) {}
+
+func discardInt(dataItem int64, streamBarIndex int) {}
+
+func discardPair(dataItemA float64, dataItemB float64, streamBarIndex int) {}
+
+func discardTriple(dataItemA float64, dataItemB float64, dataItemC float64, streamBarIndex int) {}
@@ -0,0 +1,178 @@
+package batch_test
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/feeds"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/batch"
+	"testing"
+	"time"
+)
+
+// benchmarkPrices are the price series of the benchmark data
+type benchmarkPrices struct {
+	open   []float64
+	high   []float64
+	low    []float64
+	close  []float64
+	volume []float64
+}
+
+// batchBenchmarks pairs each batch function with the streaming indicator it calculates, both with the streaming
+// indicator's default parameters
+var batchBenchmarks = []struct {
+	name         string
+	calculate    func(p benchmarkPrices)
+	newIndicator func() (gotrade.DOHLCVTickReceiver, error)
+}{
+	{"Adl", func(p benchmarkPrices) { batch.Adl(p.high, p.low, p.close, p.volume) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewAdl() }},
+	{"Adx", func(p benchmarkPrices) { batch.Adx(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAdx() }},
+	{"Adxr", func(p benchmarkPrices) { batch.Adxr(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAdxr() }},
+	{"Aroon", func(p benchmarkPrices) { batch.Aroon(p.high, p.low, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAroon() }},
+	{"AroonOsc", func(p benchmarkPrices) { batch.AroonOsc(p.high, p.low, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAroonOsc() }},
+	{"Atr", func(p benchmarkPrices) { batch.Atr(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAtr() }},
+	{"AtrZigZag", func(p benchmarkPrices) { batch.AtrZigZag(p.high, p.low, p.close, 14, 3.0) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewAtrZigZag(14, 3.0) }},
+	{"AvgPrice", func(p benchmarkPrices) { batch.AvgPrice(p.open, p.high, p.low, p.close) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewAvgPrice() }},
+	{"BollingerBands", func(p benchmarkPrices) { batch.BollingerBands(p.close, 5) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultBollingerBands() }},
+	{"Cci", func(p benchmarkPrices) { batch.Cci(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultCci() }},
+	{"ChaikinOsc", func(p benchmarkPrices) { batch.ChaikinOsc(p.high, p.low, p.close, p.volume, 3, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultChaikinOsc() }},
+	{"Dema", func(p benchmarkPrices) { batch.Dema(p.close, 30) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultDema() }},
+	{"Dx", func(p benchmarkPrices) { batch.Dx(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultDx() }},
+	{"Ema", func(p benchmarkPrices) { batch.Ema(p.close, 25) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultEma() }},
+	{"Hhv", func(p benchmarkPrices) { batch.Hhv(p.close, 25) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultHhv() }},
+	{"HhvBars", func(p benchmarkPrices) { batch.HhvBars(p.close, 25) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultHhvBars() }},
+	{"Kama", func(p benchmarkPrices) { batch.Kama(p.close, 25) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultKama() }},
+	{"LinReg", func(p benchmarkPrices) { batch.LinReg(p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinReg() }},
+	{"LinRegAng", func(p benchmarkPrices) { batch.LinRegAng(p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinRegAng() }},
+	{"LinRegInt", func(p benchmarkPrices) { batch.LinRegInt(p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinRegInt() }},
+	{"LinRegSlp", func(p benchmarkPrices) { batch.LinRegSlp(p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinRegSlp() }},
+	{"Llv", func(p benchmarkPrices) { batch.Llv(p.close, 25) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLlv() }},
+	{"LlvBars", func(p benchmarkPrices) { batch.LlvBars(p.close, 25) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLlvBars() }},
+	{"Macd", func(p benchmarkPrices) { batch.Macd(p.close, 12, 26, 9) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMacd() }},
+	{"MedPrice", func(p benchmarkPrices) { batch.MedPrice(p.high, p.low) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewMedPrice() }},
+	{"Mfi", func(p benchmarkPrices) { batch.Mfi(p.high, p.low, p.close, p.volume, 25) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMfi() }},
+	{"MinusDi", func(p benchmarkPrices) { batch.MinusDi(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMinusDi() }},
+	{"MinusDm", func(p benchmarkPrices) { batch.MinusDm(p.high, p.low, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMinusDm() }},
+	{"Mom", func(p benchmarkPrices) { batch.Mom(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMom() }},
+	{"Obv", func(p benchmarkPrices) { batch.Obv(p.close, p.volume) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewObv() }},
+	{"PlusDi", func(p benchmarkPrices) { batch.PlusDi(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultPlusDi() }},
+	{"PlusDm", func(p benchmarkPrices) { batch.PlusDm(p.high, p.low, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultPlusDm() }},
+	{"Roc", func(p benchmarkPrices) { batch.Roc(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRoc() }},
+	{"RocP", func(p benchmarkPrices) { batch.RocP(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRocP() }},
+	{"RocR", func(p benchmarkPrices) { batch.RocR(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRocR() }},
+	{"RocR100", func(p benchmarkPrices) { batch.RocR100(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRocR100() }},
+	{"Rsi", func(p benchmarkPrices) { batch.Rsi(p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRsi() }},
+	{"Sar", func(p benchmarkPrices) { batch.Sar(p.high, p.low, 0.02, 0.2) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultSar() }},
+	{"Sma", func(p benchmarkPrices) { batch.Sma(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultSma() }},
+	{"StdDev", func(p benchmarkPrices) { batch.StdDev(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultStdDev() }},
+	{"StochOsc", func(p benchmarkPrices) { batch.StochOsc(p.high, p.low, p.close, 5, 3, 3) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultStochOsc() }},
+	{"StochRsi", func(p benchmarkPrices) { batch.StochRsi(p.close, 14, 5, 3) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultStochRsi() }},
+	{"Tema", func(p benchmarkPrices) { batch.Tema(p.close, 30) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultTema() }},
+	{"Trima", func(p benchmarkPrices) { batch.Trima(p.close, 30) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultTrima() }},
+	{"TrueRange", func(p benchmarkPrices) { batch.TrueRange(p.high, p.low, p.close) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewTrueRange() }},
+	{"Tsf", func(p benchmarkPrices) { batch.Tsf(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultTsf() }},
+	{"TypPrice", func(p benchmarkPrices) { batch.TypPrice(p.high, p.low, p.close) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewTypPrice() }},
+	{"Var", func(p benchmarkPrices) { batch.Var(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultVar() }},
+	{"WillR", func(p benchmarkPrices) { batch.WillR(p.high, p.low, p.close, 14) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultWillR() }},
+	{"Wma", func(p benchmarkPrices) { batch.Wma(p.close, 10) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultWma() }},
+	{"ZigZag", func(p benchmarkPrices) { batch.ZigZag(p.high, p.low, 5.0) },
+		func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultZigZag() }},
+}
+
+// loadBenchmarkData loads the full JSE top 40 history into memory so that the benchmarks only measure the
+// indicator calculations
+func loadBenchmarkData(b *testing.B) []gotrade.DOHLCV {
+	source := gotrade.NewDailyDOHLCVStream()
+	csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat("../../testdata/JSETOPI.ALL.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+	if err := csvFeed.FillDOHLCVStream(source); err != nil {
+		b.Fatal(err)
+	}
+	return source.Data
+}
+
+// BenchmarkBatch measures each batch function calculating the full history
+func BenchmarkBatch(b *testing.B) {
+	var p benchmarkPrices
+	p.open, p.high, p.low, p.close, p.volume = batch.SplitDOHLCV(loadBenchmarkData(b))
+	for _, benchmark := range batchBenchmarks {
+		calculate := benchmark.calculate
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				calculate(p)
+			}
+		})
+	}
+}
+
+// BenchmarkStreaming measures the streaming indicator of each batch function calculating the full history, ticking
+// the data through a new indicator each iteration
+func BenchmarkStreaming(b *testing.B) {
+	data := loadBenchmarkData(b)
+	for _, benchmark := range batchBenchmarks {
+		newIndicator := benchmark.newIndicator
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				ind, err := newIndicator()
+				if err != nil {
+					b.Fatal(err)
+				}
+				for i, dataItem := range data {
+					ind.ReceiveDOHLCVTick(dataItem, i+1)
+				}
+			}
+		})
+	}
+}
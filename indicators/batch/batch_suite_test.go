@@ -0,0 +1,49 @@
+package batch_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/feeds"
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	csvFeed *feeds.CSVFileFeed
+)
+
+func TestBatch(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Batch Suite")
+}
+
+var _ = BeforeSuite(func() {
+	csvFeed = feeds.NewCSVFileFeedWithDOHLCVFormat("../../testdata/JSETOPI.2013.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+})
+
+var _ = AfterSuite(func() {
+	csvFeed = nil
+})
+
+// streamYear passes a year's data to the streaming indicators and returns the data
+func streamYear(subscribers ...gotrade.DOHLCVTickReceiver) []gotrade.DOHLCV {
+	priceStream := gotrade.NewDailyDOHLCVStream()
+	for _, subscriber := range subscribers {
+		priceStream.AddTickSubscription(subscriber)
+	}
+	csvFeed.FillDOHLCVStream(priceStream)
+	return priceStream.Data
+}
+
+// expectIdentical expects the batch results to be bit for bit identical to the streaming indicator's results
+func expectIdentical(batchResults []float64, err error, streamingResults []float64) {
+	Expect(err).To(BeNil())
+	Expect(len(streamingResults)).To(BeNumerically(">", 0))
+	Expect(len(batchResults)).To(Equal(len(streamingResults)))
+	for i := range batchResults {
+		Expect(math.Float64bits(batchResults[i])).To(Equal(math.Float64bits(streamingResults[i])), "result %d", i)
+	}
+}
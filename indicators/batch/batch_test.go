@@ -0,0 +1,412 @@
+package batch_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/batch"
+	"time"
+)
+
+var _ = Describe("when splitting DOHLCV source data", func() {
+	It("should return the open, high, low and close prices and the volumes", func() {
+		data := []gotrade.DOHLCV{
+			gotrade.NewDOHLCVDataItem(time.Now(), 1.0, 2.0, 0.5, 1.5, 100.0),
+			gotrade.NewDOHLCVDataItem(time.Now(), 1.5, 3.0, 1.0, 2.5, 200.0)}
+
+		openPrices, highPrices, lowPrices, closePrices, volumes := batch.SplitDOHLCV(data)
+
+		Expect(openPrices).To(Equal([]float64{1.0, 1.5}))
+		Expect(highPrices).To(Equal([]float64{2.0, 3.0}))
+		Expect(lowPrices).To(Equal([]float64{0.5, 1.0}))
+		Expect(closePrices).To(Equal([]float64{1.5, 2.5}))
+		Expect(volumes).To(Equal([]float64{100.0, 200.0}))
+	})
+})
+
+var _ = Describe("when calculating a batch indicator with invalid parameters", func() {
+	It("should return the error of the streaming indicator", func() {
+		_, streamingErr := indicators.NewSmaWithoutStorage(1, func(dataItem float64, streamBarIndex int) {})
+		results, err := batch.Sma([]float64{1.0, 2.0}, 1)
+
+		Expect(results).To(BeNil())
+		Expect(err).To(Equal(streamingErr))
+	})
+
+	It("should return the appropriate error when the input arrays have different lengths", func() {
+		results, err := batch.Atr([]float64{2.0, 3.0}, []float64{1.0, 2.0}, []float64{1.5}, 14)
+
+		Expect(results).To(BeNil())
+		Expect(err).To(Equal(batch.ErrInputLengthsDiffer))
+	})
+})
+
+var _ = Describe("when calculating a batch indicator with less source data than its lookback period", func() {
+	It("should return no results", func() {
+		results, err := batch.Sma([]float64{1.0, 2.0}, 10)
+
+		Expect(err).To(BeNil())
+		Expect(results).To(BeEmpty())
+	})
+})
+
+var _ = Describe("when calculating the batch indicators of a year's data", func() {
+	var (
+		openPrices  []float64
+		highPrices  []float64
+		lowPrices   []float64
+		closePrices []float64
+		volumes     []float64
+	)
+
+	BeforeEach(func() {
+		openPrices, highPrices, lowPrices, closePrices, volumes = batch.SplitDOHLCV(streamYear())
+	})
+
+	Describe("the moving averages", func() {
+		It("should calculate the same Sma as the streaming indicator", func() {
+			ind, _ := indicators.NewSma(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Sma(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Ema as the streaming indicator", func() {
+			ind, _ := indicators.NewEma(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Ema(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Wma as the streaming indicator", func() {
+			ind, _ := indicators.NewWma(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Wma(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Dema as the streaming indicator", func() {
+			ind, _ := indicators.NewDema(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Dema(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Tema as the streaming indicator", func() {
+			ind, _ := indicators.NewTema(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Tema(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Trima as the streaming indicator for odd and even time periods", func() {
+			for _, timePeriod := range []int{9, 10} {
+				ind, _ := indicators.NewTrima(timePeriod, gotrade.UseClosePrice)
+				streamYear(ind)
+				results, err := batch.Trima(closePrices, timePeriod)
+				expectIdentical(results, err, ind.Data)
+			}
+		})
+
+		It("should calculate the same Kama as the streaming indicator", func() {
+			ind, _ := indicators.NewKama(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Kama(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+	})
+
+	Describe("the statistics", func() {
+		It("should calculate the same Var as the streaming indicator", func() {
+			ind, _ := indicators.NewVar(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Var(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same StdDev as the streaming indicator", func() {
+			ind, _ := indicators.NewStdDev(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.StdDev(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same BollingerBands as the streaming indicator", func() {
+			ind, _ := indicators.NewBollingerBands(20, gotrade.UseClosePrice)
+			streamYear(ind)
+			upperBand, middleBand, lowerBand, err := batch.BollingerBands(closePrices, 20)
+			expectIdentical(upperBand, err, ind.UpperBand)
+			expectIdentical(middleBand, err, ind.MiddleBand)
+			expectIdentical(lowerBand, err, ind.LowerBand)
+		})
+
+		It("should calculate the same Hhv and Llv as the streaming indicators", func() {
+			hhv, _ := indicators.NewHhv(10, gotrade.UseClosePrice)
+			llv, _ := indicators.NewLlv(10, gotrade.UseClosePrice)
+			streamYear(hhv, llv)
+			highs, err := batch.Hhv(closePrices, 10)
+			expectIdentical(highs, err, hhv.Data)
+			lows, err := batch.Llv(closePrices, 10)
+			expectIdentical(lows, err, llv.Data)
+		})
+
+		It("should calculate the same HhvBars and LlvBars as the streaming indicators", func() {
+			hhvBars, _ := indicators.NewHhvBars(10, gotrade.UseClosePrice)
+			llvBars, _ := indicators.NewLlvBars(10, gotrade.UseClosePrice)
+			streamYear(hhvBars, llvBars)
+			highBars, err := batch.HhvBars(closePrices, 10)
+			Expect(err).To(BeNil())
+			Expect(highBars).To(Equal(hhvBars.Data))
+			lowBars, err := batch.LlvBars(closePrices, 10)
+			Expect(err).To(BeNil())
+			Expect(lowBars).To(Equal(llvBars.Data))
+		})
+
+		It("should calculate the same Hhv, Llv and their bars as the streaming indicators for negative source data", func() {
+			useNegativeClosePrice := func(dataItem gotrade.DOHLCV) float64 {
+				return -dataItem.C()
+			}
+			hhv, _ := indicators.NewHhv(10, useNegativeClosePrice)
+			hhvBars, _ := indicators.NewHhvBars(10, useNegativeClosePrice)
+			llv, _ := indicators.NewLlv(10, useNegativeClosePrice)
+			llvBars, _ := indicators.NewLlvBars(10, useNegativeClosePrice)
+			streamYear(hhv, hhvBars, llv, llvBars)
+
+			negativeClosePrices := make([]float64, len(closePrices))
+			for i, closePrice := range closePrices {
+				negativeClosePrices[i] = -closePrice
+			}
+
+			highs, err := batch.Hhv(negativeClosePrices, 10)
+			expectIdentical(highs, err, hhv.Data)
+			lows, err := batch.Llv(negativeClosePrices, 10)
+			expectIdentical(lows, err, llv.Data)
+			highBars, err := batch.HhvBars(negativeClosePrices, 10)
+			Expect(err).To(BeNil())
+			Expect(highBars).To(Equal(hhvBars.Data))
+			lowBars, err := batch.LlvBars(negativeClosePrices, 10)
+			Expect(err).To(BeNil())
+			Expect(lowBars).To(Equal(llvBars.Data))
+		})
+
+		It("should calculate the same linear regressions as the streaming indicators", func() {
+			linReg, _ := indicators.NewLinReg(14, gotrade.UseClosePrice)
+			linRegAng, _ := indicators.NewLinRegAng(14, gotrade.UseClosePrice)
+			linRegInt, _ := indicators.NewLinRegInt(14, gotrade.UseClosePrice)
+			linRegSlp, _ := indicators.NewLinRegSlp(14, gotrade.UseClosePrice)
+			tsf, _ := indicators.NewTsf(14, gotrade.UseClosePrice)
+			streamYear(linReg, linRegAng, linRegInt, linRegSlp, tsf)
+
+			results, err := batch.LinReg(closePrices, 14)
+			expectIdentical(results, err, linReg.Data)
+			results, err = batch.LinRegAng(closePrices, 14)
+			expectIdentical(results, err, linRegAng.Data)
+			results, err = batch.LinRegInt(closePrices, 14)
+			expectIdentical(results, err, linRegInt.Data)
+			results, err = batch.LinRegSlp(closePrices, 14)
+			expectIdentical(results, err, linRegSlp.Data)
+			results, err = batch.Tsf(closePrices, 14)
+			expectIdentical(results, err, tsf.Data)
+		})
+	})
+
+	Describe("the momentum indicators", func() {
+		It("should calculate the same Mom as the streaming indicator", func() {
+			ind, _ := indicators.NewMom(10, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Mom(closePrices, 10)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same rates of change as the streaming indicators", func() {
+			roc, _ := indicators.NewRoc(10, gotrade.UseClosePrice)
+			rocP, _ := indicators.NewRocP(10, gotrade.UseClosePrice)
+			rocR, _ := indicators.NewRocR(10, gotrade.UseClosePrice)
+			rocR100, _ := indicators.NewRocR100(10, gotrade.UseClosePrice)
+			streamYear(roc, rocP, rocR, rocR100)
+
+			results, err := batch.Roc(closePrices, 10)
+			expectIdentical(results, err, roc.Data)
+			results, err = batch.RocP(closePrices, 10)
+			expectIdentical(results, err, rocP.Data)
+			results, err = batch.RocR(closePrices, 10)
+			expectIdentical(results, err, rocR.Data)
+			results, err = batch.RocR100(closePrices, 10)
+			expectIdentical(results, err, rocR100.Data)
+		})
+
+		It("should calculate the same Rsi as the streaming indicator", func() {
+			ind, _ := indicators.NewRsi(14, gotrade.UseClosePrice)
+			streamYear(ind)
+			results, err := batch.Rsi(closePrices, 14)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Macd as the streaming indicator", func() {
+			ind, _ := indicators.NewMacd(12, 26, 9, gotrade.UseClosePrice)
+			streamYear(ind)
+			macd, signal, histogram, err := batch.Macd(closePrices, 12, 26, 9)
+			expectIdentical(macd, err, ind.Macd)
+			expectIdentical(signal, err, ind.Signal)
+			expectIdentical(histogram, err, ind.Histogram)
+		})
+
+		It("should calculate the same Macd as the streaming indicator when the fast time period is the longer", func() {
+			ind, _ := indicators.NewMacd(26, 12, 9, gotrade.UseClosePrice)
+			streamYear(ind)
+			macd, signal, histogram, err := batch.Macd(closePrices, 26, 12, 9)
+			expectIdentical(macd, err, ind.Macd)
+			expectIdentical(signal, err, ind.Signal)
+			expectIdentical(histogram, err, ind.Histogram)
+		})
+
+		It("should calculate the same StochOsc as the streaming indicator", func() {
+			ind, _ := indicators.NewStochOsc(5, 3, 3)
+			streamYear(ind)
+			slowK, slowD, err := batch.StochOsc(highPrices, lowPrices, closePrices, 5, 3, 3)
+			expectIdentical(slowK, err, ind.SlowK)
+			expectIdentical(slowD, err, ind.SlowD)
+		})
+
+		It("should calculate the same StochRsi as the streaming indicator", func() {
+			ind, _ := indicators.NewStochRsi(14, 5, 3)
+			streamYear(ind)
+			fastK, fastD, err := batch.StochRsi(closePrices, 14, 5, 3)
+			expectIdentical(fastK, err, ind.SlowK)
+			expectIdentical(fastD, err, ind.SlowD)
+		})
+
+		It("should calculate the same WillR as the streaming indicator", func() {
+			ind, _ := indicators.NewWillR(14)
+			streamYear(ind)
+			results, err := batch.WillR(highPrices, lowPrices, closePrices, 14)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Cci as the streaming indicator", func() {
+			ind, _ := indicators.NewCci(14)
+			streamYear(ind)
+			results, err := batch.Cci(highPrices, lowPrices, closePrices, 14)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should calculate the same Aroon and AroonOsc as the streaming indicators", func() {
+			aroon, _ := indicators.NewAroon(25)
+			aroonOsc, _ := indicators.NewAroonOsc(25)
+			streamYear(aroon, aroonOsc)
+			up, down, err := batch.Aroon(highPrices, lowPrices, 25)
+			expectIdentical(up, err, aroon.Up)
+			expectIdentical(down, err, aroon.Down)
+			results, err := batch.AroonOsc(highPrices, lowPrices, 25)
+			expectIdentical(results, err, aroonOsc.Data)
+		})
+	})
+
+	Describe("the directional movement indicators", func() {
+		It("should calculate the same TrueRange and Atr as the streaming indicators", func() {
+			trueRange, _ := indicators.NewTrueRange()
+			atr, _ := indicators.NewAtr(14)
+			streamYear(trueRange, atr)
+			results, err := batch.TrueRange(highPrices, lowPrices, closePrices)
+			expectIdentical(results, err, trueRange.Data)
+			results, err = batch.Atr(highPrices, lowPrices, closePrices, 14)
+			expectIdentical(results, err, atr.Data)
+		})
+
+		It("should calculate the same PlusDm and MinusDm as the streaming indicators", func() {
+			for _, timePeriod := range []int{1, 2, 14} {
+				plusDm, _ := indicators.NewPlusDm(timePeriod)
+				minusDm, _ := indicators.NewMinusDm(timePeriod)
+				streamYear(plusDm, minusDm)
+				results, err := batch.PlusDm(highPrices, lowPrices, timePeriod)
+				expectIdentical(results, err, plusDm.Data)
+				results, err = batch.MinusDm(highPrices, lowPrices, timePeriod)
+				expectIdentical(results, err, minusDm.Data)
+			}
+		})
+
+		It("should calculate the same PlusDi and MinusDi as the streaming indicators", func() {
+			for _, timePeriod := range []int{1, 14} {
+				plusDi, _ := indicators.NewPlusDi(timePeriod)
+				minusDi, _ := indicators.NewMinusDi(timePeriod)
+				streamYear(plusDi, minusDi)
+				results, err := batch.PlusDi(highPrices, lowPrices, closePrices, timePeriod)
+				expectIdentical(results, err, plusDi.Data)
+				results, err = batch.MinusDi(highPrices, lowPrices, closePrices, timePeriod)
+				expectIdentical(results, err, minusDi.Data)
+			}
+		})
+
+		It("should calculate the same Dx, Adx and Adxr as the streaming indicators", func() {
+			dx, _ := indicators.NewDx(14)
+			adx, _ := indicators.NewAdx(14)
+			adxr, _ := indicators.NewAdxr(14)
+			streamYear(dx, adx, adxr)
+			results, err := batch.Dx(highPrices, lowPrices, closePrices, 14)
+			expectIdentical(results, err, dx.Data)
+			results, err = batch.Adx(highPrices, lowPrices, closePrices, 14)
+			expectIdentical(results, err, adx.Data)
+			results, err = batch.Adxr(highPrices, lowPrices, closePrices, 14)
+			expectIdentical(results, err, adxr.Data)
+		})
+
+		It("should calculate the same Sar as the streaming indicator", func() {
+			ind, _ := indicators.NewSar(0.02, 0.2)
+			streamYear(ind)
+			results, err := batch.Sar(highPrices, lowPrices, 0.02, 0.2)
+			expectIdentical(results, err, ind.Data)
+		})
+
+		It("should confirm the same ZigZag pivots as the streaming indicators", func() {
+			zigzag, _ := indicators.NewZigZag(3.0)
+			atrZigZag, _ := indicators.NewAtrZigZag(14, 2.0)
+			streamYear(zigzag, atrZigZag)
+
+			pivots, err := batch.ZigZag(highPrices, lowPrices, 3.0)
+			Expect(err).To(BeNil())
+			Expect(len(pivots)).To(BeNumerically(">", 0))
+			Expect(pivots).To(Equal(zigzag.Data))
+
+			pivots, err = batch.AtrZigZag(highPrices, lowPrices, closePrices, 14, 2.0)
+			Expect(err).To(BeNil())
+			Expect(len(pivots)).To(BeNumerically(">", 0))
+			Expect(pivots).To(Equal(atrZigZag.Data))
+		})
+	})
+
+	Describe("the price and volume indicators", func() {
+		It("should calculate the same prices as the streaming indicators", func() {
+			avgPrice, _ := indicators.NewAvgPrice()
+			medPrice, _ := indicators.NewMedPrice()
+			typPrice, _ := indicators.NewTypPrice()
+			streamYear(avgPrice, medPrice, typPrice)
+			results, err := batch.AvgPrice(openPrices, highPrices, lowPrices, closePrices)
+			expectIdentical(results, err, avgPrice.Data)
+			results, err = batch.MedPrice(highPrices, lowPrices)
+			expectIdentical(results, err, medPrice.Data)
+			results, err = batch.TypPrice(highPrices, lowPrices, closePrices)
+			expectIdentical(results, err, typPrice.Data)
+		})
+
+		It("should calculate the same Adl and ChaikinOsc as the streaming indicators", func() {
+			adl, _ := indicators.NewAdl()
+			chaikinOsc, _ := indicators.NewChaikinOsc(3, 10)
+			streamYear(adl, chaikinOsc)
+			results, err := batch.Adl(highPrices, lowPrices, closePrices, volumes)
+			expectIdentical(results, err, adl.Data)
+			results, err = batch.ChaikinOsc(highPrices, lowPrices, closePrices, volumes, 3, 10)
+			expectIdentical(results, err, chaikinOsc.Data)
+		})
+
+		It("should calculate the same Obv and Mfi as the streaming indicators", func() {
+			obv, _ := indicators.NewObv()
+			mfi, _ := indicators.NewMfi(14)
+			streamYear(obv, mfi)
+			results, err := batch.Obv(closePrices, volumes)
+			expectIdentical(results, err, obv.Data)
+			results, err = batch.Mfi(highPrices, lowPrices, closePrices, volumes, 14)
+			expectIdentical(results, err, mfi.Data)
+		})
+	})
+})
@@ -0,0 +1,302 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+// TrueRange calculates the True Range of the high, low and close prices
+func TrueRange(highPrices []float64, lowPrices []float64, closePrices []float64) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewTrueRangeWithoutStorage(discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return trueRange(highPrices, lowPrices, closePrices, newOutput(len(closePrices), ind.GetLookbackPeriod())), nil
+}
+
+func trueRange(highPrices []float64, lowPrices []float64, closePrices []float64, out []float64) []float64 {
+	for i := 1; i < len(closePrices); i++ {
+		high := math.Max(highPrices[i], closePrices[i-1])
+		low := math.Min(lowPrices[i], closePrices[i-1])
+		out = append(out, high-low)
+	}
+	return out
+}
+
+// Atr calculates the Average True Range (Atr) of the high, low and close prices
+func Atr(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewAtrWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return atr(highPrices, lowPrices, closePrices, timePeriod, newOutput(len(closePrices), ind.GetLookbackPeriod())), nil
+}
+
+func atr(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int, out []float64) []float64 {
+	multiplier := float64(timePeriod - 1)
+
+	// the first average is the simple average of the true ranges, after which it is smoothed
+	var periodTotal float64
+	var previousAvgTrueRange float64
+	for i, value := range trueRange(highPrices, lowPrices, closePrices, newOutput(len(closePrices), 1)) {
+		if i < timePeriod-1 {
+			periodTotal += value
+		} else if i == timePeriod-1 {
+			periodTotal += value
+			previousAvgTrueRange = periodTotal / float64(timePeriod)
+			out = append(out, previousAvgTrueRange)
+		} else {
+			previousAvgTrueRange = ((previousAvgTrueRange * multiplier) + value) / float64(timePeriod)
+			out = append(out, previousAvgTrueRange)
+		}
+	}
+	return out
+}
+
+// PlusDm calculates the Plus Directional Movement (PlusDm) of the high and low prices
+func PlusDm(highPrices []float64, lowPrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewPlusDmWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return directionalMovement(highPrices, lowPrices, timePeriod, true, ind.GetLookbackPeriod()), nil
+}
+
+// MinusDm calculates the Minus Directional Movement (MinusDm) of the high and low prices
+func MinusDm(highPrices []float64, lowPrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewMinusDmWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return directionalMovement(highPrices, lowPrices, timePeriod, false, ind.GetLookbackPeriod()), nil
+}
+
+// movement returns the plus, or minus, directional movement of the bar from the previous bar
+func movement(highPrices []float64, lowPrices []float64, i int, plus bool) (dm float64, ok bool) {
+	diffP := highPrices[i] - highPrices[i-1]
+	diffM := lowPrices[i-1] - lowPrices[i]
+
+	if plus {
+		return diffP, (diffP > 0) && (diffP > diffM)
+	}
+	return diffM, (diffM > 0) && (diffP < diffM)
+}
+
+func directionalMovement(highPrices []float64, lowPrices []float64, timePeriod int, plus bool, lookbackPeriod int) []float64 {
+	out := newOutput(len(highPrices), lookbackPeriod)
+
+	var previousDm float64
+	for i := 1; i < len(highPrices); i++ {
+		dm, ok := movement(highPrices, lowPrices, i, plus)
+
+		if lookbackPeriod == 1 {
+			var result float64
+			if ok {
+				result = dm
+			} else {
+				result = 0
+			}
+			out = append(out, result)
+		} else if i < timePeriod {
+			if ok {
+				previousDm += dm
+			}
+
+			if i == timePeriod-1 {
+				out = append(out, previousDm)
+			}
+		} else {
+			var result float64
+			if ok {
+				result = previousDm - (previousDm / float64(timePeriod)) + dm
+			} else {
+				result = previousDm - (previousDm / float64(timePeriod))
+			}
+			out = append(out, result)
+
+			previousDm = result
+		}
+	}
+	return out
+}
+
+// PlusDi calculates the Plus Directional Indicator (PlusDi) of the high, low and close prices
+func PlusDi(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewPlusDiWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return directionalIndicator(highPrices, lowPrices, closePrices, timePeriod, true, ind.GetLookbackPeriod()), nil
+}
+
+// MinusDi calculates the Minus Directional Indicator (MinusDi) of the high, low and close prices
+func MinusDi(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewMinusDiWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return directionalIndicator(highPrices, lowPrices, closePrices, timePeriod, false, ind.GetLookbackPeriod()), nil
+}
+
+func directionalIndicator(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int, plus bool, lookbackPeriod int) []float64 {
+	out := newOutput(len(closePrices), lookbackPeriod)
+
+	var previousDm float64
+	var previousTrueRange float64
+	for i := 1; i < len(closePrices); i++ {
+		dm, ok := movement(highPrices, lowPrices, i, plus)
+
+		if lookbackPeriod == 1 {
+			// the streaming indicator's true range receives the bar a second time, by when the previous close is
+			// the bar's own close
+			currentTrueRange := math.Max(highPrices[i], closePrices[i]) - math.Min(lowPrices[i], closePrices[i])
+
+			var result float64
+			if ok && currentTrueRange != 0.0 {
+				result = dm / currentTrueRange
+			} else {
+				result = 0
+			}
+			out = append(out, result)
+			continue
+		}
+
+		currentTrueRange := math.Max(highPrices[i], closePrices[i-1]) - math.Min(lowPrices[i], closePrices[i-1])
+		if i < timePeriod {
+			if ok {
+				previousDm += dm
+			}
+			previousTrueRange += currentTrueRange
+		} else {
+			previousTrueRange = previousTrueRange - (previousTrueRange / float64(timePeriod)) + currentTrueRange
+			if ok {
+				previousDm = previousDm - (previousDm / float64(timePeriod)) + dm
+			} else {
+				previousDm = previousDm - (previousDm / float64(timePeriod))
+			}
+
+			var result float64
+			if previousTrueRange != 0.0 {
+				result = float64(100.0) * previousDm / previousTrueRange
+			} else {
+				result = 0.0
+			}
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// Dx calculates the Directional Movement Index (Dx) of the high, low and close prices
+func Dx(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewDxWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return dx(highPrices, lowPrices, closePrices, timePeriod, ind.GetLookbackPeriod()), nil
+}
+
+func dx(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int, lookbackPeriod int) []float64 {
+	out := newOutput(len(closePrices), lookbackPeriod)
+
+	plusDi := directionalIndicator(highPrices, lowPrices, closePrices, timePeriod, true, timePeriod)
+	minusDi := directionalIndicator(highPrices, lowPrices, closePrices, timePeriod, false, timePeriod)
+	for i, currentPlusDi := range plusDi {
+		var result float64
+		tmp := minusDi[i] + currentPlusDi
+		if tmp != 0.0 {
+			result = 100.0 * (math.Abs(minusDi[i]-currentPlusDi) / tmp)
+		} else {
+			result = 0.0
+		}
+		out = append(out, result)
+	}
+	return out
+}
+
+// Adx calculates the Average Directional Index (Adx) of the high, low and close prices
+func Adx(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewAdxWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return adx(highPrices, lowPrices, closePrices, timePeriod, newOutput(len(closePrices), ind.GetLookbackPeriod())), nil
+}
+
+func adx(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int, out []float64) []float64 {
+	var sumDX float64
+	var previousAdx float64
+	for i, currentDX := range dx(highPrices, lowPrices, closePrices, timePeriod, timePeriod) {
+		if i < timePeriod-1 {
+			sumDX += currentDX
+		} else if i == timePeriod-1 {
+			sumDX += currentDX
+			previousAdx = sumDX / float64(timePeriod)
+			out = append(out, previousAdx)
+		} else {
+			previousAdx = (previousAdx*float64(timePeriod-1) + currentDX) / float64(timePeriod)
+			out = append(out, previousAdx)
+		}
+	}
+	return out
+}
+
+// Adxr calculates the Average Directional Index Rating (Adxr) of the high, low and close prices, the average of the
+// Adx and the Adx timePeriod-1 bars before
+func Adxr(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewAdxrWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	adxs := adx(highPrices, lowPrices, closePrices, timePeriod, newOutput(len(closePrices), (2*timePeriod)-1))
+	for i := timePeriod - 1; i < len(adxs); i++ {
+		out = append(out, (adxs[i]+adxs[i-timePeriod+1])/2.0)
+	}
+	return out, nil
+}
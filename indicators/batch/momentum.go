@@ -0,0 +1,355 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+// Mom calculates the Momentum (Mom) of the source data
+func Mom(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewMomWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(in), ind.GetLookbackPeriod())
+
+	for i := timePeriod; i < len(in); i++ {
+		out = append(out, in[i]-in[i-timePeriod])
+	}
+	return out, nil
+}
+
+// the rate of change of a price from a previous price
+type rocResult int
+
+const (
+	rocPercentage rocResult = iota
+	rocProportion
+	rocRatio
+	rocRatio100
+)
+
+// Roc calculates the Rate of Change (Roc) of the source data, ((price/previousPrice)-1)*100
+func Roc(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewRocWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return roc(in, timePeriod, rocPercentage, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+// RocP calculates the Rate of Change Percentage (RocP) of the source data, (price-previousPrice)/previousPrice
+func RocP(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewRocPWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return roc(in, timePeriod, rocProportion, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+// RocR calculates the Rate of Change Ratio (RocR) of the source data, price/previousPrice
+func RocR(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewRocRWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return roc(in, timePeriod, rocRatio, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+// RocR100 calculates the Rate of Change Ratio 100 Scale (RocR100) of the source data, (price/previousPrice)*100
+func RocR100(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewRocR100WithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return roc(in, timePeriod, rocRatio100, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+func roc(in []float64, timePeriod int, resultType rocResult, out []float64) []float64 {
+	for i := timePeriod; i < len(in); i++ {
+		previousPrice := in[i-timePeriod]
+
+		var result float64
+		if previousPrice != 0 {
+			switch resultType {
+			case rocPercentage:
+				result = 100.0 * ((in[i] / previousPrice) - 1)
+			case rocProportion:
+				result = (in[i] - previousPrice) / previousPrice
+			case rocRatio:
+				result = (in[i] / previousPrice)
+			case rocRatio100:
+				result = (in[i] / previousPrice) * 100.0
+			}
+		} else {
+			result = 0.0
+		}
+
+		out = append(out, result)
+	}
+	return out
+}
+
+// Rsi calculates the Relative Strength Index (Rsi) of the source data
+func Rsi(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewRsiWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsi(in, timePeriod, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+func rsi(in []float64, timePeriod int, out []float64) []float64 {
+	var previousGain float64
+	var previousLoss float64
+	for i := 1; i < len(in); i++ {
+		value := in[i]
+		previousClose := in[i-1]
+
+		if i > timePeriod {
+			previousGain *= float64(timePeriod - 1)
+			previousLoss *= float64(timePeriod - 1)
+		}
+
+		if value > previousClose {
+			previousGain += (value - previousClose)
+		} else {
+			previousLoss -= (value - previousClose)
+		}
+
+		if i >= timePeriod {
+			previousGain /= float64(timePeriod)
+			previousLoss /= float64(timePeriod)
+
+			//    Rsi = 100 * (prevGain/(prevGain+prevLoss))
+			var result float64
+			if previousGain+previousLoss == 0.0 {
+				result = 0.0
+			} else {
+				result = 100.0 * (previousGain / (previousGain + previousLoss))
+			}
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// Macd calculates the Moving Average Convergence Divergence (Macd) of the source data
+//	- macd: the fast Ema less the slow Ema
+//	- signal: the Ema of the macd
+//	- histogram: the macd less the signal
+func Macd(in []float64, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (macd []float64, signal []float64, histogram []float64, err error) {
+	ind, err := indicators.NewMacd(fastTimePeriod, slowTimePeriod, signalTimePeriod, gotrade.UseClosePrice)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lookback := ind.GetLookbackPeriod()
+
+	// the fast ema is shifted up so that it has valid data at the same time as the slow ema
+	emaSlowSkip := slowTimePeriod - fastTimePeriod
+	if emaSlowSkip < 0 {
+		emaSlowSkip = 0
+	} else if emaSlowSkip > len(in) {
+		emaSlowSkip = len(in)
+	}
+	emaFast := ema(in[emaSlowSkip:], fastTimePeriod, newOutput(len(in), emaSlowSkip+fastTimePeriod-1))
+	emaSlow := ema(in, slowTimePeriod, newOutput(len(in), slowTimePeriod-1))
+
+	// the macd line of each slow ema, with the latest fast ema at its bar
+	macdLine := newOutput(len(in), slowTimePeriod-1)
+	for i, slowValue := range emaSlow {
+		var fastValue float64
+		if fastIndex := i + slowTimePeriod - emaSlowSkip - fastTimePeriod; fastIndex >= 0 {
+			fastValue = emaFast[fastIndex]
+		}
+		macdLine = append(macdLine, fastValue-slowValue)
+	}
+
+	signal = ema(macdLine, signalTimePeriod, newOutput(len(in), lookback))
+
+	macd = newOutput(len(in), lookback)
+	histogram = newOutput(len(in), lookback)
+	offset := len(macdLine) - len(signal)
+	for i, signalValue := range signal {
+		macdValue := macdLine[i+offset]
+		macd = append(macd, macdValue)
+		histogram = append(histogram, macdValue-signalValue)
+	}
+	return macd, signal, histogram, nil
+}
+
+// StochOsc calculates the Stochastic Oscillator (StochOsc) of the high, low and close prices
+//	- slowK: the Sma of the fast %K
+//	- slowD: the Sma of the slow %K
+func StochOsc(highPrices []float64, lowPrices []float64, closePrices []float64, fastKTimePeriod int, slowKTimePeriod int, slowDTimePeriod int) (slowK []float64, slowD []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, nil, err
+	}
+
+	ind, err := indicators.NewStochOscWithoutStorage(fastKTimePeriod, slowKTimePeriod, slowDTimePeriod, discardPair)
+	if err != nil {
+		return nil, nil, err
+	}
+	lookback := ind.GetLookbackPeriod()
+
+	periodHighs, _ := highest(highPrices, fastKTimePeriod, newOutput(len(highPrices), fastKTimePeriod-1), nil)
+	periodLows, _ := lowest(lowPrices, fastKTimePeriod, newOutput(len(lowPrices), fastKTimePeriod-1), nil)
+
+	fastK := newOutput(len(closePrices), fastKTimePeriod-1)
+	for i, periodHigh := range periodHighs {
+		fastK = append(fastK, 100.0*((closePrices[i+fastKTimePeriod-1]-periodLows[i])/(periodHigh-periodLows[i])))
+	}
+
+	slowKMA := sma(fastK, slowKTimePeriod, newOutput(len(fastK), slowKTimePeriod-1))
+	slowD = sma(slowKMA, slowDTimePeriod, newOutput(len(closePrices), lookback))
+
+	slowK = newOutput(len(closePrices), lookback)
+	slowK = append(slowK, slowKMA[len(slowKMA)-len(slowD):]...)
+	return slowK, slowD, nil
+}
+
+// StochRsi calculates the Stochastic Relative Strength Index (StochRsi) of the source data
+//	- fastK: the stochastic of the Rsi
+//	- fastD: the Sma of the fast %K
+func StochRsi(in []float64, timePeriod int, fastKTimePeriod int, fastDTimePeriod int) (fastK []float64, fastD []float64, err error) {
+	ind, err := indicators.NewStochRsiWithoutStorage(timePeriod, fastKTimePeriod, fastDTimePeriod, discardPair)
+	if err != nil {
+		return nil, nil, err
+	}
+	lookback := ind.GetLookbackPeriod()
+
+	rsis := rsi(in, timePeriod, newOutput(len(in), timePeriod))
+	periodHighs, _ := highest(rsis, fastKTimePeriod, newOutput(len(rsis), fastKTimePeriod-1), nil)
+	periodLows, _ := lowest(rsis, fastKTimePeriod, newOutput(len(rsis), fastKTimePeriod-1), nil)
+
+	fastKs := newOutput(len(rsis), fastKTimePeriod-1)
+	for i, periodHigh := range periodHighs {
+		var result float64
+		diff := periodHigh - periodLows[i]
+		if diff != 0 {
+			result = 100.0 * ((rsis[i+fastKTimePeriod-1] - periodLows[i]) / diff)
+		} else {
+			result = 0
+		}
+		fastKs = append(fastKs, result)
+	}
+
+	fastD = sma(fastKs, fastDTimePeriod, newOutput(len(in), lookback))
+
+	fastK = newOutput(len(in), lookback)
+	fastK = append(fastK, fastKs[len(fastKs)-len(fastD):]...)
+	return fastK, fastD, nil
+}
+
+// WillR calculates the Williams Percent R (WillR) of the high, low and close prices
+func WillR(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewWillRWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	lowIndexes := extremeIndexes(lowPrices, timePeriod, false, false)
+	for i, highIndex := range extremeIndexes(highPrices, timePeriod, true, false) {
+		highestHigh := highPrices[highIndex]
+		lowestLow := lowPrices[lowIndexes[i]]
+		closePrice := closePrices[i+timePeriod-1]
+
+		out = append(out, (highestHigh-closePrice)/(highestHigh-lowestLow)*-100.0)
+	}
+	return out, nil
+}
+
+// Cci calculates the Commodity Channel Index (Cci) of the high, low and close prices
+func Cci(highPrices []float64, lowPrices []float64, closePrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewCciWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	var factor float64 = 0.015
+	typicalPrices := make([]float64, len(closePrices))
+	for i := range closePrices {
+		typicalPrices[i] = (highPrices[i] + lowPrices[i] + closePrices[i]) / 3.0
+	}
+
+	for i, typicalPriceAvg := range sma(typicalPrices, timePeriod, newOutput(len(typicalPrices), timePeriod-1)) {
+		period := typicalPrices[i : i+timePeriod]
+
+		// calculate the mean deviation
+		var meanDeviation float64 = 0.0
+		for _, value := range period {
+			meanDeviation += math.Abs(value - typicalPriceAvg)
+		}
+		meanDeviation /= float64(timePeriod)
+
+		out = append(out, ((period[timePeriod-1] - typicalPriceAvg) / (factor * meanDeviation)))
+	}
+	return out, nil
+}
+
+// Aroon calculates the Aroon Up and Aroon Down of the high and low prices
+func Aroon(highPrices []float64, lowPrices []float64, timePeriod int) (up []float64, down []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices); err != nil {
+		return nil, nil, err
+	}
+
+	ind, err := indicators.NewAroonWithoutStorage(timePeriod, discardPair)
+	if err != nil {
+		return nil, nil, err
+	}
+	up = newOutput(len(highPrices), ind.GetLookbackPeriod())
+	down = newOutput(len(highPrices), ind.GetLookbackPeriod())
+
+	up, down = aroon(highPrices, lowPrices, timePeriod, up, down)
+	return up, down, nil
+}
+
+func aroon(highPrices []float64, lowPrices []float64, timePeriod int, up []float64, down []float64) ([]float64, []float64) {
+	aroonFactor := 100.0 / float64(timePeriod)
+
+	// the latest of equal highs and lows is used
+	lowIndexes := extremeIndexes(lowPrices, timePeriod+1, false, true)
+	for i, highIndex := range extremeIndexes(highPrices, timePeriod+1, true, true) {
+		daysSinceHigh := i + timePeriod - highIndex
+		daysSinceLow := i + timePeriod - lowIndexes[i]
+
+		up = append(up, aroonFactor*float64(timePeriod-daysSinceHigh))
+		down = append(down, aroonFactor*float64(timePeriod-daysSinceLow))
+	}
+	return up, down
+}
+
+// AroonOsc calculates the Aroon Oscillator (AroonOsc) of the high and low prices, the Aroon Up less the Aroon Down
+func AroonOsc(highPrices []float64, lowPrices []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewAroonOscWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(highPrices), ind.GetLookbackPeriod())
+
+	up, down := aroon(highPrices, lowPrices, timePeriod, newOutput(len(highPrices), timePeriod), newOutput(len(highPrices), timePeriod))
+	for i, value := range up {
+		out = append(out, value-down[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,207 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+// Sma calculates the Simple Moving Average (Sma) of the source data
+func Sma(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewSmaWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return sma(in, timePeriod, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+func sma(in []float64, timePeriod int, out []float64) []float64 {
+	var periodTotal indicators.KahanSum
+	for i, value := range in {
+		if i >= timePeriod {
+			periodTotal.Sub(in[i-timePeriod])
+		}
+		periodTotal.Add(value)
+		if i >= timePeriod-1 {
+			out = append(out, periodTotal.Value()/float64(timePeriod))
+		}
+	}
+	return out
+}
+
+// Ema calculates the Exponential Moving Average (Ema) of the source data
+func Ema(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewEmaWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return ema(in, timePeriod, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+func ema(in []float64, timePeriod int, out []float64) []float64 {
+	multiplier := float64(2.0 / float64(timePeriod+1.0))
+
+	var periodTotal float64
+	var previousEma float64
+	for i, value := range in {
+		if i < timePeriod-1 {
+			periodTotal += value
+		} else if i == timePeriod-1 {
+			periodTotal += value
+			previousEma = periodTotal / float64(timePeriod)
+			out = append(out, previousEma)
+		} else {
+			previousEma = (value-previousEma)*multiplier + previousEma
+			out = append(out, previousEma)
+		}
+	}
+	return out
+}
+
+// Wma calculates the Weighted Moving Average (Wma) of the source data
+func Wma(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewWmaWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(in), ind.GetLookbackPeriod())
+
+	var periodWeightTotal int
+	for i := 1; i <= timePeriod; i++ {
+		periodWeightTotal += i
+	}
+
+	for i := timePeriod - 1; i < len(in); i++ {
+		// the weights are applied by repeated addition, as the streaming indicator does
+		var sum float64
+		for iter, value := range in[i-timePeriod+1 : i+1] {
+			var localSum float64
+			for j := 0; j <= iter; j++ {
+				localSum += value
+			}
+			sum += localSum
+		}
+		out = append(out, sum/float64(periodWeightTotal))
+	}
+	return out, nil
+}
+
+// Dema calculates the Double Exponential Moving Average (Dema) of the source data
+func Dema(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewDemaWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(in), ind.GetLookbackPeriod())
+
+	ema1 := ema(in, timePeriod, newOutput(len(in), timePeriod-1))
+	ema2 := ema(ema1, timePeriod, newOutput(len(ema1), timePeriod-1))
+
+	offset := len(ema1) - len(ema2)
+	for i, value := range ema2 {
+		out = append(out, (2*ema1[i+offset])-value)
+	}
+	return out, nil
+}
+
+// Tema calculates the Triple Exponential Moving Average (Tema) of the source data
+func Tema(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewTemaWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(in), ind.GetLookbackPeriod())
+
+	ema1 := ema(in, timePeriod, newOutput(len(in), timePeriod-1))
+	ema2 := ema(ema1, timePeriod, newOutput(len(ema1), timePeriod-1))
+	ema3 := ema(ema2, timePeriod, newOutput(len(ema2), timePeriod-1))
+
+	offset1 := len(ema1) - len(ema3)
+	offset2 := len(ema2) - len(ema3)
+	for i, value := range ema3 {
+		out = append(out, (3*ema1[i+offset1]-3*ema2[i+offset2])+value)
+	}
+	return out, nil
+}
+
+// Trima calculates the Triangular Moving Average (Trima) of the source data
+func Trima(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewTrimaWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	var sma1Period int
+	var sma2Period int
+	if timePeriod%2 == 0 {
+		sma1Period = timePeriod / 2
+		sma2Period = (timePeriod / 2) + 1
+	} else {
+		sma1Period = (timePeriod + 1) / 2
+		sma2Period = (timePeriod + 1) / 2
+	}
+
+	sma1 := sma(in, sma1Period, newOutput(len(in), sma1Period-1))
+	return sma(sma1, sma2Period, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+// Kama calculates the Kaufman Adaptive Moving Average (Kama) of the source data
+func Kama(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewKamaWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(in), ind.GetLookbackPeriod())
+
+	constantMax := float64(2.0 / (30.0 + 1.0))
+	constantDiff := float64((2.0 / (2.0 + 1.0)) - (2.0 / (30.0 + 1.0)))
+
+	var sumROC indicators.KahanSum
+	var previousKama float64
+	previousClose := math.SmallestNonzeroFloat64
+	for i, value := range in {
+		if i <= timePeriod {
+			if previousClose > math.SmallestNonzeroFloat64 {
+				sumROC.Add(math.Abs(value - previousClose))
+			}
+		}
+
+		if i >= timePeriod {
+			var periodROC float64
+			if i == timePeriod {
+				previousKama = previousClose
+				periodROC = value - in[0]
+			} else {
+				closeMinusN := in[i-timePeriod-1]
+				closeMinusN1 := in[i-timePeriod]
+				periodROC = value - closeMinusN1
+
+				sumROC.Sub(math.Abs(closeMinusN1 - closeMinusN))
+				sumROC.Add(math.Abs(value - previousClose))
+			}
+
+			// calculate the efficiency ratio
+			var er float64
+			if sumROC.Value() <= periodROC || isZero(sumROC.Value()) {
+				er = 1.0
+			} else {
+				er = math.Abs(periodROC / sumROC.Value())
+			}
+
+			sc := (er * constantDiff) + constantMax
+			sc *= sc
+			previousKama = ((value - previousKama) * sc) + previousKama
+
+			out = append(out, previousKama)
+		}
+
+		previousClose = value
+	}
+	return out, nil
+}
+
+func isZero(value float64) bool {
+	var epsilon float64 = 0.00000000000001
+	return (((-epsilon) < value) && (value < epsilon))
+}
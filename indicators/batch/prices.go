@@ -0,0 +1,59 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// AvgPrice calculates the Average Price (AvgPrice) of the open, high, low and close prices
+func AvgPrice(openPrices []float64, highPrices []float64, lowPrices []float64, closePrices []float64) (out []float64, err error) {
+	if err = checkLengths(openPrices, highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewAvgPriceWithoutStorage(discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	for i, closePrice := range closePrices {
+		out = append(out, (openPrices[i]+highPrices[i]+lowPrices[i]+closePrice)/float64(4.0))
+	}
+	return out, nil
+}
+
+// MedPrice calculates the Median Price (MedPrice) of the high and low prices
+func MedPrice(highPrices []float64, lowPrices []float64) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewMedPriceWithoutStorage(discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(highPrices), ind.GetLookbackPeriod())
+
+	for i, highPrice := range highPrices {
+		out = append(out, (highPrice+lowPrices[i])/float64(2.0))
+	}
+	return out, nil
+}
+
+// TypPrice calculates the Typical Price (TypPrice) of the high, low and close prices
+func TypPrice(highPrices []float64, lowPrices []float64, closePrices []float64) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewTypPriceWithoutStorage(discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	for i, closePrice := range closePrices {
+		out = append(out, (highPrices[i]+lowPrices[i]+closePrice)/float64(3.0))
+	}
+	return out, nil
+}
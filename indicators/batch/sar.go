@@ -0,0 +1,167 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// Sar calculates the Parabolic Stop and Reverse (Sar) of the high and low prices
+func Sar(highPrices []float64, lowPrices []float64, accelerationFactor float64, accelerationFactorMax float64) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewSarWithoutStorage(accelerationFactor, accelerationFactorMax, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(highPrices), ind.GetLookbackPeriod())
+	if len(highPrices) < 2 {
+		return out, nil
+	}
+
+	var isLong bool
+	var extremePoint float64
+	var previousSar float64
+	acceleration := accelerationFactor
+	previousHigh := highPrices[0]
+	previousLow := lowPrices[0]
+	for i := 1; i < len(highPrices); i++ {
+		high := highPrices[i]
+		low := lowPrices[i]
+
+		if i == 1 {
+			// the initial direction is short when there is minus directional movement between the first two bars
+			_, isShort := movement(highPrices, lowPrices, i, false)
+			isLong = !isShort
+			if isLong {
+				extremePoint = high
+				previousSar = previousLow
+			} else {
+				extremePoint = low
+				previousSar = previousHigh
+			}
+
+			// the high low of the first bar is used as the sar for the second bar, as TA-Lib does
+			previousHigh = high
+			previousLow = low
+		}
+
+		if isLong {
+			if low <= previousSar {
+				// switch to short if the low penetrates the Sar value
+				isLong = false
+				previousSar = extremePoint
+
+				// make sure the overridden Sar is within yesterdays and todays range
+				if previousSar < previousHigh {
+					previousSar = previousHigh
+				}
+				if previousSar < high {
+					previousSar = high
+				}
+
+				out = append(out, previousSar)
+
+				// adjust af and extremePoint
+				acceleration = accelerationFactor
+				extremePoint = low
+
+				// calculate the new Sar
+				var diff float64 = extremePoint - previousSar
+				previousSar = previousSar + acceleration*(diff)
+
+				// make sure the overridden Sar is within yesterdays and todays range
+				if previousSar < previousHigh {
+					previousSar = previousHigh
+				}
+				if previousSar < high {
+					previousSar = high
+				}
+			} else {
+				// no switch, output the current Sar
+				out = append(out, previousSar)
+
+				if high > extremePoint {
+					// adjust af and extremePoint
+					extremePoint = high
+					acceleration += accelerationFactor
+					if acceleration > accelerationFactorMax {
+						acceleration = accelerationFactorMax
+					}
+				}
+
+				// calculate the new Sar
+				var diff float64 = extremePoint - previousSar
+				previousSar = previousSar + acceleration*(diff)
+
+				// make sure the overridden Sar is within yesterdays and todays range
+				if previousSar > previousLow {
+					previousSar = previousLow
+				}
+				if previousSar > low {
+					previousSar = low
+				}
+			}
+		} else {
+			if high >= previousSar {
+				// switch to long if the high penetrates the Sar value
+				isLong = true
+				previousSar = extremePoint
+
+				// make sure the overridden Sar is within yesterdays and todays range
+				if previousSar > previousLow {
+					previousSar = previousLow
+				}
+				if previousSar > low {
+					previousSar = low
+				}
+
+				out = append(out, previousSar)
+
+				// adjust af and extremePoint
+				acceleration = accelerationFactor
+				extremePoint = high
+
+				// calculate the new Sar
+				var diff float64 = extremePoint - previousSar
+				previousSar = previousSar + acceleration*(diff)
+
+				// make sure the overridden Sar is within yesterdays and todays range
+				if previousSar > previousLow {
+					previousSar = previousLow
+				}
+				if previousSar > low {
+					previousSar = low
+				}
+			} else {
+				// no switch, output the current Sar
+				out = append(out, previousSar)
+
+				if low < extremePoint {
+					// adjust af and extremePoint
+					extremePoint = low
+					acceleration += accelerationFactor
+					if acceleration > accelerationFactorMax {
+						acceleration = accelerationFactorMax
+					}
+				}
+
+				// calculate the new Sar
+				var diff float64 = extremePoint - previousSar
+				previousSar = previousSar + acceleration*(diff)
+
+				// make sure the overridden Sar is within yesterdays and todays range
+				if previousSar < previousHigh {
+					previousSar = previousHigh
+				}
+				if previousSar < high {
+					previousSar = high
+				}
+			}
+		}
+
+		previousHigh = high
+		previousLow = low
+	}
+	return out, nil
+}
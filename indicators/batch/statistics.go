@@ -0,0 +1,235 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+// Var calculates the Variance (Var) of the source data
+func Var(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewVarWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return variance(in, timePeriod, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+func variance(in []float64, timePeriod int, out []float64) []float64 {
+	var mean float64
+	var periodVar float64
+	var periodCounter int
+	for i, value := range in {
+		previousMean := mean
+		previousVar := periodVar
+
+		if periodCounter < timePeriod {
+			periodCounter += 1
+			delta := value - previousMean
+			mean = previousMean + delta/float64(periodCounter)
+
+			periodVar = previousVar + delta*(value-mean)
+		} else {
+			firstValue := in[i-timePeriod]
+			delta := value - firstValue
+			dOld := firstValue - previousMean
+			mean = previousMean + delta/float64(periodCounter)
+			dNew := value - mean
+			periodVar = previousVar + (dOld+dNew)*(delta)
+		}
+
+		if periodCounter >= timePeriod {
+			out = append(out, periodVar/float64(timePeriod))
+		}
+	}
+	return out
+}
+
+// StdDev calculates the Standard Deviation (StdDev) of the source data
+func StdDev(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewStdDevWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return stdDev(in, timePeriod, newOutput(len(in), ind.GetLookbackPeriod())), nil
+}
+
+func stdDev(in []float64, timePeriod int, out []float64) []float64 {
+	out = variance(in, timePeriod, out)
+	for i, value := range out {
+		out[i] = math.Sqrt(value)
+	}
+	return out
+}
+
+// BollingerBands calculates the Bollinger Bands of the source data, two standard deviations either side of its
+// simple moving average
+func BollingerBands(in []float64, timePeriod int) (upperBand []float64, middleBand []float64, lowerBand []float64, err error) {
+	ind, err := indicators.NewBollingerBandsWithoutStorage(timePeriod, discardTriple)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lookback := ind.GetLookbackPeriod()
+
+	middleBand = sma(in, timePeriod, newOutput(len(in), lookback))
+	deviations := stdDev(in, timePeriod, newOutput(len(in), lookback))
+
+	upperBand = newOutput(len(in), lookback)
+	lowerBand = newOutput(len(in), lookback)
+	for i, deviation := range deviations {
+		upperBand = append(upperBand, middleBand[i]+2*deviation)
+		lowerBand = append(lowerBand, middleBand[i]-2*deviation)
+	}
+	return upperBand, middleBand, lowerBand, nil
+}
+
+// Hhv calculates the Highest High Value (Hhv) of the source data
+func Hhv(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewHhvWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _ = highest(in, timePeriod, newOutput(len(in), ind.GetLookbackPeriod()), nil)
+	return out, nil
+}
+
+// HhvBars calculates the number of bars since the Highest High Value (HhvBars) of the source data
+func HhvBars(in []float64, timePeriod int) (out []int64, err error) {
+	ind, err := indicators.NewHhvBarsWithoutStorage(timePeriod, discardInt)
+	if err != nil {
+		return nil, err
+	}
+
+	_, out = highest(in, timePeriod, nil, newIntOutput(len(in), ind.GetLookbackPeriod()))
+	return out, nil
+}
+
+// highest appends the highest value of each period to highs and the number of bars since it to bars, either of
+// which may be nil when it is not required. Of equal highest values the oldest is used.
+func highest(in []float64, timePeriod int, highs []float64, bars []int64) ([]float64, []int64) {
+	for i, highIndex := range extremeIndexes(in, timePeriod, true, false) {
+		if highs != nil {
+			highs = append(highs, in[highIndex])
+		}
+		if bars != nil {
+			bars = append(bars, int64(i+timePeriod-1-highIndex))
+		}
+	}
+	return highs, bars
+}
+
+// Llv calculates the Lowest Low Value (Llv) of the source data
+func Llv(in []float64, timePeriod int) (out []float64, err error) {
+	ind, err := indicators.NewLlvWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _ = lowest(in, timePeriod, newOutput(len(in), ind.GetLookbackPeriod()), nil)
+	return out, nil
+}
+
+// LlvBars calculates the number of bars since the Lowest Low Value (LlvBars) of the source data
+func LlvBars(in []float64, timePeriod int) (out []int64, err error) {
+	ind, err := indicators.NewLlvBarsWithoutStorage(timePeriod, discardInt)
+	if err != nil {
+		return nil, err
+	}
+
+	_, out = lowest(in, timePeriod, nil, newIntOutput(len(in), ind.GetLookbackPeriod()))
+	return out, nil
+}
+
+// lowest appends the lowest value of each period to lows and the number of bars since it to bars, either of
+// which may be nil when it is not required. Of equal lowest values the oldest is used.
+func lowest(in []float64, timePeriod int, lows []float64, bars []int64) ([]float64, []int64) {
+	for i, lowIndex := range extremeIndexes(in, timePeriod, false, false) {
+		if lows != nil {
+			lows = append(lows, in[lowIndex])
+		}
+		if bars != nil {
+			bars = append(bars, int64(i+timePeriod-1-lowIndex))
+		}
+	}
+	return lows, bars
+}
+
+// the result of a linear regression
+type linRegResult int
+
+const (
+	linRegValue linRegResult = iota
+	linRegAngle
+	linRegIntercept
+	linRegSlope
+	linRegForecast
+)
+
+// LinReg calculates the Linear Regression (LinReg) of the source data
+func LinReg(in []float64, timePeriod int) (out []float64, err error) {
+	return linReg(in, timePeriod, linRegValue)
+}
+
+// LinRegAng calculates the Linear Regression Angle (LinRegAng) of the source data, in degrees
+func LinRegAng(in []float64, timePeriod int) (out []float64, err error) {
+	return linReg(in, timePeriod, linRegAngle)
+}
+
+// LinRegInt calculates the Linear Regression Intercept (LinRegInt) of the source data
+func LinRegInt(in []float64, timePeriod int) (out []float64, err error) {
+	return linReg(in, timePeriod, linRegIntercept)
+}
+
+// LinRegSlp calculates the Linear Regression Slope (LinRegSlp) of the source data
+func LinRegSlp(in []float64, timePeriod int) (out []float64, err error) {
+	return linReg(in, timePeriod, linRegSlope)
+}
+
+// Tsf calculates the Time Series Forecast (Tsf) of the source data
+func Tsf(in []float64, timePeriod int) (out []float64, err error) {
+	return linReg(in, timePeriod, linRegForecast)
+}
+
+func linReg(in []float64, timePeriod int, resultType linRegResult) (out []float64, err error) {
+	ind, err := indicators.NewLinRegWithoutStorage(timePeriod, discardTriple)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(in), ind.GetLookbackPeriod())
+
+	timePeriodF := float64(timePeriod)
+	timePeriodFMinusOne := timePeriodF - 1.0
+	sumX := timePeriodF * timePeriodFMinusOne * 0.5
+	sumXSquare := timePeriodF * timePeriodFMinusOne * (2.0*timePeriodF - 1.0) / 6.0
+	divisor := sumX*sumX - timePeriodF*sumXSquare
+
+	for i := timePeriod - 1; i < len(in); i++ {
+		sumXY := 0.0
+		sumY := 0.0
+		x := timePeriod
+		for _, value := range in[i-timePeriod+1 : i] {
+			x--
+			sumY += value
+			sumXY += (float64(x) * value)
+		}
+		sumY += in[i]
+		m := (timePeriodF*sumXY - sumX*sumY) / divisor
+		b := (sumY - m*sumX) / timePeriodF
+
+		switch resultType {
+		case linRegValue:
+			out = append(out, b+m*float64(timePeriodF-1.0))
+		case linRegAngle:
+			out = append(out, math.Atan(m)*(180.0/math.Pi))
+		case linRegIntercept:
+			out = append(out, b)
+		case linRegSlope:
+			out = append(out, m)
+		case linRegForecast:
+			out = append(out, b+m*float64(timePeriod))
+		}
+	}
+	return out, nil
+}
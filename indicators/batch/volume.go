@@ -0,0 +1,138 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// Adl calculates the Accumulation Distribution Line (Adl) of the high, low and close prices and volumes
+func Adl(highPrices []float64, lowPrices []float64, closePrices []float64, volumes []float64) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices, volumes); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewAdlWithoutStorage(discardFloat)
+	if err != nil {
+		return nil, err
+	}
+
+	return adl(highPrices, lowPrices, closePrices, volumes, newOutput(len(closePrices), ind.GetLookbackPeriod())), nil
+}
+
+func adl(highPrices []float64, lowPrices []float64, closePrices []float64, volumes []float64, out []float64) []float64 {
+	var previousAdl float64
+	for i, closePrice := range closePrices {
+		moneyFlowMultiplier := ((closePrice - lowPrices[i]) - (highPrices[i] - closePrice)) / (highPrices[i] - lowPrices[i])
+		moneyFlowVolume := moneyFlowMultiplier * volumes[i]
+		previousAdl = previousAdl + moneyFlowVolume
+		out = append(out, previousAdl)
+	}
+	return out
+}
+
+// ChaikinOsc calculates the Chaikin Oscillator (ChaikinOsc) of the high, low and close prices and volumes, the fast
+// Ema of the Adl less its slow Ema
+func ChaikinOsc(highPrices []float64, lowPrices []float64, closePrices []float64, volumes []float64, fastTimePeriod int, slowTimePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices, volumes); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewChaikinOscWithoutStorage(fastTimePeriod, slowTimePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	emaFastMultiplier := float64(2.0 / float64(fastTimePeriod+1.0))
+	emaSlowMultiplier := float64(2.0 / float64(slowTimePeriod+1.0))
+
+	// both emas are seeded with the first adl rather than a simple average
+	var emaFast float64
+	var emaSlow float64
+	for i, value := range adl(highPrices, lowPrices, closePrices, volumes, newOutput(len(closePrices), 0)) {
+		if i == 0 {
+			emaFast = value
+			emaSlow = value
+		}
+
+		emaFast = (value-emaFast)*emaFastMultiplier + emaFast
+		emaSlow = (value-emaSlow)*emaSlowMultiplier + emaSlow
+		if i >= slowTimePeriod-1 {
+			out = append(out, emaFast-emaSlow)
+		}
+	}
+	return out, nil
+}
+
+// Obv calculates the On Balance Volume (Obv) of the close prices and volumes
+func Obv(closePrices []float64, volumes []float64) (out []float64, err error) {
+	if err = checkLengths(closePrices, volumes); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewObvWithoutStorage(discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	var previousObv float64
+	for i, closePrice := range closePrices {
+		if i == 0 {
+			previousObv = volumes[i]
+		} else if closePrice > closePrices[i-1] {
+			previousObv += volumes[i]
+		} else if closePrice < closePrices[i-1] {
+			previousObv -= volumes[i]
+		}
+		out = append(out, previousObv)
+	}
+	return out, nil
+}
+
+// Mfi calculates the Money Flow Index (Mfi) of the high, low and close prices and volumes
+func Mfi(highPrices []float64, lowPrices []float64, closePrices []float64, volumes []float64, timePeriod int) (out []float64, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices, volumes); err != nil {
+		return nil, err
+	}
+
+	ind, err := indicators.NewMfiWithoutStorage(timePeriod, discardFloat)
+	if err != nil {
+		return nil, err
+	}
+	out = newOutput(len(closePrices), ind.GetLookbackPeriod())
+
+	// the positive and negative money flows of each bar, the flows of the bar leaving the period are removed
+	// from the totals
+	positiveHistory := make([]float64, len(closePrices))
+	negativeHistory := make([]float64, len(closePrices))
+	var positiveMoneyFlow indicators.KahanSum
+	var negativeMoneyFlow indicators.KahanSum
+	var previousTypPrice float64
+	for i := range closePrices {
+		typicalPrice := (highPrices[i] + lowPrices[i] + closePrices[i]) / float64(3.0)
+
+		if i > 0 {
+			moneyFlow := typicalPrice * volumes[i]
+
+			if i > timePeriod {
+				positiveMoneyFlow.Sub(positiveHistory[i-timePeriod])
+				negativeMoneyFlow.Sub(negativeHistory[i-timePeriod])
+			}
+
+			if typicalPrice > previousTypPrice {
+				positiveMoneyFlow.Add(moneyFlow)
+				positiveHistory[i] = moneyFlow
+			} else if typicalPrice < previousTypPrice {
+				negativeMoneyFlow.Add(moneyFlow)
+				negativeHistory[i] = moneyFlow
+			}
+
+			if i >= timePeriod {
+				out = append(out, 100.0*(positiveMoneyFlow.Value()/(positiveMoneyFlow.Value()+negativeMoneyFlow.Value())))
+			}
+		}
+
+		previousTypPrice = typicalPrice
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// extremeIndexes returns the index of the highest, or lowest, value of each period of the source data from the
+// first full period onwards, as tracked by the RollingExtreme of the streaming indicators. Of equal extreme values
+// the newest is the extreme when newest is true, otherwise the oldest.
+func extremeIndexes(in []float64, period int, highest bool, newest bool) []int {
+	if len(in) < period {
+		return nil
+	}
+	out := make([]int, 0, len(in)-period+1)
+
+	var extreme *indicators.RollingExtreme
+	if highest {
+		extreme = indicators.NewRollingMax(period, newest)
+	} else {
+		extreme = indicators.NewRollingMin(period, newest)
+	}
+
+	for i, value := range in {
+		extreme.Push(value)
+		if i >= period-1 {
+			out = append(out, i-extreme.Age())
+		}
+	}
+	return out
+}
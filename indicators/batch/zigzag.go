@@ -0,0 +1,74 @@
+package batch
+
+import (
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+// ZigZag calculates the confirmed pivots of a ZigZag of the high and low prices, whose pivots are confirmed by a
+// reversal of percentage percent, e.g. 5.0 for 5%. The stream bar index of a pivot is the index of its bar in the
+// source data plus one, as for the streaming indicator, and the provisional pivot of the latest leg is omitted.
+func ZigZag(highPrices []float64, lowPrices []float64, percentage float64) (pivots []indicators.ZigZagPivot, err error) {
+	if err = checkLengths(highPrices, lowPrices); err != nil {
+		return nil, err
+	}
+
+	pivots = make([]indicators.ZigZagPivot, 0)
+	ind, err := indicators.NewZigZagWithoutStorage(percentage, confirmedPivots(&pivots))
+	if err != nil {
+		return nil, err
+	}
+
+	bar := &priceBar{}
+	for i := range highPrices {
+		bar.high, bar.low = highPrices[i], lowPrices[i]
+		ind.ReceiveDOHLCVTick(bar, i+1)
+	}
+	return pivots, nil
+}
+
+// AtrZigZag calculates the confirmed pivots of a ZigZag of the high, low and close prices, whose pivots are
+// confirmed by a reversal of atrMultiple times the average true range of atrPeriod bars
+func AtrZigZag(highPrices []float64, lowPrices []float64, closePrices []float64, atrPeriod int, atrMultiple float64) (pivots []indicators.ZigZagPivot, err error) {
+	if err = checkLengths(highPrices, lowPrices, closePrices); err != nil {
+		return nil, err
+	}
+
+	pivots = make([]indicators.ZigZagPivot, 0)
+	ind, err := indicators.NewAtrZigZagWithoutStorage(atrPeriod, atrMultiple, confirmedPivots(&pivots))
+	if err != nil {
+		return nil, err
+	}
+
+	bar := &priceBar{}
+	for i := range highPrices {
+		bar.high, bar.low, bar.close = highPrices[i], lowPrices[i], closePrices[i]
+		ind.ReceiveDOHLCVTick(bar, i+1)
+	}
+	return pivots, nil
+}
+
+// confirmedPivots returns an event action appending the confirmed pivots of a ZigZag to pivots, unlike the other
+// batch functions the ZigZag is calculated by the streaming indicator as its pivots are repainted
+func confirmedPivots(pivots *[]indicators.ZigZagPivot) indicators.ZigZagEventAction {
+	return func(event indicators.ZigZagEvent) {
+		if event.EventType == indicators.ZigZagPivotConfirmed {
+			*pivots = append(*pivots, event.Pivot)
+		}
+	}
+}
+
+// priceBar is the bar ticked through a streaming indicator, one bar is reused for each source data bar as the
+// indicators do not retain the bars they receive
+type priceBar struct {
+	high  float64
+	low   float64
+	close float64
+}
+
+func (b *priceBar) D() time.Time { return time.Time{} }
+func (b *priceBar) O() float64   { return 0.0 }
+func (b *priceBar) H() float64   { return b.high }
+func (b *priceBar) L() float64   { return b.low }
+func (b *priceBar) C() float64   { return b.close }
+func (b *priceBar) V() float64   { return 0.0 }
@@ -15,6 +15,9 @@ type BollingerBandsWithoutStorage struct {
 	stdDev               *StdDevWithoutStorage
 	currentSma           float64
 	timePeriod           int
+
+	// the state prior to the latest source data bar
+	savedState *BollingerBandsWithoutStorage
 }
 
 // NewBollingerBandsWithoutStorage creates a Bollinger Band Indicator (BollingerBand) without storage
@@ -82,11 +85,15 @@ func NewBollingerBands(timePeriod int, selectData gotrade.DOHLCVDataSelectionFun
 	ind.BollingerBandsWithoutStorage, err = NewBollingerBandsWithoutStorage(
 		timePeriod,
 		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
-			ind.UpperBand = append(ind.UpperBand, dataItemUpperBand)
-			ind.MiddleBand = append(ind.MiddleBand, dataItemMiddleBand)
-			ind.LowerBand = append(ind.LowerBand, dataItemLowerBand)
+			ind.UpperBand = ind.storeFloat(0, ind.UpperBand, dataItemUpperBand)
+			ind.MiddleBand = ind.storeFloat(1, ind.MiddleBand, dataItemMiddleBand)
+			ind.LowerBand = ind.storeFloat(2, ind.LowerBand, dataItemLowerBand)
 		})
 
+	if err == nil {
+		ind.nameOutputs("UpperBand", "MiddleBand", "LowerBand")
+	}
+
 	return &ind, err
 }
 
@@ -157,11 +164,58 @@ func NewDefaultBollingerBandsForStreamWithSrcLen(sourceLength uint, priceStream
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *BollingerBands) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	var selectedData float64 = ind.selectData(tickData)
-	ind.RecieveTick(selectedData, streamBarIndex)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *BollingerBands) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
 }
 
 // ReceiveTick consumes a source data float price tick
-func (ind *BollingerBandsWithoutStorage) RecieveTick(tickData float64, streamBarIndex int) {
+func (ind *BollingerBandsWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.sma.ReceiveTick(tickData, streamBarIndex)
 	ind.stdDev.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *BollingerBandsWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// RecieveTick consumes a source data float price tick
+// Deprecated: use ReceiveTick
+func (ind *BollingerBandsWithoutStorage) RecieveTick(tickData float64, streamBarIndex int) {
+	ind.ReceiveTick(tickData, streamBarIndex)
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *BollingerBandsWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.sma.saveState(streamBarIndex)
+	ind.stdDev.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(BollingerBandsWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *BollingerBandsWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.sma.restoreState()
+	ind.stdDev.restoreState()
+	*ind = *ind.savedState
+}
@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 	"math"
@@ -15,10 +14,13 @@ type CciWithoutStorage struct {
 	periodCounter          int
 	typicalPriceAvg        *SmaWithoutStorage
 	factor                 float64
-	typicalPriceHistory    *list.List
+	typicalPriceHistory    *gotrade.FloatRingBuffer
 	currentAvgTypicalPrice float64
 	currentTypicalPrice    float64
 	timePeriod             int
+
+	// the state prior to the latest source data bar
+	savedState *CciWithoutStorage
 }
 
 // NewCciWithoutStorage creates a Commodity Channel Index Indicator (Cci) without storage
@@ -44,7 +46,7 @@ func NewCciWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		factor:              0.015,
 		periodCounter:       (timePeriod * -1),
-		typicalPriceHistory: list.New(),
+		typicalPriceHistory: gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:          timePeriod,
 	}
 
@@ -53,8 +55,7 @@ func NewCciWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 
 		var meanDeviation float64 = 0.0
 		// calculate the mean deviation
-		for e := ind.typicalPriceHistory.Front(); e != nil; e = e.Next() {
-			value := e.Value.(float64)
+		for _, value := range ind.typicalPriceHistory.Values() {
 			meanDeviation += math.Abs(value - currentTypicalPriceAvg)
 		}
 		meanDeviation /= float64(ind.timePeriod)
@@ -79,9 +80,13 @@ type Cci struct {
 func NewCci(timePeriod int) (indicator *Cci, err error) {
 	ind := Cci{}
 	ind.CciWithoutStorage, err = NewCciWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -146,6 +151,8 @@ func NewDefaultCciForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOH
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *Cci) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 
 	// calculate the typical price
@@ -153,14 +160,40 @@ func (ind *Cci) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.currentTypicalPrice = typicalPrice
 
 	// push it to the history
-	ind.typicalPriceHistory.PushBack(typicalPrice)
-
-	// trim the history
-	if ind.typicalPriceHistory.Len() > ind.timePeriod {
-		var first = ind.typicalPriceHistory.Front()
-		ind.typicalPriceHistory.Remove(first)
-	}
+	ind.typicalPriceHistory.Push(typicalPrice)
 
 	// add it to the average
 	ind.typicalPriceAvg.ReceiveTick(typicalPrice, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Cci) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *CciWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.typicalPriceHistory.SaveState()
+	ind.typicalPriceAvg.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(CciWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *CciWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.typicalPriceHistory.RestoreState()
+	ind.typicalPriceAvg.restoreState()
+	*ind = *ind.savedState
+}
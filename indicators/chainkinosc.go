@@ -19,6 +19,9 @@ type ChaikinOscWithoutStorage struct {
 	emaSlowMultiplier float64
 	periodCounter     int
 	isInitialised     bool
+
+	// the state prior to the latest source data bar
+	savedState *ChaikinOscWithoutStorage
 }
 
 // NewChaikinOscWithoutStorage creates a Chaikin Oscillator Indicator (ChaikinOsc) without storage
@@ -103,9 +106,13 @@ func NewChaikinOsc(fastTimePeriod int, slowTimePeriod int) (indicator *ChaikinOs
 	newChaikinOsc := ChaikinOsc{}
 	newChaikinOsc.ChaikinOscWithoutStorage, err = NewChaikinOscWithoutStorage(fastTimePeriod, slowTimePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			newChaikinOsc.Data = append(newChaikinOsc.Data, dataItem)
+			newChaikinOsc.Data = newChaikinOsc.storeFloat(0, newChaikinOsc.Data, dataItem)
 		})
 
+	if err == nil {
+		newChaikinOsc.nameOutputs("Data")
+	}
+
 	return &newChaikinOsc, err
 }
 
@@ -172,5 +179,37 @@ func NewDefaultChaikinOscForStreamWithSrcLen(sourceLength uint, priceStream gotr
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *ChaikinOsc) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.adl.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *ChaikinOsc) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *ChaikinOscWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.adl.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(ChaikinOscWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *ChaikinOscWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.adl.restoreState()
+	*ind = *ind.savedState
+}
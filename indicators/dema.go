@@ -15,6 +15,9 @@ type DemaWithoutStorage struct {
 	ema1       *EmaWithoutStorage
 	ema2       *EmaWithoutStorage
 	currentEMA float64
+
+	// the state prior to the latest source data bar
+	savedState *DemaWithoutStorage
 }
 
 // NewDemaWithoutStorage creates a Double Exponential Moving Average Indicator (Dema) without storage
@@ -78,9 +81,13 @@ func NewDema(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indica
 
 	ind.DemaWithoutStorage, err = NewDemaWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -151,6 +158,47 @@ func (dema *Dema) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
 	dema.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (dema *Dema) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if dema.prepareRevision(dema, streamBarIndex) {
+		dema.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (dema *DemaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	dema.saveState(streamBarIndex)
+
 	dema.ema1.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (dema *DemaWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if dema.prepareRevision(dema, streamBarIndex) {
+		dema.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (dema *DemaWithoutStorage) saveState(streamBarIndex int) {
+	if !dema.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	dema.saveBounds()
+	dema.ema1.saveState(streamBarIndex)
+	dema.ema2.saveState(streamBarIndex)
+
+	if dema.savedState == nil {
+		dema.savedState = new(DemaWithoutStorage)
+	}
+	*dema.savedState = *dema
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (dema *DemaWithoutStorage) restoreState() {
+	dema.restoreBaseState()
+	dema.restoreBounds()
+	dema.ema1.restoreState()
+	dema.ema2.restoreState()
+	*dema = *dema.savedState
+}
@@ -18,6 +18,9 @@ type DxWithoutStorage struct {
 	currentPlusDi  float64
 	currentMinusDi float64
 	timePeriod     int
+
+	// the state prior to the latest source data bar
+	savedState *DxWithoutStorage
 }
 
 // NewDxWithoutStorage creates a Directional Movement Index Indicator (Dx) without storage
@@ -89,9 +92,13 @@ func NewDx(timePeriod int) (indicator *Dx, err error) {
 	ind := Dx{}
 	ind.DxWithoutStorage, err = NewDxWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -156,6 +163,40 @@ func NewDefaultDxForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHL
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *DxWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.minusDI.ReceiveDOHLCVTick(tickData, streamBarIndex)
 	ind.plusDI.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *DxWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *DxWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.minusDI.saveState(streamBarIndex)
+	ind.plusDI.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(DxWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *DxWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.minusDI.restoreState()
+	ind.plusDI.restoreState()
+	*ind = *ind.savedState
+}
@@ -15,6 +15,9 @@ type EmaWithoutStorage struct {
 	multiplier    float64
 	previousEma   float64
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *EmaWithoutStorage
 }
 
 // NewEmaWithoutStorage creates an Exponential Moving Average Indicator (Ema) without storage
@@ -67,9 +70,13 @@ func NewEma(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 
 	ind.EmaWithoutStorage, err = NewEmaWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -138,7 +145,16 @@ func (ind *Ema) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Ema) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *EmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 	if ind.periodCounter < 0 {
 		ind.periodTotal += tickData
@@ -158,3 +174,31 @@ func (ind *EmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *EmaWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *EmaWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(EmaWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *EmaWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
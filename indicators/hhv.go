@@ -1,10 +1,8 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
 )
 
 // A Highest High Value Indicator (Hhv), no storage, for use in other indicators
@@ -12,10 +10,12 @@ type HhvWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodHistory    *list.List
-	currentHigh      float64
-	currentHighIndex int
-	timePeriod       int
+	periodCounter int
+	periodHigh    *RollingExtreme
+	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *HhvWithoutStorage
 }
 
 // NewHhvWithoutStorage creates a Highest High Value Indicator (Hhv) without storage
@@ -39,9 +39,8 @@ func NewHhvWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	lookback := timePeriod - 1
 	ind := HhvWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		currentHigh:                  math.SmallestNonzeroFloat64,
-		currentHighIndex:             0,
-		periodHistory:                list.New(),
+		periodCounter:                timePeriod * -1,
+		periodHigh:                   NewRollingMax(timePeriod, false),
 		timePeriod:                   timePeriod,
 	}
 
@@ -68,9 +67,13 @@ func NewHhv(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 	}
 
 	ind.HhvWithoutStorage, err = NewHhvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -139,51 +142,52 @@ func (ind *Hhv) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Hhv) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *HhvWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current high
-		if ind.currentHighIndex == ind.timePeriod-1 {
-			ind.currentHigh = math.SmallestNonzeroFloat64
-			// we have we need to find the new high in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value > ind.currentHigh {
-					ind.currentHigh = value
-					ind.currentHighIndex = i
-				}
-				i -= 1
-			}
-		} else {
-			if tickData > ind.currentHigh {
-				ind.currentHigh = tickData
-				ind.currentHighIndex = 0
-			} else {
-				ind.currentHighIndex += 1
-			}
-		}
-		var result = ind.currentHigh
+	ind.saveState(streamBarIndex)
+
+	ind.periodCounter += 1
+	ind.periodHigh.Push(tickData)
+
+	if ind.periodCounter >= 0 {
+		var result = ind.periodHigh.Value()
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *HhvWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
 
-	} else {
-		if tickData > ind.currentHigh {
-			ind.currentHigh = tickData
-			ind.currentHighIndex = 0
-		} else {
-			ind.currentHighIndex += 1
-		}
+// saveState records the state of the indicator prior to the source data bar
+func (ind *HhvWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentHigh
+	ind.saveBounds()
+	ind.periodHigh.SaveState()
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
+	if ind.savedState == nil {
+		ind.savedState = new(HhvWithoutStorage)
 	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *HhvWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHigh.RestoreState()
+	*ind = *ind.savedState
 }
@@ -406,3 +406,36 @@ var _ = Describe("when calculating a highest high value (hhv) with DOHLCV source
 	})
 
 })
+
+var _ = Describe("when calculating a highest high value (hhv) of negative source data", func() {
+	var (
+		hhv     *indicators.HhvWithoutStorage
+		hhvBars *indicators.HhvBarsWithoutStorage
+		highs   []float64
+		bars    []int64
+	)
+
+	BeforeEach(func() {
+		highs = nil
+		bars = nil
+		hhv, _ = indicators.NewHhvWithoutStorage(3, func(dataItem float64, streamBarIndex int) {
+			highs = append(highs, dataItem)
+		})
+		hhvBars, _ = indicators.NewHhvBarsWithoutStorage(3, func(dataItem int64, streamBarIndex int) {
+			bars = append(bars, dataItem)
+		})
+
+		for i, value := range []float64{-5.0, -3.0, -4.0, -3.0, -6.0, -7.0, -8.0} {
+			hhv.ReceiveTick(value, i+1)
+			hhvBars.ReceiveTick(value, i+1)
+		}
+	})
+
+	It("should return the highest value of each period", func() {
+		Expect(highs).To(Equal([]float64{-3.0, -3.0, -3.0, -3.0, -6.0}))
+	})
+
+	It("should return the bars since the oldest of equal highest values", func() {
+		Expect(bars).To(Equal([]int64{1, 2, 1, 2, 2}))
+	})
+})
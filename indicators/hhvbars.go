@@ -1,10 +1,8 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
 )
 
 // A Highest High Value Bars Indicator (HhvBars), no storage, for use in other indicators
@@ -12,10 +10,12 @@ type HhvBarsWithoutStorage struct {
 	*baseIndicatorWithIntBounds
 
 	// private variables
-	periodHistory    *list.List
-	currentHigh      float64
-	currentHighIndex int64
-	timePeriod       int
+	periodCounter int
+	periodHigh    *RollingExtreme
+	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *HhvBarsWithoutStorage
 }
 
 // NewHhvBarsWithoutStorage creates a Highest High Value Bars Indicator Indicator (HhvBars) without storage
@@ -40,9 +40,8 @@ func NewHhvBarsWithoutStorage(timePeriod int, valueAvailableAction ValueAvailabl
 
 	ind := HhvBarsWithoutStorage{
 		baseIndicatorWithIntBounds: newBaseIndicatorWithIntBounds(lookback, valueAvailableAction),
-		currentHigh:                math.SmallestNonzeroFloat64,
-		currentHighIndex:           0,
-		periodHistory:              list.New(),
+		periodCounter:              timePeriod * -1,
+		periodHigh:                 NewRollingMax(timePeriod, false),
 		timePeriod:                 timePeriod,
 	}
 
@@ -69,9 +68,13 @@ func NewHhvBars(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (ind
 	}
 
 	ind.HhvBarsWithoutStorage, err = NewHhvBarsWithoutStorage(timePeriod, func(dataItem int64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeInt(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -140,53 +143,52 @@ func (ind *HhvBars) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex in
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *HhvBars) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *HhvBarsWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current high
-		if ind.currentHighIndex == int64(ind.timePeriod-1) {
-			ind.currentHigh = math.SmallestNonzeroFloat64
-			// we have we need to find the new high in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value > ind.currentHigh {
-					ind.currentHigh = value
-					ind.currentHighIndex = int64(i)
-				}
-				i -= 1
-			}
-		} else {
-			if tickData > ind.currentHigh {
-				ind.currentHigh = tickData
-				ind.currentHighIndex = 0
-			} else {
-				ind.currentHighIndex += 1
-			}
-		}
-
-		var result = ind.currentHighIndex
+	ind.saveState(streamBarIndex)
+
+	ind.periodCounter += 1
+	ind.periodHigh.Push(tickData)
+
+	if ind.periodCounter >= 0 {
+		var result = int64(ind.periodHigh.Age())
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
 
-	} else {
-		if tickData > ind.currentHigh {
-			ind.currentHigh = tickData
-			ind.currentHighIndex = 0
-		} else {
-			ind.currentHighIndex += 1
-		}
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *HhvBarsWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentHighIndex
+// saveState records the state of the indicator prior to the source data bar
+func (ind *HhvBarsWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHigh.SaveState()
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
+	if ind.savedState == nil {
+		ind.savedState = new(HhvBarsWithoutStorage)
 	}
+	*ind.savedState = *ind
+}
 
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *HhvBarsWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHigh.RestoreState()
+	*ind = *ind.savedState
 }
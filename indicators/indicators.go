@@ -24,6 +24,12 @@
  		* Indicator without storage with specified parameters
 			- for use inside other indicators, has no storage of results which is instead
 			- provided via a callback when it becomes available for use in the parent indicator.
+
+	Each indicator with storage publishes the results of its outputs, e.g. the Signal line of a Macd, as streams
+	of float ticks which other indicators can subscribe to, e.g. a Sma of a Rsi or Bollinger Bands of an Obv.
+
+	Every indicator is also registered by name with the metadata of its parameters, outputs and lookback period,
+	so that it can be created from a parameter map or JSON, e.g. {"name":"macd","fast":12,"slow":26,"signal":9}.
 */
 package indicators
 
@@ -39,6 +45,7 @@ var (
 	ErrLookbackPeriodMustBeGreaterThanZero  = errors.New("Lookback period must be greater than 0")
 	ErrValueAvailableActionIsNil            = errors.New("A ValueAvailableAction is required")
 	ErrDOHLCVDataSelectFuncIsNil            = errors.New("A DOHLCVDataSelectionFunc is required")
+	ErrMaxHistoryMustBeSetBeforeFirstValue  = errors.New("Max history must be set before the indicator produces its first value")
 	ErrStrBelowMinimum                      = "is less than the minimum"
 	ErrStrAboveMaximum                      = "is greater than the maximum"
 
@@ -57,6 +64,17 @@ type Indicator interface {
 	Length() int
 }
 
+type IndicatorWithMaxHistory interface {
+	// caps the stored results to the most recent maxHistory values, 0 stores all results.
+	SetMaxHistory(maxHistory int) error
+	// the maximum number of stored results, 0 when all results are stored.
+	MaxHistory() int
+	// the index into the stored results of the result offset bars before the latest result.
+	DataIndexFromLatest(offset int) (index int, ok bool)
+	// the index into the stored results of the result for the source data bar.
+	DataIndexForBar(streamBarIndex int) (index int, ok bool)
+}
+
 type IndicatorWithTimePeriod interface {
 	GetTimePeriod() int
 }
@@ -75,9 +93,19 @@ type IndicatorWithIntBounds interface {
 	MaxValue() int64
 }
 
+// revisableIndicator is implemented by indicators which can revise the result of their latest source data bar
+type revisableIndicator interface {
+	// records the state of the indicator, and of its children, prior to the source data bar
+	saveState(streamBarIndex int)
+	// returns the indicator, and its children, to the state recorded by saveState
+	restoreState()
+}
+
 type baseFloatBounds struct {
-	minValue float64
-	maxValue float64
+	minValue      float64
+	maxValue      float64
+	savedMinValue float64
+	savedMaxValue float64
 }
 
 func newBaseFloatBounds() *baseFloatBounds {
@@ -93,6 +121,16 @@ func (ind *baseFloatBounds) MaxValue() float64 {
 	return ind.maxValue
 }
 
+func (ind *baseFloatBounds) saveBounds() {
+	ind.savedMinValue = ind.minValue
+	ind.savedMaxValue = ind.maxValue
+}
+
+func (ind *baseFloatBounds) restoreBounds() {
+	ind.minValue = ind.savedMinValue
+	ind.maxValue = ind.savedMaxValue
+}
+
 func (ind *baseFloatBounds) UpdateMinMax(minCandidate float64, maxCandidate float64) {
 	// update the maximum result value
 	if maxCandidate > ind.maxValue {
@@ -106,8 +144,10 @@ func (ind *baseFloatBounds) UpdateMinMax(minCandidate float64, maxCandidate floa
 }
 
 type baseIntBounds struct {
-	minValue int64
-	maxValue int64
+	minValue      int64
+	maxValue      int64
+	savedMinValue int64
+	savedMaxValue int64
 }
 
 func newBaseIntBounds() *baseIntBounds {
@@ -123,6 +163,16 @@ func (ind *baseIntBounds) MaxValue() int64 {
 	return ind.maxValue
 }
 
+func (ind *baseIntBounds) saveBounds() {
+	ind.savedMinValue = ind.minValue
+	ind.savedMaxValue = ind.maxValue
+}
+
+func (ind *baseIntBounds) restoreBounds() {
+	ind.minValue = ind.savedMinValue
+	ind.maxValue = ind.savedMaxValue
+}
+
 func (ind *baseIntBounds) UpdateMinMax(minCandidate int64, maxCandidate int64) {
 	// update the maximum result value
 	if maxCandidate > ind.maxValue {
@@ -139,10 +189,24 @@ type baseIndicator struct {
 	validFromBar   int
 	dataLength     int
 	lookbackPeriod int
+	maxHistory     int
+	floatHistory   []*gotrade.FloatRingBuffer
+	intHistory     []*gotrade.IntRingBuffer
+	// the data length at which each capped output was last stored
+	floatStored []int
+	intStored   []int
+	// the named outputs publishing the results, and the output the source data is received from, if any
+	outputs []*IndicatorOutput
+	source  Indicator
+
+	// the state prior to the latest source data bar, for revising the latest result
+	stateBarIndex     int
+	savedValidFromBar int
+	savedDataLength   int
 }
 
 func newBaseIndicator(lookbackPeriod int) *baseIndicator {
-	ind := baseIndicator{lookbackPeriod: lookbackPeriod, validFromBar: -1}
+	ind := baseIndicator{lookbackPeriod: lookbackPeriod, validFromBar: -1, stateBarIndex: -1}
 	return &ind
 }
 
@@ -150,7 +214,12 @@ func (ind *baseIndicator) ValidFromBar() int {
 	return ind.validFromBar
 }
 
+// GetLookbackPeriod returns the lookback period of the indicator, including the lookback period of the indicator
+// output it receives its source data from, if any
 func (ind *baseIndicator) GetLookbackPeriod() int {
+	if ind.source != nil {
+		return ind.lookbackPeriod + ind.source.GetLookbackPeriod()
+	}
 	return ind.lookbackPeriod
 }
 
@@ -170,6 +239,173 @@ func (ind *baseIndicator) SetValidFromBar(streamBarIndex int) {
 	}
 }
 
+// SetMaxHistory caps the stored results of the indicator to the most recent maxHistory values, 0 stores all results.
+// When capped, each of the indicator's result slices is a view of a ring buffer holding its most recent values,
+// oldest first, which is overwritten as new results arrive. Length and ValidFromBar still account for every result.
+func (ind *baseIndicator) SetMaxHistory(maxHistory int) error {
+	if ind.dataLength > 0 {
+		return ErrMaxHistoryMustBeSetBeforeFirstValue
+	}
+
+	if maxHistory < 0 {
+		return errors.New("maxHistory is less than the minimum (0)")
+	}
+
+	ind.maxHistory = maxHistory
+	return nil
+}
+
+func (ind *baseIndicator) MaxHistory() int {
+	return ind.maxHistory
+}
+
+// DataIndexFromLatest returns the index into the stored results of the result offset bars before the latest result
+func (ind *baseIndicator) DataIndexFromLatest(offset int) (index int, ok bool) {
+	stored := ind.storedLength()
+	if offset < 0 || offset >= stored {
+		return -1, false
+	}
+	return stored - 1 - offset, true
+}
+
+// DataIndexForBar returns the index into the stored results of the result for the source data bar
+func (ind *baseIndicator) DataIndexForBar(streamBarIndex int) (index int, ok bool) {
+	if ind.validFromBar == -1 {
+		return -1, false
+	}
+	return ind.DataIndexFromLatest(ind.validFromBar + ind.dataLength - 1 - streamBarIndex)
+}
+
+// Outputs returns the named outputs of the indicator, in the order of its result fields
+func (ind *baseIndicator) Outputs() []*IndicatorOutput {
+	outputs := make([]*IndicatorOutput, len(ind.outputs))
+	copy(outputs, ind.outputs)
+	return outputs
+}
+
+// Output returns the output of the indicator named after its result field, e.g. "Signal" for a Macd
+func (ind *baseIndicator) Output(name string) (output *IndicatorOutput, ok bool) {
+	for _, output := range ind.outputs {
+		if output.name == name {
+			return output, true
+		}
+	}
+	return nil, false
+}
+
+// nameOutputs creates the outputs of the indicator, one per result field in the order they are stored
+func (ind *baseIndicator) nameOutputs(names ...string) {
+	for _, name := range names {
+		ind.outputs = append(ind.outputs, newIndicatorOutput(name, ind))
+	}
+}
+
+func (ind *baseIndicator) setSource(source Indicator) {
+	ind.source = source
+}
+
+// publishOutput passes the latest result of the output on to its subscribers
+func (ind *baseIndicator) publishOutput(output int, newValue float64, revision bool) {
+	if output < len(ind.outputs) {
+		ind.outputs[output].publish(newValue, ind.validFromBar+ind.dataLength-1, revision)
+	}
+}
+
+func (ind *baseIndicator) storedLength() int {
+	if ind.maxHistory > 0 && ind.dataLength > ind.maxHistory {
+		return ind.maxHistory
+	}
+	return ind.dataLength
+}
+
+// storeFloat appends a new value to the results of the indicator's output, honouring the max history.
+// When the latest result is being revised the new value replaces it instead.
+func (ind *baseIndicator) storeFloat(output int, data []float64, newValue float64) []float64 {
+	if ind.maxHistory == 0 {
+		if len(data) > 0 && len(data) == ind.dataLength {
+			data[len(data)-1] = newValue
+			ind.publishOutput(output, newValue, true)
+			return data
+		}
+		ind.publishOutput(output, newValue, false)
+		return append(data, newValue)
+	}
+
+	for len(ind.floatHistory) <= output {
+		ind.floatHistory = append(ind.floatHistory, gotrade.NewFloatRingBuffer(ind.maxHistory))
+		ind.floatStored = append(ind.floatStored, 0)
+	}
+	if ind.floatStored[output] == ind.dataLength {
+		ind.floatHistory[output].ReplaceLatest(newValue)
+		ind.publishOutput(output, newValue, true)
+	} else {
+		ind.floatHistory[output].Push(newValue)
+		ind.floatStored[output] = ind.dataLength
+		ind.publishOutput(output, newValue, false)
+	}
+	return ind.floatHistory[output].Values()
+}
+
+// storeInt appends a new value to the results of the indicator's output, honouring the max history.
+// When the latest result is being revised the new value replaces it instead.
+func (ind *baseIndicator) storeInt(output int, data []int64, newValue int64) []int64 {
+	if ind.maxHistory == 0 {
+		if len(data) > 0 && len(data) == ind.dataLength {
+			data[len(data)-1] = newValue
+			ind.publishOutput(output, float64(newValue), true)
+			return data
+		}
+		ind.publishOutput(output, float64(newValue), false)
+		return append(data, newValue)
+	}
+
+	for len(ind.intHistory) <= output {
+		ind.intHistory = append(ind.intHistory, gotrade.NewIntRingBuffer(ind.maxHistory))
+		ind.intStored = append(ind.intStored, 0)
+	}
+	if ind.intStored[output] == ind.dataLength {
+		ind.intHistory[output].ReplaceLatest(newValue)
+		ind.publishOutput(output, float64(newValue), true)
+	} else {
+		ind.intHistory[output].Push(newValue)
+		ind.intStored[output] = ind.dataLength
+		ind.publishOutput(output, float64(newValue), false)
+	}
+	return ind.intHistory[output].Values()
+}
+
+// saveBaseState records the base state prior to the source data bar, returning false when the state
+// prior to the bar has already been recorded, e.g. by a parent indicator
+func (ind *baseIndicator) saveBaseState(streamBarIndex int) bool {
+	if ind.stateBarIndex == streamBarIndex {
+		return false
+	}
+
+	ind.stateBarIndex = streamBarIndex
+	ind.savedValidFromBar = ind.validFromBar
+	ind.savedDataLength = ind.dataLength
+	return true
+}
+
+func (ind *baseIndicator) restoreBaseState() {
+	ind.validFromBar = ind.savedValidFromBar
+	ind.dataLength = ind.savedDataLength
+}
+
+// prepareRevision returns the indicator to its state prior to the source data bar when it is the latest bar received.
+// Returns false when the bar precedes the latest bar and can no longer be revised, a bar that follows the latest
+// bar is received as a new bar.
+func (ind *baseIndicator) prepareRevision(indicator revisableIndicator, streamBarIndex int) bool {
+	if streamBarIndex < ind.stateBarIndex {
+		return false
+	}
+
+	if streamBarIndex == ind.stateBarIndex {
+		indicator.restoreState()
+	}
+	return true
+}
+
 type baseIndicatorWithTimePeriod struct {
 	timePeriod int
 }
@@ -0,0 +1,108 @@
+package indicators_test
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/feeds"
+	"github.com/thetruetrade/gotrade/indicators"
+	"testing"
+	"time"
+)
+
+// benchmarkIndicators creates one of each indicator with default parameters
+var benchmarkIndicators = []struct {
+	name         string
+	newIndicator func() (gotrade.DOHLCVTickReceiver, error)
+}{
+	{"Adl", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewAdl() }},
+	{"Adx", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAdx() }},
+	{"Adxr", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAdxr() }},
+	{"Aroon", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAroon() }},
+	{"AroonOsc", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAroonOsc() }},
+	{"Atr", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultAtr() }},
+	{"AvgPrice", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewAvgPrice() }},
+	{"BollingerBands", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultBollingerBands() }},
+	{"Cci", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultCci() }},
+	{"ChaikinOsc", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultChaikinOsc() }},
+	{"Dema", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultDema() }},
+	{"Dx", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultDx() }},
+	{"Ema", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultEma() }},
+	{"Hhv", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultHhv() }},
+	{"HhvBars", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultHhvBars() }},
+	{"Kama", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultKama() }},
+	{"LinReg", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinReg() }},
+	{"LinRegAng", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinRegAng() }},
+	{"LinRegInt", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinRegInt() }},
+	{"LinRegSlp", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLinRegSlp() }},
+	{"Llv", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLlv() }},
+	{"LlvBars", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultLlvBars() }},
+	{"Macd", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMacd() }},
+	{"MedPrice", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewMedPrice() }},
+	{"Mfi", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMfi() }},
+	{"MinusDi", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMinusDi() }},
+	{"MinusDm", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMinusDm() }},
+	{"Mom", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultMom() }},
+	{"Obv", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewObv() }},
+	{"PlusDi", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultPlusDi() }},
+	{"PlusDm", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultPlusDm() }},
+	{"Roc", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRoc() }},
+	{"RocP", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRocP() }},
+	{"RocR", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRocR() }},
+	{"RocR100", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRocR100() }},
+	{"Rsi", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultRsi() }},
+	{"Sar", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultSar() }},
+	{"Sma", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultSma() }},
+	{"StdDev", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultStdDev() }},
+	{"StochOsc", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultStochOsc() }},
+	{"StochRsi", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultStochRsi() }},
+	{"Tema", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultTema() }},
+	{"Trima", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultTrima() }},
+	{"TrueRange", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewTrueRange() }},
+	{"Tsf", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultTsf() }},
+	{"TypPrice", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewTypPrice() }},
+	{"Var", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultVar() }},
+	{"WillR", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultWillR() }},
+	{"Wma", func() (gotrade.DOHLCVTickReceiver, error) { return indicators.NewDefaultWma() }},
+	{"ZigZag", func() (gotrade.DOHLCVTickReceiver, error) {
+		return indicators.NewZigZagWithoutStorage(5.0, func(event indicators.ZigZagEvent) {})
+	}},
+}
+
+// loadBenchmarkData loads the full JSE top 40 history into memory so that the benchmarks only measure the indicators
+func loadBenchmarkData(b *testing.B) []gotrade.DOHLCV {
+	source := gotrade.NewDailyDOHLCVStream()
+	csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat("../testdata/JSETOPI.ALL.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+	if err := csvFeed.FillDOHLCVStream(source); err != nil {
+		b.Fatal(err)
+	}
+	return source.Data
+}
+
+// BenchmarkIndicators measures each indicator receiving a tick once it is producing results. The stored results
+// are capped so that receiving a tick should not allocate, a non zero allocs/op is a regression.
+func BenchmarkIndicators(b *testing.B) {
+	data := loadBenchmarkData(b)
+	for _, benchmark := range benchmarkIndicators {
+		newIndicator := benchmark.newIndicator
+		b.Run(benchmark.name, func(b *testing.B) {
+			indicator, err := newIndicator()
+			if err != nil {
+				b.Fatal(err)
+			}
+			if capped, ok := indicator.(indicators.IndicatorWithMaxHistory); ok {
+				capped.SetMaxHistory(100)
+			}
+
+			// warm up the indicator so that it is producing results
+			for i, dataItem := range data {
+				indicator.ReceiveDOHLCVTick(dataItem, i+1)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				indicator.ReceiveDOHLCVTick(data[n%len(data)], len(data)+n+1)
+			}
+		})
+	}
+}
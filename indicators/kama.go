@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 	"math"
@@ -13,15 +12,18 @@ type KamaWithoutStorage struct {
 
 	// private variables
 	periodTotal   float64
-	periodHistory *list.List
+	periodHistory *gotrade.FloatRingBuffer
 	periodCounter int
 	constantMax   float64
 	constantDiff  float64
-	sumROC        float64
+	sumROC        KahanSum
 	periodROC     float64
 	previousClose float64
 	previousKama  float64
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *KamaWithoutStorage
 }
 
 // NewKamaWithoutStorage creates a Kaufman Adaptive Moving Average Indicator (Kama) without storage
@@ -48,9 +50,8 @@ func NewKamaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAc
 		periodCounter:                (timePeriod + 1) * -1,
 		constantMax:                  float64(2.0 / (30.0 + 1.0)),
 		constantDiff:                 float64((2.0 / (2.0 + 1.0)) - (2.0 / (30.0 + 1.0))),
-		sumROC:                       0.0,
 		periodROC:                    0.0,
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod + 1),
 		previousClose:                math.SmallestNonzeroFloat64,
 		timePeriod:                   timePeriod,
 	}
@@ -78,9 +79,13 @@ func NewKama(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indica
 	}
 
 	ind.KamaWithoutStorage, err = NewKamaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -149,27 +154,37 @@ func (ind *Kama) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Kama) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *KamaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
+	// the close timePeriod+1 bars ago is discarded by the history once it is full
+	oldestClose, _ := ind.periodHistory.Push(tickData)
 
 	if ind.periodCounter <= 0 {
 		if ind.previousClose > math.SmallestNonzeroFloat64 {
-			ind.sumROC += math.Abs(tickData - ind.previousClose)
+			ind.sumROC.Add(math.Abs(tickData - ind.previousClose))
 		}
 	}
 	if ind.periodCounter == 0 {
 		var er float64 = 0.0
 		var sc float64 = 0.0
-		var closeMinusN float64 = ind.periodHistory.Front().Value.(float64)
+		var closeMinusN float64 = ind.periodHistory.Oldest()
 		ind.previousKama = ind.previousClose
 		ind.periodROC = tickData - closeMinusN
 
 		// calculate the efficiency ratio
-		if ind.sumROC <= ind.periodROC || isZero(ind.sumROC) {
+		if ind.sumROC.Value() <= ind.periodROC || isZero(ind.sumROC.Value()) {
 			er = 1.0
 		} else {
-			er = math.Abs(ind.periodROC / ind.sumROC)
+			er = math.Abs(ind.periodROC / ind.sumROC.Value())
 		}
 
 		sc = (er * ind.constantDiff) + ind.constantMax
@@ -184,18 +199,18 @@ func (ind *KamaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 
 		var er float64 = 0.0
 		var sc float64 = 0.0
-		var closeMinusN float64 = ind.periodHistory.Front().Value.(float64)
-		var closeMinusN1 float64 = ind.periodHistory.Front().Next().Value.(float64)
+		var closeMinusN float64 = oldestClose
+		var closeMinusN1 float64 = ind.periodHistory.Oldest()
 		ind.periodROC = tickData - closeMinusN1
 
-		ind.sumROC -= math.Abs(closeMinusN1 - closeMinusN)
-		ind.sumROC += math.Abs(tickData - ind.previousClose)
+		ind.sumROC.Sub(math.Abs(closeMinusN1 - closeMinusN))
+		ind.sumROC.Add(math.Abs(tickData - ind.previousClose))
 
 		// calculate the efficiency ratio
-		if ind.sumROC <= ind.periodROC || isZero(ind.sumROC) {
+		if ind.sumROC.Value() <= ind.periodROC || isZero(ind.sumROC.Value()) {
 			er = 1.0
 		} else {
-			er = math.Abs(ind.periodROC / ind.sumROC)
+			er = math.Abs(ind.periodROC / ind.sumROC.Value())
 		}
 
 		sc = (er * ind.constantDiff) + ind.constantMax
@@ -209,14 +224,39 @@ func (ind *KamaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 
 	ind.previousClose = tickData
 
-	if ind.periodHistory.Len() > (ind.timePeriod + 1) {
-		var first = ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-	}
+}
 
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *KamaWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
 }
 
 func isZero(value float64) bool {
 	var epsilon float64 = 0.00000000000001
 	return (((-epsilon) < value) && (value < epsilon))
 }
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *KamaWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(KamaWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *KamaWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -13,12 +12,15 @@ type LinRegWithoutStorage struct {
 
 	// private variables
 	periodCounter        int
-	periodHistory        *list.List
+	periodHistory        *gotrade.FloatRingBuffer
 	sumX                 float64
 	sumXSquare           float64
 	divisor              float64
 	valueAvailableAction ValueAvailableActionLinearReg
 	timePeriod           int
+
+	// the state prior to the latest source data bar
+	savedState *LinRegWithoutStorage
 }
 
 // NewLinRegWithoutStorage creates a Linear Regression Indicator (LinReg) without storage
@@ -43,7 +45,7 @@ func NewLinRegWithoutStorage(timePeriod int, valueAvailableAction ValueAvailable
 		baseIndicator:        newBaseIndicator(lookback),
 		baseFloatBounds:      newBaseFloatBounds(),
 		periodCounter:        (timePeriod) * -1,
-		periodHistory:        list.New(),
+		periodHistory:        gotrade.NewFloatRingBuffer(timePeriod - 1),
 		valueAvailableAction: valueAvailableAction,
 		timePeriod:           timePeriod,
 	}
@@ -80,11 +82,15 @@ func NewLinReg(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indi
 
 	ind.LinRegWithoutStorage, err = NewLinRegWithoutStorage(timePeriod,
 		func(dataItem float64, slope float64, intercept float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 
 			ind.UpdateMinMax(dataItem, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -153,17 +159,24 @@ func (ind *LinReg) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *LinReg) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *LinRegWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 
 	if ind.periodCounter >= 0 {
 		sumXY := 0.0
 		sumY := 0.0
 		i := ind.timePeriod
-		var value float64 = 0.0
-		for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
+		for _, value := range ind.periodHistory.Values() {
 			i--
-			value = e.Value.(float64)
 			sumY += value
 			sumXY += (float64(i) * value)
 		}
@@ -183,11 +196,36 @@ func (ind *LinRegWithoutStorage) ReceiveTick(tickData float64, streamBarIndex in
 		ind.valueAvailableAction(result, m, b, streamBarIndex)
 	}
 
-	ind.periodHistory.PushBack(tickData)
+	ind.periodHistory.Push(tickData)
+
+}
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *LinRegWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *LinRegWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
 
-	if ind.periodHistory.Len() >= ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+	if ind.savedState == nil {
+		ind.savedState = new(LinRegWithoutStorage)
 	}
+	*ind.savedState = *ind
+}
 
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *LinRegWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
 }
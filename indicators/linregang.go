@@ -30,9 +30,13 @@ func NewLinRegAng(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 
 			ind.UpdateMinMax(result, result)
 
-			ind.Data = append(ind.Data, result)
+			ind.Data = ind.storeFloat(0, ind.Data, result)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -100,3 +104,10 @@ func (ind *LinRegAng) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex
 	var selectedData = ind.selectData(tickData)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *LinRegAng) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
@@ -29,9 +29,13 @@ func NewLinRegSlp(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (i
 
 			ind.UpdateMinMax(result, result)
 
-			ind.Data = append(ind.Data, result)
+			ind.Data = ind.storeFloat(0, ind.Data, result)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -99,3 +103,10 @@ func (ind *LinRegSlp) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex
 	var selectedData = ind.selectData(tickData)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *LinRegSlp) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
@@ -1,10 +1,8 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
 )
 
 // A Lowest Low Value Indicator (Llv), no storage, for use in other indicators
@@ -12,10 +10,12 @@ type LlvWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodHistory   *list.List
-	currentLow      float64
-	currentLowIndex int
-	timePeriod      int
+	periodCounter int
+	periodLow     *RollingExtreme
+	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *LlvWithoutStorage
 }
 
 // NewLlvWithoutStorage creates a Lowest Low Value Indicator Indicator (Llv) without storage
@@ -39,9 +39,8 @@ func NewLlvWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	lookback := timePeriod - 1
 	ind := LlvWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		currentLow:                   math.MaxFloat64,
-		currentLowIndex:              0,
-		periodHistory:                list.New(),
+		periodCounter:                timePeriod * -1,
+		periodLow:                    NewRollingMin(timePeriod, false),
 		timePeriod:                   timePeriod,
 	}
 
@@ -68,9 +67,13 @@ func NewLlv(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 	}
 
 	ind.LlvWithoutStorage, err = NewLlvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -139,52 +142,52 @@ func (ind *Llv) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Llv) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *LlvWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current low
-		if ind.currentLowIndex == ind.timePeriod-1 {
-			ind.currentLow = math.MaxFloat64
-			// we have we need to find the new low in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value < ind.currentLow {
-					ind.currentLow = value
-					ind.currentLowIndex = i
-				}
-				i -= 1
-			}
-		} else {
-			if tickData < ind.currentLow {
-				ind.currentLow = tickData
-				ind.currentLowIndex = 0
-			} else {
-				ind.currentLowIndex += 1
-			}
-		}
-
-		var result = ind.currentLow
+	ind.saveState(streamBarIndex)
+
+	ind.periodCounter += 1
+	ind.periodLow.Push(tickData)
+
+	if ind.periodCounter >= 0 {
+		var result = ind.periodLow.Value()
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *LlvWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
 
-	} else {
-		if tickData < ind.currentLow {
-			ind.currentLow = tickData
-			ind.currentLowIndex = 0
-		} else {
-			ind.currentLowIndex += 1
-		}
+// saveState records the state of the indicator prior to the source data bar
+func (ind *LlvWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentLow
+	ind.saveBounds()
+	ind.periodLow.SaveState()
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
+	if ind.savedState == nil {
+		ind.savedState = new(LlvWithoutStorage)
 	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *LlvWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodLow.RestoreState()
+	*ind = *ind.savedState
 }
@@ -406,3 +406,36 @@ var _ = Describe("when calculating a lowest low value (llv) with DOHLCV source d
 	})
 
 })
+
+var _ = Describe("when calculating a lowest low value (llv) of negative source data", func() {
+	var (
+		llv     *indicators.LlvWithoutStorage
+		llvBars *indicators.LlvBarsWithoutStorage
+		lows    []float64
+		bars    []int64
+	)
+
+	BeforeEach(func() {
+		lows = nil
+		bars = nil
+		llv, _ = indicators.NewLlvWithoutStorage(3, func(dataItem float64, streamBarIndex int) {
+			lows = append(lows, dataItem)
+		})
+		llvBars, _ = indicators.NewLlvBarsWithoutStorage(3, func(dataItem int64, streamBarIndex int) {
+			bars = append(bars, dataItem)
+		})
+
+		for i, value := range []float64{-5.0, -7.0, -4.0, -7.0, -3.0, -2.0, -1.0} {
+			llv.ReceiveTick(value, i+1)
+			llvBars.ReceiveTick(value, i+1)
+		}
+	})
+
+	It("should return the lowest value of each period", func() {
+		Expect(lows).To(Equal([]float64{-7.0, -7.0, -7.0, -7.0, -3.0}))
+	})
+
+	It("should return the bars since the oldest of equal lowest values", func() {
+		Expect(bars).To(Equal([]int64{1, 2, 1, 2, 2}))
+	})
+})
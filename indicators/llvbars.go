@@ -1,10 +1,8 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
 )
 
 // A Lowest Low Value Bars Indicator (LlvBars), no storage, for use in other indicators
@@ -12,10 +10,12 @@ type LlvBarsWithoutStorage struct {
 	*baseIndicatorWithIntBounds
 
 	// private variables
-	periodHistory   *list.List
-	currentLow      float64
-	currentLowIndex int64
-	timePeriod      int
+	periodCounter int
+	periodLow     *RollingExtreme
+	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *LlvBarsWithoutStorage
 }
 
 // NewLlvBarsWithoutStorage creates a Lowest Low Value Bars Indicator Indicator (LlvBars) without storage
@@ -40,9 +40,8 @@ func NewLlvBarsWithoutStorage(timePeriod int, valueAvailableAction ValueAvailabl
 
 	ind := LlvBarsWithoutStorage{
 		baseIndicatorWithIntBounds: newBaseIndicatorWithIntBounds(lookback, valueAvailableAction),
-		currentLow:                 math.MaxFloat64,
-		currentLowIndex:            0,
-		periodHistory:              list.New(),
+		periodCounter:              timePeriod * -1,
+		periodLow:                  NewRollingMin(timePeriod, false),
 		timePeriod:                 timePeriod,
 	}
 
@@ -69,9 +68,13 @@ func NewLlvBars(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (ind
 	}
 
 	ind.LlvBarsWithoutStorage, err = NewLlvBarsWithoutStorage(timePeriod, func(dataItem int64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeInt(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -140,53 +143,52 @@ func (ind *LlvBars) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex in
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *LlvBars) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *LlvBarsWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current low
-		if ind.currentLowIndex == int64(ind.timePeriod-1) {
-			ind.currentLow = math.MaxFloat64
-			// we have we need to find the new low in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value < ind.currentLow {
-					ind.currentLow = value
-					ind.currentLowIndex = int64(i)
-				}
-				i -= 1
-			}
-		} else {
-			if tickData < ind.currentLow {
-				ind.currentLow = tickData
-				ind.currentLowIndex = 0
-			} else {
-				ind.currentLowIndex += 1
-			}
-		}
-
-		var result = ind.currentLowIndex
+	ind.saveState(streamBarIndex)
+
+	ind.periodCounter += 1
+	ind.periodLow.Push(tickData)
+
+	if ind.periodCounter >= 0 {
+		var result = int64(ind.periodLow.Age())
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
 
-	} else {
-		if tickData < ind.currentLow {
-			ind.currentLow = tickData
-			ind.currentLowIndex = 0
-		} else {
-			ind.currentLowIndex += 1
-		}
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *LlvBarsWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentLowIndex
+// saveState records the state of the indicator prior to the source data bar
+func (ind *LlvBarsWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodLow.SaveState()
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
+	if ind.savedState == nil {
+		ind.savedState = new(LlvBarsWithoutStorage)
 	}
+	*ind.savedState = *ind
+}
 
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *LlvBarsWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodLow.RestoreState()
+	*ind = *ind.savedState
 }
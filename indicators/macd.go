@@ -25,6 +25,9 @@ type Macd struct {
 	emaSlowSkip          int
 	selectData           gotrade.DOHLCVDataSelectionFunc
 
+	// the state prior to the latest source data bar
+	savedState *Macd
+
 	// public variables
 	Macd      []float64
 	Signal    []float64
@@ -117,10 +120,15 @@ func NewMacd(fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, selec
 
 	ind.selectData = selectData
 	ind.valueAvailableAction = func(dataItemMacd float64, dataItemSignal float64, dataItemHistogram float64, streamBarIndex int) {
-		ind.Macd = append(ind.Macd, dataItemMacd)
-		ind.Signal = append(ind.Signal, dataItemSignal)
-		ind.Histogram = append(ind.Histogram, dataItemHistogram)
+		ind.Macd = ind.storeFloat(0, ind.Macd, dataItemMacd)
+		ind.Signal = ind.storeFloat(1, ind.Signal, dataItemSignal)
+		ind.Histogram = ind.storeFloat(2, ind.Histogram, dataItemHistogram)
+	}
+
+	if err == nil {
+		ind.nameOutputs("Macd", "Signal", "Histogram")
 	}
+
 	return &ind, err
 }
 
@@ -199,9 +207,52 @@ func (ind *Macd) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Macd) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *Macd) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	if streamBarIndex > ind.emaSlowSkip {
 		ind.emaFast.ReceiveTick(tickData, streamBarIndex)
 	}
 	ind.emaSlow.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *Macd) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *Macd) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.emaFast.saveState(streamBarIndex)
+	ind.emaSlow.saveState(streamBarIndex)
+	ind.emaSignal.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(Macd)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *Macd) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.emaFast.restoreState()
+	ind.emaSlow.restoreState()
+	ind.emaSignal.restoreState()
+	*ind = *ind.savedState
+}
@@ -455,3 +455,26 @@ var _ = Describe("when calculating a moving average convergence divergence (macd
 	})
 
 })
+
+var _ = Describe("when calculating a moving average convergence divergence (macd) with a capped history", func() {
+	var (
+		indicator *indicators.Macd
+		uncapped  *indicators.Macd
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewMacd(3, 5, 2, gotrade.UseClosePrice)
+		indicator.SetMaxHistory(3)
+		uncapped, _ = indicators.NewMacd(3, 5, 2, gotrade.UseClosePrice)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			uncapped.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("the indicator should only store the most recent results of each output", func() {
+		Expect(indicator.Macd).To(Equal(uncapped.Macd[len(uncapped.Macd)-3:]))
+		Expect(indicator.Signal).To(Equal(uncapped.Signal[len(uncapped.Signal)-3:]))
+		Expect(indicator.Histogram).To(Equal(uncapped.Histogram[len(uncapped.Histogram)-3:]))
+	})
+})
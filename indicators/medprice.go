@@ -7,6 +7,9 @@ import (
 // A Median Price Indicator (MedPrice), no storage, for use in other indicators
 type MedPriceWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
+
+	// the state prior to the latest source data bar
+	savedState *MedPriceWithoutStorage
 }
 
 // NewMedPriceWithoutStorage creates a Median Price Indicator (MedPrice) without storage
@@ -36,9 +39,13 @@ type MedPrice struct {
 func NewMedPrice() (indicator *MedPrice, err error) {
 	ind := MedPrice{}
 	ind.MedPriceWithoutStorage, err = NewMedPriceWithoutStorage(func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -70,8 +77,37 @@ func NewMedPriceForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLC
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *MedPriceWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
 	result := (tickData.H() + tickData.L()) / float64(2.0)
 
 	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *MedPriceWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *MedPriceWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(MedPriceWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *MedPriceWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -13,13 +12,16 @@ type MfiWithoutStorage struct {
 	// private variables
 	periodCounter     int
 	typicalPrice      *TypPriceWithoutStorage
-	positiveMoneyFlow float64
-	negativeMoneyFlow float64
-	positiveHistory   *list.List
-	negativeHistory   *list.List
+	positiveMoneyFlow KahanSum
+	negativeMoneyFlow KahanSum
+	positiveHistory   *gotrade.FloatRingBuffer
+	negativeHistory   *gotrade.FloatRingBuffer
 	previousTypPrice  float64
 	currentVolume     float64
 	timePeriod        int
+
+	// the state prior to the latest source data bar
+	savedState *MfiWithoutStorage
 }
 
 // NewMfiWithoutStorage creates a Money Flow Index Indicator (Mfi) without storage
@@ -44,10 +46,8 @@ func NewMfiWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	ind := MfiWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                (timePeriod * -1) - 1,
-		positiveHistory:              list.New(),
-		negativeHistory:              list.New(),
-		positiveMoneyFlow:            0.0,
-		negativeMoneyFlow:            0.0,
+		positiveHistory:              gotrade.NewFloatRingBuffer(timePeriod),
+		negativeHistory:              gotrade.NewFloatRingBuffer(timePeriod),
 		currentVolume:                0.0,
 		previousTypPrice:             0.0,
 		timePeriod:                   timePeriod,
@@ -59,64 +59,36 @@ func NewMfiWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 		if ind.periodCounter > (ind.timePeriod * -1) {
 			moneyFlow := dataItem * ind.currentVolume
 
-			if ind.periodCounter <= 0 {
-				if dataItem > ind.previousTypPrice {
-					ind.positiveMoneyFlow += moneyFlow
-					ind.positiveHistory.PushBack(moneyFlow)
-					ind.negativeHistory.PushBack(0.0)
-				} else if dataItem < ind.previousTypPrice {
-					ind.negativeMoneyFlow += moneyFlow
-					ind.positiveHistory.PushBack(0.0)
-					ind.negativeHistory.PushBack(moneyFlow)
-				} else {
-					ind.positiveHistory.PushBack(0.0)
-					ind.negativeHistory.PushBack(0.0)
-				}
+			var positiveFlow float64 = 0.0
+			var negativeFlow float64 = 0.0
+			if dataItem > ind.previousTypPrice {
+				positiveFlow = moneyFlow
+			} else if dataItem < ind.previousTypPrice {
+				negativeFlow = moneyFlow
 			}
 
-			if ind.periodCounter == 0 {
-
-				result := 100.0 * (ind.positiveMoneyFlow / (ind.positiveMoneyFlow + ind.negativeMoneyFlow))
+			// the flows leaving the period are discarded by the histories once they are full
+			firstPositive, discarded := ind.positiveHistory.Push(positiveFlow)
+			firstNegative, _ := ind.negativeHistory.Push(negativeFlow)
+			if discarded {
+				ind.positiveMoneyFlow.Sub(firstPositive)
+				ind.negativeMoneyFlow.Sub(firstNegative)
+			}
 
-				ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+			if dataItem > ind.previousTypPrice {
+				ind.positiveMoneyFlow.Add(moneyFlow)
+			} else if dataItem < ind.previousTypPrice {
+				ind.negativeMoneyFlow.Add(moneyFlow)
 			}
-			if ind.periodCounter > 0 {
-				firstPositive := ind.positiveHistory.Front().Value.(float64)
-				ind.positiveMoneyFlow -= firstPositive
-
-				firstNegative := ind.negativeHistory.Front().Value.(float64)
-				ind.negativeMoneyFlow -= firstNegative
-
-				if dataItem > ind.previousTypPrice {
-					ind.positiveMoneyFlow += moneyFlow
-					ind.positiveHistory.PushBack(moneyFlow)
-					ind.negativeHistory.PushBack(0.0)
-				} else if dataItem < ind.previousTypPrice {
-					ind.negativeMoneyFlow += moneyFlow
-					ind.positiveHistory.PushBack(0.0)
-					ind.negativeHistory.PushBack(moneyFlow)
-				} else {
-					ind.positiveHistory.PushBack(0.0)
-					ind.negativeHistory.PushBack(0.0)
-				}
-
-				result := 100.0 * (ind.positiveMoneyFlow / (ind.positiveMoneyFlow + ind.negativeMoneyFlow))
+
+			if ind.periodCounter >= 0 {
+				positiveMoneyFlow := ind.positiveMoneyFlow.Value()
+				result := 100.0 * (positiveMoneyFlow / (positiveMoneyFlow + ind.negativeMoneyFlow.Value()))
 
 				ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 			}
-
 		}
 		ind.previousTypPrice = dataItem
-
-		if ind.positiveHistory.Len() > ind.timePeriod {
-			first := ind.positiveHistory.Front()
-			ind.positiveHistory.Remove(first)
-		}
-
-		if ind.negativeHistory.Len() > ind.timePeriod {
-			first := ind.negativeHistory.Front()
-			ind.negativeHistory.Remove(first)
-		}
 	})
 
 	return &ind, err
@@ -134,9 +106,13 @@ type Mfi struct {
 func NewMfi(timePeriod int) (indicator *Mfi, err error) {
 	ind := Mfi{}
 	ind.MfiWithoutStorage, err = NewMfiWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -201,6 +177,42 @@ func NewDefaultMfiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOH
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *MfiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.currentVolume = tickData.V()
 	ind.typicalPrice.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *MfiWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *MfiWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.positiveHistory.SaveState()
+	ind.negativeHistory.SaveState()
+	ind.typicalPrice.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(MfiWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *MfiWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.positiveHistory.RestoreState()
+	ind.negativeHistory.RestoreState()
+	ind.typicalPrice.restoreState()
+	*ind = *ind.savedState
+}
@@ -18,6 +18,9 @@ type MinusDiWithoutStorage struct {
 	currentTrueRange  float64
 	trueRange         *TrueRange
 	timePeriod        int
+
+	// the state prior to the latest source data bar
+	savedState *MinusDiWithoutStorage
 }
 
 // NewMinusDiWithoutStorage creates a Minus Directional Indicator (MinusDi) without storage
@@ -72,9 +75,13 @@ type MinusDi struct {
 func NewMinusDi(timePeriod int) (indicator *MinusDi, err error) {
 	ind := MinusDi{}
 	ind.MinusDiWithoutStorage, err = NewMinusDiWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -139,6 +146,7 @@ func NewDefaultMinusDiForStreamWithSrcLen(sourceLength uint, priceStream gotrade
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *MinusDiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
 	// forward to the true range indicator first using previous data
 	ind.trueRange.ReceiveDOHLCVTick(tickData, streamBarIndex)
@@ -194,3 +202,33 @@ func (ind *MinusDiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, str
 	ind.previousHigh = high
 	ind.previousLow = low
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *MinusDiWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *MinusDiWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.trueRange.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(MinusDiWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *MinusDiWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.trueRange.restoreState()
+	*ind = *ind.savedState
+}
@@ -15,6 +15,9 @@ type MinusDmWithoutStorage struct {
 	previousLow     float64
 	previousMinusDm float64
 	timePeriod      int
+
+	// the state prior to the latest source data bar
+	savedState *MinusDmWithoutStorage
 }
 
 // NewMinusDmWithoutStorage creates a Minus Directional Movement Indicator (MinusDm) without storage
@@ -61,9 +64,13 @@ type MinusDm struct {
 func NewMinusDm(timePeriod int) (indicator *MinusDm, err error) {
 	ind := MinusDm{}
 	ind.MinusDmWithoutStorage, err = NewMinusDmWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -128,6 +135,8 @@ func NewDefaultMinusDmForStreamWithSrcLen(sourceLength uint, priceStream gotrade
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *MinusDmWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 	high := tickData.H()
 	low := tickData.L()
@@ -178,3 +187,31 @@ func (ind *MinusDmWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, str
 	ind.previousHigh = high
 	ind.previousLow = low
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *MinusDmWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *MinusDmWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(MinusDmWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *MinusDmWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -12,8 +11,11 @@ type MomWithoutStorage struct {
 
 	// private variables
 	periodCounter int
-	periodHistory *list.List
+	periodHistory *gotrade.FloatRingBuffer
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *MomWithoutStorage
 }
 
 // NewMomWithoutStorage creates a Momentum Indicator (Mom) without storage
@@ -38,7 +40,7 @@ func NewMomWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	ind := MomWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                (timePeriod * -1),
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:                   timePeriod,
 	}
 
@@ -66,9 +68,13 @@ func NewMom(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 
 	ind.MomWithoutStorage, err = NewMomWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -139,22 +145,55 @@ func (ind *Mom) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Mom) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *MomWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
+	// the price timePeriod bars ago is discarded by the history once it is full
+	previousPrice, _ := ind.periodHistory.Push(tickData)
 
 	if ind.periodCounter > 0 {
 
 		// Mom = price - previousPrice
-		previousPrice := ind.periodHistory.Front().Value.(float64)
-
 		var result float64 = tickData - previousPrice
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
+}
 
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *MomWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
 	}
 }
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *MomWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(MomWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *MomWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
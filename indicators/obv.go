@@ -12,6 +12,9 @@ type ObvWithoutStorage struct {
 	periodCounter int
 	previousObv   float64
 	previousClose float64
+
+	// the state prior to the latest source data bar
+	savedState *ObvWithoutStorage
 }
 
 // NewObvWithoutStorage creates an On Balance Volume Indicator (Obv) without storage
@@ -45,9 +48,13 @@ type Obv struct {
 func NewObv() (indicator *Obv, err error) {
 	ind := Obv{}
 	ind.ObvWithoutStorage, err = NewObvWithoutStorage(func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -79,6 +86,8 @@ func NewObvForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStre
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *ObvWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 
 	if ind.periodCounter <= 0 {
@@ -105,3 +114,31 @@ func (ind *ObvWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamB
 		ind.previousClose = tickData.C()
 	}
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *ObvWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *ObvWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(ObvWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *ObvWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
@@ -0,0 +1,100 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"sync"
+)
+
+// TickStreamSubscriber is a source of float ticks, e.g. an output of an indicator
+type TickStreamSubscriber interface {
+	AddTickSubscription(subscriber gotrade.TickReceiver)
+}
+
+// TickStreamUnsubscriber is a source of float ticks which subscribers can leave
+type TickStreamUnsubscriber interface {
+	RemoveTickSubscription(subscriber gotrade.TickReceiver)
+}
+
+// chainableIndicator is implemented by indicators which can take their source data from the output of
+// another indicator, adding its lookback period to their own
+type chainableIndicator interface {
+	setSource(source Indicator)
+}
+
+// An IndicatorOutput is the stream of results of one output of an indicator, e.g. the Signal line of a Macd.
+// Each result is published to the subscribers as a float tick with the source data bar index it was calculated
+// for, so an indicator subscribed to the output is valid from the bar at which it has received enough results,
+// and its lookback period includes the lookback period of the output's indicator.
+// A revision of the latest result is published to the subscribers which implement gotrade.TickUpdateReceiver,
+// the other subscribers ignore revisions.
+type IndicatorOutput struct {
+	// private variables
+	name             string
+	indicator        *baseIndicator
+	subscribers      []gotrade.TickReceiver
+	subscribersMutex sync.Mutex
+}
+
+func newIndicatorOutput(name string, indicator *baseIndicator) *IndicatorOutput {
+	return &IndicatorOutput{name: name, indicator: indicator}
+}
+
+// Name returns the name of the output, the name of the indicator's field which stores its results
+func (o *IndicatorOutput) Name() string {
+	return o.name
+}
+
+// ValidFromBar returns the source data bar number from which the output's indicator is valid, starting at bar 1
+func (o *IndicatorOutput) ValidFromBar() int {
+	return o.indicator.ValidFromBar()
+}
+
+// GetLookbackPeriod returns the lookback period of the output's indicator, including that of its sources
+func (o *IndicatorOutput) GetLookbackPeriod() int {
+	return o.indicator.GetLookbackPeriod()
+}
+
+// Length returns the number of results published by the output
+func (o *IndicatorOutput) Length() int {
+	return o.indicator.Length()
+}
+
+// AddTickSubscription publishes the output's results to the subscriber, an indicator subscribed to the output
+// takes the output as its source
+func (o *IndicatorOutput) AddTickSubscription(subscriber gotrade.TickReceiver) {
+	if chained, ok := subscriber.(chainableIndicator); ok {
+		chained.setSource(o)
+	}
+
+	o.subscribersMutex.Lock()
+	defer o.subscribersMutex.Unlock()
+	o.subscribers = append(o.subscribers, subscriber)
+}
+
+// RemoveTickSubscription stops publishing the output's results to the subscriber
+func (o *IndicatorOutput) RemoveTickSubscription(subscriber gotrade.TickReceiver) {
+	o.subscribersMutex.Lock()
+	defer o.subscribersMutex.Unlock()
+	subscribers := make([]gotrade.TickReceiver, 0, len(o.subscribers))
+	for _, existing := range o.subscribers {
+		if existing != subscriber {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	o.subscribers = subscribers
+}
+
+// publish passes a result, or a revision of the latest result, on to the subscribers
+func (o *IndicatorOutput) publish(value float64, streamBarIndex int, revision bool) {
+	o.subscribersMutex.Lock()
+	subscribers := o.subscribers
+	o.subscribersMutex.Unlock()
+
+	for _, subscriber := range subscribers {
+		if !revision {
+			subscriber.ReceiveTick(value, streamBarIndex)
+		} else if receiver, ok := subscriber.(gotrade.TickUpdateReceiver); ok {
+			receiver.ReceiveTickUpdate(value, streamBarIndex)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when requesting the outputs of an indicator", func() {
+	var (
+		indicator *indicators.Macd
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultMacd()
+	})
+
+	It("should have an output named after each result field", func() {
+		var names []string
+		for _, output := range indicator.Outputs() {
+			names = append(names, output.Name())
+		}
+		Expect(names).To(Equal([]string{"Macd", "Signal", "Histogram"}))
+	})
+
+	It("should find an output by name", func() {
+		output, ok := indicator.Output("Signal")
+		Expect(ok).To(BeTrue())
+		Expect(output.Name()).To(Equal("Signal"))
+		Expect(output.GetLookbackPeriod()).To(Equal(indicator.GetLookbackPeriod()))
+	})
+
+	It("should not find an output with an unknown name", func() {
+		_, ok := indicator.Output("Data")
+		Expect(ok).To(BeFalse())
+	})
+})
+
+var _ = Describe("when chaining a simple moving average (sma) to the output of a relative strength index (rsi)", func() {
+	var (
+		rsi      *indicators.Rsi
+		sma      *indicators.Sma
+		expected []float64
+	)
+
+	BeforeEach(func() {
+		rsi, _ = indicators.NewDefaultRsi()
+		sma, _ = indicators.NewSma(4, gotrade.UseClosePrice)
+		output, _ := rsi.Output("Data")
+		output.AddTickSubscription(sma)
+
+		expected = nil
+		standalone, _ := indicators.NewSmaWithoutStorage(4, func(dataItem float64, streamBarIndex int) {
+			expected = append(expected, dataItem)
+		})
+
+		for i := range sourceDOHLCVData {
+			rsi.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+		for i := range rsi.Data {
+			standalone.ReceiveTick(rsi.Data[i], i+1)
+		}
+	})
+
+	It("should include the lookback period of the rsi in its lookback period", func() {
+		Expect(sma.GetLookbackPeriod()).To(Equal(rsi.GetLookbackPeriod() + 3))
+	})
+
+	It("should be valid from the source data bar at which it received enough rsi results", func() {
+		Expect(sma.ValidFromBar()).To(Equal(rsi.ValidFromBar() + 3))
+		Expect(sma.ValidFromBar()).To(Equal(sma.GetLookbackPeriod() + 1))
+	})
+
+	It("should calculate the sma of the rsi results", func() {
+		Expect(sma.Length()).To(Equal(rsi.Length() - 3))
+		Expect(sma.Data).To(Equal(expected))
+	})
+
+	Context("and a further indicator is chained to the output of the sma", func() {
+		var (
+			ema *indicators.Ema
+		)
+
+		BeforeEach(func() {
+			rsi, _ = indicators.NewDefaultRsi()
+			sma, _ = indicators.NewSma(4, gotrade.UseClosePrice)
+			ema, _ = indicators.NewEma(3, gotrade.UseClosePrice)
+			rsiOutput, _ := rsi.Output("Data")
+			rsiOutput.AddTickSubscription(sma)
+			smaOutput, _ := sma.Output("Data")
+			smaOutput.AddTickSubscription(ema)
+
+			for i := range sourceDOHLCVData {
+				rsi.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should propagate the lookback period and valid from bar through the chain", func() {
+			Expect(ema.GetLookbackPeriod()).To(Equal(rsi.GetLookbackPeriod() + 3 + 2))
+			Expect(ema.ValidFromBar()).To(Equal(ema.GetLookbackPeriod() + 1))
+			Expect(ema.Length()).To(Equal(sma.Length() - 2))
+		})
+	})
+
+	Context("and the subscription is removed", func() {
+		BeforeEach(func() {
+			rsi, _ = indicators.NewDefaultRsi()
+			sma, _ = indicators.NewSma(4, gotrade.UseClosePrice)
+			output, _ := rsi.Output("Data")
+			output.AddTickSubscription(sma)
+			output.RemoveTickSubscription(sma)
+
+			for i := range sourceDOHLCVData {
+				rsi.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should not receive any rsi results", func() {
+			Expect(sma.Length()).To(Equal(0))
+		})
+	})
+})
+
+var _ = Describe("when chaining bollinger bands to the output of an on balance volume (obv)", func() {
+	var (
+		obv        *indicators.Obv
+		bollinger  *indicators.BollingerBands
+		revised    *indicators.BollingerBands
+		lastBar    int
+		revisedBar gotrade.DOHLCV
+	)
+
+	BeforeEach(func() {
+		obv, _ = indicators.NewObv()
+		bollinger, _ = indicators.NewBollingerBands(5, gotrade.UseClosePrice)
+		output, _ := obv.Output("Data")
+		output.AddTickSubscription(bollinger)
+
+		lastBar = len(sourceDOHLCVData) - 1
+		for i := range sourceDOHLCVData {
+			obv.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		// the same chain given the revision of the latest bar as its latest bar
+		last := sourceDOHLCVData[lastBar]
+		revisedBar = gotrade.NewDOHLCVDataItem(last.D(), last.O(), last.H(), last.L(), last.C()*2.0, last.V()*3.0)
+		revisedObv, _ := indicators.NewObv()
+		revised, _ = indicators.NewBollingerBands(5, gotrade.UseClosePrice)
+		revisedOutput, _ := revisedObv.Output("Data")
+		revisedOutput.AddTickSubscription(revised)
+		for i := 0; i < lastBar; i++ {
+			revisedObv.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+		revisedObv.ReceiveDOHLCVTick(revisedBar, lastBar+1)
+	})
+
+	It("should include the lookback period of the obv in its lookback period", func() {
+		Expect(bollinger.GetLookbackPeriod()).To(Equal(obv.GetLookbackPeriod() + 4))
+		Expect(bollinger.ValidFromBar()).To(Equal(obv.ValidFromBar() + 4))
+	})
+
+	Context("and the latest bar is revised", func() {
+		BeforeEach(func() {
+			obv.ReceiveDOHLCVTickUpdate(revisedBar, lastBar+1)
+		})
+
+		It("should revise its latest result rather than add a result", func() {
+			Expect(bollinger.Length()).To(Equal(revised.Length()))
+			Expect(bollinger.UpperBand).To(Equal(revised.UpperBand))
+			Expect(bollinger.MiddleBand).To(Equal(revised.MiddleBand))
+			Expect(bollinger.LowerBand).To(Equal(revised.LowerBand))
+		})
+	})
+})
@@ -15,6 +15,9 @@ type PlusDmWithoutStorage struct {
 	previousLow    float64
 	previousPlusDm float64
 	timePeriod     int
+
+	// the state prior to the latest source data bar
+	savedState *PlusDmWithoutStorage
 }
 
 // NewPlusDmWithoutStorage creates a Plus Directional Movement Indicator (PlusDm) without storage
@@ -61,9 +64,13 @@ type PlusDm struct {
 func NewPlusDm(timePeriod int) (indicator *PlusDm, err error) {
 	ind := PlusDm{}
 	ind.PlusDmWithoutStorage, err = NewPlusDmWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -128,6 +135,8 @@ func NewDefaultPlusDmForStreamWithSrcLen(sourceLength uint, priceStream gotrade.
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *PlusDmWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 	high := tickData.H()
 	low := tickData.L()
@@ -178,3 +187,31 @@ func (ind *PlusDmWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, stre
 	ind.previousHigh = high
 	ind.previousLow = low
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *PlusDmWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *PlusDmWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(PlusDmWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *PlusDmWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
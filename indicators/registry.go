@@ -0,0 +1,798 @@
+package indicators
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrIndicatorNameIsMissing = errors.New("An indicator name is required")
+	ErrStrNotRegistered       = "is not a registered indicator"
+	ErrStrNotAParameter       = "is not a parameter of the indicator"
+	ErrStrNotANumber          = "is not a number"
+	ErrStrNotAnInteger        = "is not an integer"
+	ErrStrNotASource          = "is not a source, expected one of open, high, low, close or volume"
+)
+
+// DefaultSource is the source data used by an indicator when its source parameter is not specified
+const DefaultSource = "close"
+
+// ParameterKind is the type of value taken by a parameter of a registered indicator
+type ParameterKind int
+
+const (
+	// an int, e.g. a time period
+	IntParameter ParameterKind = iota
+	// a float64, e.g. an acceleration factor
+	FloatParameter
+	// the name of the price, or volume, of the source data, one of open, high, low, close or volume
+	SourceParameter
+)
+
+// String returns the name of the parameter kind
+func (kind ParameterKind) String() string {
+	switch kind {
+	case IntParameter:
+		return "int"
+	case FloatParameter:
+		return "float"
+	case SourceParameter:
+		return "source"
+	}
+	return "unknown"
+}
+
+// An IndicatorParameter describes a parameter of a registered indicator, its bounds are those validated by the
+// indicator's constructors
+type IndicatorParameter struct {
+	// the name of the parameter in a parameter map, e.g. "period"
+	Name string
+	Kind ParameterKind
+	// the value used when the parameter is not specified, a source parameter defaults to DefaultSource
+	Default float64
+	Min     float64
+	Max     float64
+	// whether the value must be greater than Min, e.g. the percentage of a ZigZag
+	MinExclusive bool
+}
+
+// An IndicatorInfo describes a registered indicator, its parameters, the names of its outputs and its lookback
+// period
+type IndicatorInfo struct {
+	// the name of the indicator in a parameter map, e.g. "macd"
+	Name        string
+	Description string
+	Parameters  []IndicatorParameter
+	// the names of the indicator's result fields, which are also the names of its outputs. Empty for the ZigZag
+	// indicators, whose confirmed pivots are stored in their Data field and reported by events rather than outputs
+	Outputs []string
+	// the lookback period in terms of the parameters, e.g. "slow + signal - 2"
+	Lookback string
+
+	// private variables
+	lookback func(params resolvedParams) int
+	create   func(params resolvedParams) (RegisteredIndicator, error)
+}
+
+// A RegisteredIndicator is an indicator constructed by the registry, it consumes DOHLCV source data
+type RegisteredIndicator interface {
+	Indicator
+	gotrade.DOHLCVTickReceiver
+}
+
+// resolvedParams are the validated parameters of an indicator, with defaults for those not specified
+type resolvedParams struct {
+	values     map[string]float64
+	selectData gotrade.DOHLCVDataSelectionFunc
+}
+
+func (p resolvedParams) int(name string) int {
+	return int(p.values[name])
+}
+
+func (p resolvedParams) float(name string) float64 {
+	return p.values[name]
+}
+
+var sources = map[string]gotrade.DOHLCVDataSelectionFunc{
+	"open":   gotrade.UseOpenPrice,
+	"high":   gotrade.UseHighPrice,
+	"low":    gotrade.UseLowPrice,
+	"close":  gotrade.UseClosePrice,
+	"volume": gotrade.UseVolume,
+}
+
+// RegisteredIndicators returns the descriptions of all registered indicators, ordered by name
+func RegisteredIndicators() []IndicatorInfo {
+	infos := make([]IndicatorInfo, len(registry))
+	copy(infos, registry)
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// LookupIndicator returns the description of the registered indicator, the name is not case sensitive
+func LookupIndicator(name string) (info IndicatorInfo, ok bool) {
+	for _, info := range registry {
+		if strings.EqualFold(info.Name, name) {
+			return info, true
+		}
+	}
+	return IndicatorInfo{}, false
+}
+
+// LookbackPeriod returns the lookback period of the indicator constructed with the parameters, without
+// constructing it
+func (info IndicatorInfo) LookbackPeriod(params map[string]interface{}) (lookback int, err error) {
+	resolved, err := info.resolve(params)
+	if err != nil {
+		return 0, err
+	}
+	return info.lookback(resolved), nil
+}
+
+// NewIndicatorFromParams creates the registered indicator for online usage, parameters which are not
+// specified take their default values, e.g. NewIndicatorFromParams("macd", map[string]interface{}{"fast": 12})
+func NewIndicatorFromParams(name string, params map[string]interface{}) (indicator RegisteredIndicator, err error) {
+	info, ok := LookupIndicator(name)
+	if !ok {
+		return nil, errors.New(name + " " + ErrStrNotRegistered)
+	}
+
+	resolved, err := info.resolve(params)
+	if err != nil {
+		return nil, err
+	}
+
+	indicator, err = info.create(resolved)
+	if err != nil {
+		return nil, err
+	}
+	return indicator, nil
+}
+
+// NewIndicatorFromParamsForStream creates the registered indicator for online usage with a source data stream
+func NewIndicatorFromParamsForStream(priceStream gotrade.DOHLCVStreamSubscriber, name string, params map[string]interface{}) (indicator RegisteredIndicator, err error) {
+	indicator, err = NewIndicatorFromParams(name, params)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(indicator)
+	return indicator, nil
+}
+
+// NewIndicatorFromJSON creates the registered indicator for online usage from a JSON object holding its name and
+// parameters, e.g. {"name":"macd","fast":12,"slow":26,"signal":9}
+func NewIndicatorFromJSON(data []byte) (indicator RegisteredIndicator, err error) {
+	var params map[string]interface{}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, err
+	}
+
+	name, ok := params["name"].(string)
+	if !ok || name == "" {
+		return nil, ErrIndicatorNameIsMissing
+	}
+	delete(params, "name")
+
+	return NewIndicatorFromParams(name, params)
+}
+
+// NewIndicatorFromJSONForStream creates the registered indicator for online usage with a source data stream from
+// a JSON object holding its name and parameters
+func NewIndicatorFromJSONForStream(priceStream gotrade.DOHLCVStreamSubscriber, data []byte) (indicator RegisteredIndicator, err error) {
+	indicator, err = NewIndicatorFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(indicator)
+	return indicator, nil
+}
+
+// resolve validates the parameters against the indicator's parameter descriptions, filling in the defaults
+func (info IndicatorInfo) resolve(params map[string]interface{}) (resolved resolvedParams, err error) {
+	for name := range params {
+		if _, ok := info.parameter(name); !ok {
+			return resolved, errors.New(name + " " + ErrStrNotAParameter + " " + info.Name)
+		}
+	}
+
+	resolved.values = make(map[string]float64, len(info.Parameters))
+	for _, parameter := range info.Parameters {
+		value, specified := params[parameter.Name]
+
+		if parameter.Kind == SourceParameter {
+			source := DefaultSource
+			if specified {
+				name, ok := value.(string)
+				if !ok {
+					return resolved, errors.New(parameter.Name + " " + ErrStrNotASource)
+				}
+				source = strings.ToLower(name)
+			}
+			selectData, ok := sources[source]
+			if !ok {
+				return resolved, errors.New(parameter.Name + " " + ErrStrNotASource)
+			}
+			resolved.selectData = selectData
+			continue
+		}
+
+		number := parameter.Default
+		if specified {
+			number, err = parameter.number(value)
+			if err != nil {
+				return resolved, err
+			}
+		}
+		resolved.values[parameter.Name] = number
+	}
+
+	return resolved, nil
+}
+
+func (info IndicatorInfo) parameter(name string) (parameter IndicatorParameter, ok bool) {
+	for _, parameter := range info.Parameters {
+		if parameter.Name == name {
+			return parameter, true
+		}
+	}
+	return IndicatorParameter{}, false
+}
+
+// number converts a value of a parameter map, e.g. a float64 decoded from JSON, checking it is within the bounds
+func (parameter IndicatorParameter) number(value interface{}) (number float64, err error) {
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case float32:
+		number = float64(v)
+	case int:
+		number = float64(v)
+	case int32:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	case json.Number:
+		if number, err = v.Float64(); err != nil {
+			return 0, errors.New(parameter.Name + " " + ErrStrNotANumber)
+		}
+	default:
+		return 0, errors.New(parameter.Name + " " + ErrStrNotANumber)
+	}
+
+	if math.IsNaN(number) {
+		return 0, errors.New(parameter.Name + " " + ErrStrNotANumber)
+	}
+	if parameter.Kind == IntParameter && number != math.Trunc(number) {
+		return 0, errors.New(parameter.Name + " " + ErrStrNotAnInteger)
+	}
+	if number < parameter.Min || (parameter.MinExclusive && number == parameter.Min) {
+		return 0, errors.New(parameter.Name + " " + ErrStrBelowMinimum + " (" + parameter.format(parameter.Min) + ")")
+	}
+	if number > parameter.Max {
+		return 0, errors.New(parameter.Name + " " + ErrStrAboveMaximum + " (" + parameter.format(parameter.Max) + ")")
+	}
+
+	return number, nil
+}
+
+func (parameter IndicatorParameter) format(value float64) string {
+	if parameter.Kind == IntParameter {
+		return strconv.Itoa(int(value))
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// the largest float accepted by the constructors which reject math.MaxFloat64
+var maximumFloatParameter = math.Nextafter(math.MaxFloat64, 0)
+
+func intParameter(name string, defaultValue int, minimum int) IndicatorParameter {
+	return IndicatorParameter{Name: name, Kind: IntParameter, Default: float64(defaultValue), Min: float64(minimum), Max: float64(MaximumLookbackPeriod)}
+}
+
+func periodParameter(defaultValue int, minimum int) IndicatorParameter {
+	return intParameter("period", defaultValue, minimum)
+}
+
+var sourceParameter = IndicatorParameter{Name: "source", Kind: SourceParameter}
+
+func zeroLookback(params resolvedParams) int {
+	return 0
+}
+
+func periodLookback(params resolvedParams) int {
+	return params.int("period")
+}
+
+func periodLessOneLookback(params resolvedParams) int {
+	return params.int("period") - 1
+}
+
+var registry = []IndicatorInfo{
+	{
+		Name: "adl", Description: "Accumulation Distribution Line Indicator (Adl)",
+		Outputs: []string{"Data"}, Lookback: "0", lookback: zeroLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAdl()
+			return ind, err
+		},
+	},
+	{
+		Name: "adx", Description: "Average Directional Index (Adx)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2)},
+		Outputs:    []string{"Data"}, Lookback: "2 * period - 1",
+		lookback: func(p resolvedParams) int { return 2*p.int("period") - 1 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAdx(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "adxr", Description: "Average Directional Index Rating (Adxr)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2)},
+		Outputs:    []string{"Data"}, Lookback: "3 * period - 2",
+		lookback: func(p resolvedParams) int { return 3*p.int("period") - 2 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAdxr(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "aroon", Description: "Aroon (Aroon)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2)},
+		Outputs:    []string{"Up", "Down"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAroon(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "aroonosc", Description: "Aroon Oscillator (AroonOsc)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2)},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAroonOsc(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "atr", Description: "Average True Range (Atr)",
+		Parameters: []IndicatorParameter{periodParameter(14, 1)},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAtr(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "atrzigzag", Description: "ZigZag Indicator (ZigZag) with an average true range threshold",
+		Parameters: []IndicatorParameter{
+			intParameter("atrPeriod", 14, 1),
+			{Name: "atrMultiple", Kind: FloatParameter, Default: 3.0, Min: 0.0, Max: math.MaxFloat64, MinExclusive: true},
+		},
+		Lookback: "atrPeriod",
+		lookback: func(p resolvedParams) int { return p.int("atrPeriod") },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAtrZigZag(p.int("atrPeriod"), p.float("atrMultiple"))
+			return ind, err
+		},
+	},
+	{
+		Name: "avgprice", Description: "Average Price (AvgPrice)",
+		Outputs: []string{"Data"}, Lookback: "0", lookback: zeroLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewAvgPrice()
+			return ind, err
+		},
+	},
+	{
+		Name: "bollingerbands", Description: "Bollinger Band Indicator (BollingerBand)",
+		Parameters: []IndicatorParameter{periodParameter(5, 2), sourceParameter},
+		Outputs:    []string{"UpperBand", "MiddleBand", "LowerBand"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewBollingerBands(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "cci", Description: "Commodity Channel Index Indicator (Cci)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2)},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewCci(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "chaikinosc", Description: "Chaikin Oscillator (ChaikinOsc)",
+		Parameters: []IndicatorParameter{intParameter("fast", 3, 2), intParameter("slow", 10, 2)},
+		Outputs:    []string{"Data"}, Lookback: "slow - 1",
+		lookback: func(p resolvedParams) int { return p.int("slow") - 1 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewChaikinOsc(p.int("fast"), p.int("slow"))
+			return ind, err
+		},
+	},
+	{
+		Name: "dema", Description: "Double Exponential Moving Average (Dema)",
+		Parameters: []IndicatorParameter{periodParameter(30, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "2 * (period - 1)",
+		lookback: func(p resolvedParams) int { return 2 * (p.int("period") - 1) },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewDema(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "dx", Description: "Directional Movement Index Indicator (Dx)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2)},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewDx(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "ema", Description: "Exponential Moving Average Indicator (Ema)",
+		Parameters: []IndicatorParameter{periodParameter(25, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewEma(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "hhv", Description: "Highest High Value Indicator (Hhv)",
+		Parameters: []IndicatorParameter{periodParameter(25, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewHhv(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "hhvbars", Description: "Highest High Value Bars Indicator (HhvBars)",
+		Parameters: []IndicatorParameter{periodParameter(25, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewHhvBars(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "kama", Description: "Kaufman Adaptive Moving Average Indicator (Kama)",
+		Parameters: []IndicatorParameter{periodParameter(25, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewKama(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "linreg", Description: "Linear Regression Indicator (LinReg)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewLinReg(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "linregang", Description: "Linear Regression Angle Indicator (LinRegAng)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewLinRegAng(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "linregint", Description: "Linear Regression Intercept Indicator (LinRegInt)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewLinRegInt(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "linregslp", Description: "Linear Regression Slope Indicator (LinRegSlp)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewLinRegSlp(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "llv", Description: "Lowest Low Value Indicator (Llv)",
+		Parameters: []IndicatorParameter{periodParameter(25, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewLlv(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "llvbars", Description: "Lowest Low Value Bars Indicator (LlvBars)",
+		Parameters: []IndicatorParameter{periodParameter(25, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewLlvBars(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "macd", Description: "Moving Average Convergence Divergence Indicator (Macd)",
+		Parameters: []IndicatorParameter{intParameter("fast", 12, 2), intParameter("slow", 26, 2), intParameter("signal", 9, 2), sourceParameter},
+		Outputs:    []string{"Macd", "Signal", "Histogram"}, Lookback: "slow + signal - 2",
+		lookback: func(p resolvedParams) int { return p.int("slow") + p.int("signal") - 2 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewMacd(p.int("fast"), p.int("slow"), p.int("signal"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "medprice", Description: "Median Price (MedPrice)",
+		Outputs: []string{"Data"}, Lookback: "0", lookback: zeroLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewMedPrice()
+			return ind, err
+		},
+	},
+	{
+		Name: "mfi", Description: "Money Flow Index Indicator (Mfi)",
+		Parameters: []IndicatorParameter{periodParameter(25, 2)},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewMfi(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "minusdi", Description: "Minus Directional Indicator (MinusDi)",
+		Parameters: []IndicatorParameter{periodParameter(14, 1)},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewMinusDi(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "minusdm", Description: "Minus Directional Movement Indicator (MinusDm)",
+		Parameters: []IndicatorParameter{periodParameter(14, 1)},
+		Outputs:    []string{"Data"}, Lookback: "max(period - 1, 1)",
+		lookback: directionalMovementLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewMinusDm(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "mom", Description: "Momentum (Mom)",
+		Parameters: []IndicatorParameter{periodParameter(10, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewMom(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "obv", Description: "On Balance Volume Indicator (Obv)",
+		Outputs: []string{"Data"}, Lookback: "0", lookback: zeroLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewObv()
+			return ind, err
+		},
+	},
+	{
+		Name: "plusdi", Description: "Plus Directional Indicator (PlusDi)",
+		Parameters: []IndicatorParameter{periodParameter(14, 1)},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewPlusDi(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "plusdm", Description: "Plus Directional Movement Indicator (PlusDm)",
+		Parameters: []IndicatorParameter{periodParameter(14, 1)},
+		Outputs:    []string{"Data"}, Lookback: "max(period - 1, 1)",
+		lookback: directionalMovementLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewPlusDm(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "roc", Description: "Rate of Change Indicator (Roc)",
+		Parameters: []IndicatorParameter{periodParameter(10, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewRoc(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "rocp", Description: "Rate of Change Percentage Indicator (RocP)",
+		Parameters: []IndicatorParameter{periodParameter(10, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewRocP(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "rocr", Description: "Rate of Change Ratio Indicator (RocR)",
+		Parameters: []IndicatorParameter{periodParameter(10, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewRocR(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "rocr100", Description: "Rate of Change Ratio 100 Scale Indicator (RocR100)",
+		Parameters: []IndicatorParameter{periodParameter(10, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewRocR100(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "rsi", Description: "Relative Strength Indicator (Rsi)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period", lookback: periodLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewRsi(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "sar", Description: "Stop and Reverse Indicator (Sar)",
+		Parameters: []IndicatorParameter{
+			{Name: "acceleration", Kind: FloatParameter, Default: 0.02, Min: 0.0, Max: maximumFloatParameter},
+			{Name: "accelerationMax", Kind: FloatParameter, Default: 0.2, Min: 0.0, Max: maximumFloatParameter},
+		},
+		Outputs: []string{"Data"}, Lookback: "1",
+		lookback: func(p resolvedParams) int { return 1 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewSar(p.float("acceleration"), p.float("accelerationMax"))
+			return ind, err
+		},
+	},
+	{
+		Name: "sma", Description: "Simple Moving Average Indicator (Sma)",
+		Parameters: []IndicatorParameter{periodParameter(10, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewSma(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "stddev", Description: "Standard Deviation Indicator (StdDev)",
+		Parameters: []IndicatorParameter{periodParameter(10, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewStdDev(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "stochosc", Description: "Stochastic Oscillator Indicator (StochOsc)",
+		Parameters: []IndicatorParameter{intParameter("fastK", 5, 1), intParameter("slowK", 3, 2), intParameter("slowD", 3, 2)},
+		Outputs:    []string{"SlowK", "SlowD"}, Lookback: "fastK + slowK + slowD - 3",
+		lookback: func(p resolvedParams) int { return p.int("fastK") + p.int("slowK") + p.int("slowD") - 3 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewStochOsc(p.int("fastK"), p.int("slowK"), p.int("slowD"))
+			return ind, err
+		},
+	},
+	{
+		Name: "stochrsi", Description: "Stochastic Relative Strength Indicator (StochRsi)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2), intParameter("fastK", 5, 1), intParameter("fastD", 3, 2)},
+		Outputs:    []string{"SlowK", "SlowD"}, Lookback: "period + fastK + fastD - 2",
+		lookback: func(p resolvedParams) int { return p.int("period") + p.int("fastK") + p.int("fastD") - 2 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewStochRsi(p.int("period"), p.int("fastK"), p.int("fastD"))
+			return ind, err
+		},
+	},
+	{
+		Name: "tema", Description: "Tripple Exponential Moving Average Indicator (Tema)",
+		Parameters: []IndicatorParameter{periodParameter(30, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "3 * (period - 1)",
+		lookback: func(p resolvedParams) int { return 3 * (p.int("period") - 1) },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewTema(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "trima", Description: "Triangular Moving Average Indicator (Trima)",
+		Parameters: []IndicatorParameter{periodParameter(30, 3), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewTrima(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "truerange", Description: "True Range Indicator (TrueRange)",
+		Outputs: []string{"Data"}, Lookback: "1",
+		lookback: func(p resolvedParams) int { return 1 },
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewTrueRange()
+			return ind, err
+		},
+	},
+	{
+		Name: "tsf", Description: "Time Series Forecast Indicator (Tsf)",
+		Parameters: []IndicatorParameter{periodParameter(10, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewTsf(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "typprice", Description: "Typical Price Indicator (TypPrice)",
+		Outputs: []string{"Data"}, Lookback: "0", lookback: zeroLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewTypPrice()
+			return ind, err
+		},
+	},
+	{
+		Name: "var", Description: "Variance Indicator (Var)",
+		Parameters: []IndicatorParameter{periodParameter(10, 1), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewVar(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "willr", Description: "Williams Percent R Indicator (WillR)",
+		Parameters: []IndicatorParameter{periodParameter(14, 2)},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewWillR(p.int("period"))
+			return ind, err
+		},
+	},
+	{
+		Name: "wma", Description: "Weighted Moving Average Indicator (Wma)",
+		Parameters: []IndicatorParameter{periodParameter(10, 2), sourceParameter},
+		Outputs:    []string{"Data"}, Lookback: "period - 1", lookback: periodLessOneLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewWma(p.int("period"), p.selectData)
+			return ind, err
+		},
+	},
+	{
+		Name: "zigzag", Description: "ZigZag Indicator (ZigZag)",
+		Parameters: []IndicatorParameter{
+			{Name: "percentage", Kind: FloatParameter, Default: 5.0, Min: 0.0, Max: math.MaxFloat64, MinExclusive: true},
+		},
+		Lookback: "0", lookback: zeroLookback,
+		create: func(p resolvedParams) (RegisteredIndicator, error) {
+			ind, err := NewZigZag(p.float("percentage"))
+			return ind, err
+		},
+	},
+}
+
+func directionalMovementLookback(p resolvedParams) int {
+	if p.int("period") > 1 {
+		return p.int("period") - 1
+	}
+	return 1
+}
@@ -0,0 +1,279 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"strings"
+)
+
+// registryOutputsCollector records the results published by the outputs of an indicator
+type registryOutputsCollector struct {
+	results [][]float64
+}
+
+type registryOutputCollector struct {
+	collector *registryOutputsCollector
+	output    int
+}
+
+func (c *registryOutputCollector) ReceiveTick(tickData float64, streamBarIndex int) {
+	c.collector.results[c.output] = append(c.collector.results[c.output], tickData)
+}
+
+func collectRegistryOutputs(indicator interface{}) *registryOutputsCollector {
+	collector := &registryOutputsCollector{}
+	if withOutputs, ok := indicator.(interface {
+		Outputs() []*indicators.IndicatorOutput
+	}); ok {
+		for i, output := range withOutputs.Outputs() {
+			collector.results = append(collector.results, nil)
+			output.AddTickSubscription(&registryOutputCollector{collector: collector, output: i})
+		}
+	}
+	return collector
+}
+
+var _ = Describe("when listing the registered indicators", func() {
+	var (
+		infos []indicators.IndicatorInfo
+	)
+
+	BeforeEach(func() {
+		infos = indicators.RegisteredIndicators()
+	})
+
+	It("should register every indicator", func() {
+		for _, benchmark := range benchmarkIndicators {
+			_, ok := indicators.LookupIndicator(benchmark.name)
+			Expect(ok).To(BeTrue(), benchmark.name)
+		}
+		_, ok := indicators.LookupIndicator("AtrZigZag")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should order the indicators by name", func() {
+		for i := 1; i < len(infos); i++ {
+			Expect(infos[i-1].Name < infos[i].Name).To(BeTrue())
+		}
+	})
+
+	It("should describe the outputs and lookback period of each indicator", func() {
+		for _, info := range infos {
+			indicator, _ := indicators.NewIndicatorFromParams(info.Name, nil)
+			_, withOutputs := indicator.(interface {
+				Outputs() []*indicators.IndicatorOutput
+			})
+			Expect(len(info.Outputs) > 0).To(Equal(withOutputs), info.Name)
+			Expect(info.Lookback).NotTo(BeEmpty(), info.Name)
+		}
+	})
+
+	It("should name the outputs after the result fields of the indicator", func() {
+		info, _ := indicators.LookupIndicator("macd")
+		indicator, _ := indicators.NewDefaultMacd()
+		for i, output := range indicator.Outputs() {
+			Expect(output.Name()).To(Equal(info.Outputs[i]))
+		}
+	})
+
+	It("should describe the parameters with the bounds validated by the constructors", func() {
+		info, _ := indicators.LookupIndicator("macd")
+		Expect(info.Parameters).To(HaveLen(4))
+		Expect(info.Parameters[0]).To(Equal(indicators.IndicatorParameter{Name: "fast", Kind: indicators.IntParameter,
+			Default: 12, Min: 2, Max: float64(indicators.MaximumLookbackPeriod)}))
+		Expect(info.Parameters[3].Name).To(Equal("source"))
+		Expect(info.Parameters[3].Kind.String()).To(Equal("source"))
+	})
+
+	It("should calculate the lookback period of each indicator with its default parameters", func() {
+		for _, info := range infos {
+			indicator, err := indicators.NewIndicatorFromParams(info.Name, nil)
+			Expect(err).To(BeNil(), info.Name)
+			lookback, err := info.LookbackPeriod(nil)
+			Expect(err).To(BeNil(), info.Name)
+			Expect(lookback).To(Equal(indicator.GetLookbackPeriod()), info.Name)
+		}
+	})
+
+	It("should calculate the lookback period of each indicator with its minimum parameters", func() {
+		for _, info := range infos {
+			params := map[string]interface{}{}
+			for _, parameter := range info.Parameters {
+				if parameter.Kind == indicators.IntParameter {
+					params[parameter.Name] = int(parameter.Min)
+				}
+			}
+			indicator, err := indicators.NewIndicatorFromParams(info.Name, params)
+			Expect(err).To(BeNil(), info.Name)
+			lookback, _ := info.LookbackPeriod(params)
+			Expect(lookback).To(Equal(indicator.GetLookbackPeriod()), info.Name)
+		}
+	})
+})
+
+var _ = Describe("when creating each registered indicator with its default parameters", func() {
+	var (
+		priceData []gotrade.DOHLCV
+	)
+
+	BeforeEach(func() {
+		priceStream := gotrade.NewDailyDOHLCVStream()
+		csvFeed.FillDOHLCVStream(priceStream)
+		priceData = priceStream.Data
+	})
+
+	It("should calculate the same results as the indicator's default constructor", func() {
+		for _, benchmark := range benchmarkIndicators {
+			expected, _ := benchmark.newIndicator()
+			actual, err := indicators.NewIndicatorFromParams(strings.ToLower(benchmark.name), map[string]interface{}{})
+			Expect(err).To(BeNil(), benchmark.name)
+
+			expectedResults := collectRegistryOutputs(expected)
+			actualResults := collectRegistryOutputs(actual)
+			for i := range priceData {
+				expected.ReceiveDOHLCVTick(priceData[i], i+1)
+				actual.ReceiveDOHLCVTick(priceData[i], i+1)
+			}
+
+			Expect(actualResults.results).To(Equal(expectedResults.results), benchmark.name)
+			Expect(actual.Length()).To(Equal(expected.(indicators.Indicator).Length()), benchmark.name)
+		}
+	})
+
+	It("should store the same pivots as the ZigZag's constructors", func() {
+		expectedZigZag, _ := indicators.NewZigZag(5.0)
+		expectedAtrZigZag, _ := indicators.NewAtrZigZag(14, 3.0)
+		actualZigZag, err := indicators.NewIndicatorFromParams("zigzag", nil)
+		Expect(err).To(BeNil())
+		actualAtrZigZag, err := indicators.NewIndicatorFromParams("atrzigzag", nil)
+		Expect(err).To(BeNil())
+
+		for i := range priceData {
+			expectedZigZag.ReceiveDOHLCVTick(priceData[i], i+1)
+			expectedAtrZigZag.ReceiveDOHLCVTick(priceData[i], i+1)
+			actualZigZag.ReceiveDOHLCVTick(priceData[i], i+1)
+			actualAtrZigZag.ReceiveDOHLCVTick(priceData[i], i+1)
+		}
+
+		Expect(len(expectedZigZag.Data)).To(BeNumerically(">", 5))
+		Expect(len(expectedAtrZigZag.Data)).To(BeNumerically(">", 5))
+		Expect(actualZigZag.(*indicators.ZigZag).Data).To(Equal(expectedZigZag.Data))
+		Expect(actualAtrZigZag.(*indicators.ZigZag).Data).To(Equal(expectedAtrZigZag.Data))
+	})
+})
+
+var _ = Describe("when creating a registered indicator from a parameter map", func() {
+	It("should use the specified parameters", func() {
+		indicator, err := indicators.NewIndicatorFromParams("sma", map[string]interface{}{"period": 4, "source": "high"})
+		Expect(err).To(BeNil())
+		expected, _ := indicators.NewSma(4, gotrade.UseHighPrice)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+		Expect(indicator.(*indicators.Sma).Data).To(Equal(expected.Data))
+	})
+
+	It("should not be case sensitive to the indicator name", func() {
+		indicator, err := indicators.NewIndicatorFromParams("StochOsc", map[string]interface{}{"fastK": 7})
+		Expect(err).To(BeNil())
+		Expect(indicator.GetLookbackPeriod()).To(Equal(10))
+	})
+
+	It("should accept float parameters", func() {
+		indicator, err := indicators.NewIndicatorFromParams("sar", map[string]interface{}{"acceleration": 0.03})
+		Expect(err).To(BeNil())
+		Expect(indicator).To(BeAssignableToTypeOf(&indicators.Sar{}))
+	})
+
+	It("should attach the indicator to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err := indicators.NewIndicatorFromParamsForStream(stream, "rsi", map[string]interface{}{"period": 7})
+		Expect(err).To(BeNil())
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+
+	It("should not attach an invalid indicator to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err := indicators.NewIndicatorFromParamsForStream(stream, "rsi", map[string]interface{}{"period": 1})
+		Expect(err).To(HaveOccurred())
+		Expect(indicator).To(BeNil())
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(BeNil())
+	})
+
+	It("should reject an unregistered indicator", func() {
+		_, err := indicators.NewIndicatorFromParams("foo", nil)
+		Expect(err).To(MatchError("foo " + indicators.ErrStrNotRegistered))
+	})
+
+	It("should reject an unknown parameter", func() {
+		_, err := indicators.NewIndicatorFromParams("sma", map[string]interface{}{"periods": 4})
+		Expect(err).To(MatchError("periods " + indicators.ErrStrNotAParameter + " sma"))
+	})
+
+	It("should reject a parameter below its minimum", func() {
+		_, err := indicators.NewIndicatorFromParams("sma", map[string]interface{}{"period": 1})
+		Expect(err).To(MatchError("period is less than the minimum (2)"))
+	})
+
+	It("should reject a parameter above its maximum", func() {
+		_, err := indicators.NewIndicatorFromParams("macd", map[string]interface{}{"slow": 100001})
+		Expect(err).To(MatchError("slow is greater than the maximum (100000)"))
+	})
+
+	It("should reject a parameter equal to an exclusive minimum", func() {
+		_, err := indicators.NewIndicatorFromParams("zigzag", map[string]interface{}{"percentage": 0.0})
+		Expect(err).To(MatchError("percentage is less than the minimum (0)"))
+	})
+
+	It("should reject a fractional int parameter", func() {
+		_, err := indicators.NewIndicatorFromParams("sma", map[string]interface{}{"period": 4.5})
+		Expect(err).To(MatchError("period " + indicators.ErrStrNotAnInteger))
+	})
+
+	It("should reject a parameter which is not a number", func() {
+		_, err := indicators.NewIndicatorFromParams("sma", map[string]interface{}{"period": "4"})
+		Expect(err).To(MatchError("period " + indicators.ErrStrNotANumber))
+	})
+
+	It("should reject an unknown source", func() {
+		_, err := indicators.NewIndicatorFromParams("sma", map[string]interface{}{"source": "vwap"})
+		Expect(err).To(MatchError("source " + indicators.ErrStrNotASource))
+	})
+})
+
+var _ = Describe("when creating a registered indicator from JSON", func() {
+	It("should create the named indicator with the parameters", func() {
+		indicator, err := indicators.NewIndicatorFromJSON([]byte(`{"name":"macd","fast":10,"slow":20,"signal":5}`))
+		Expect(err).To(BeNil())
+		expected, _ := indicators.NewMacd(10, 20, 5, gotrade.UseClosePrice)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+		macd := indicator.(*indicators.Macd)
+		Expect(macd.GetLookbackPeriod()).To(Equal(23))
+		Expect(macd.Macd).To(Equal(expected.Macd))
+		Expect(macd.Signal).To(Equal(expected.Signal))
+		Expect(macd.Histogram).To(Equal(expected.Histogram))
+	})
+
+	It("should attach the indicator to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err := indicators.NewIndicatorFromJSONForStream(stream, []byte(`{"name":"bollingerbands","source":"low"}`))
+		Expect(err).To(BeNil())
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+
+	It("should require the indicator name", func() {
+		_, err := indicators.NewIndicatorFromJSON([]byte(`{"period":10}`))
+		Expect(err).To(Equal(indicators.ErrIndicatorNameIsMissing))
+	})
+
+	It("should reject invalid JSON", func() {
+		_, err := indicators.NewIndicatorFromJSON([]byte(`{"name":"sma",`))
+		Expect(err).To(HaveOccurred())
+	})
+})
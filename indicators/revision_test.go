@@ -0,0 +1,168 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"reflect"
+)
+
+// attachRevisionIndicators attaches one of each indicator with default parameters to the stream
+func attachRevisionIndicators(stream *gotrade.InterDayDOHLCVStream) map[string]interface{} {
+	attached := make(map[string]interface{})
+	attached["adl"], _ = indicators.NewAdlForStream(stream)
+	attached["adx"], _ = indicators.NewDefaultAdxForStream(stream)
+	attached["adxr"], _ = indicators.NewDefaultAdxrForStream(stream)
+	attached["aroon"], _ = indicators.NewDefaultAroonForStream(stream)
+	attached["aroonosc"], _ = indicators.NewDefaultAroonOscForStream(stream)
+	attached["atr"], _ = indicators.NewDefaultAtrForStream(stream)
+	attached["avgprice"], _ = indicators.NewAvgPriceForStream(stream)
+	attached["bollinger"], _ = indicators.NewDefaultBollingerBandsForStream(stream)
+	attached["cci"], _ = indicators.NewDefaultCciForStream(stream)
+	attached["chaikinosc"], _ = indicators.NewDefaultChaikinOscForStream(stream)
+	attached["dema"], _ = indicators.NewDefaultDemaForStream(stream)
+	attached["dx"], _ = indicators.NewDefaultDxForStream(stream)
+	attached["ema"], _ = indicators.NewDefaultEmaForStream(stream)
+	attached["hhv"], _ = indicators.NewDefaultHhvForStream(stream)
+	attached["hhvbars"], _ = indicators.NewDefaultHhvBarsForStream(stream)
+	attached["kama"], _ = indicators.NewDefaultKamaForStream(stream)
+	attached["linreg"], _ = indicators.NewDefaultLinRegForStream(stream)
+	attached["linregang"], _ = indicators.NewDefaultLinRegAngForStream(stream)
+	attached["linregint"], _ = indicators.NewDefaultLinRegIntForStream(stream)
+	attached["linregslp"], _ = indicators.NewDefaultLinRegSlpForStream(stream)
+	attached["llv"], _ = indicators.NewDefaultLlvForStream(stream)
+	attached["llvbars"], _ = indicators.NewDefaultLlvBarsForStream(stream)
+	attached["macd"], _ = indicators.NewDefaultMacdForStream(stream)
+	attached["medprice"], _ = indicators.NewMedPriceForStream(stream)
+	attached["mfi"], _ = indicators.NewDefaultMfiForStream(stream)
+	attached["minusdi"], _ = indicators.NewDefaultMinusDiForStream(stream)
+	attached["minusdm"], _ = indicators.NewDefaultMinusDmForStream(stream)
+	attached["mom"], _ = indicators.NewDefaultMomForStream(stream)
+	attached["obv"], _ = indicators.NewObvForStream(stream)
+	attached["plusdi"], _ = indicators.NewDefaultPlusDiForStream(stream)
+	attached["plusdm"], _ = indicators.NewDefaultPlusDmForStream(stream)
+	attached["roc"], _ = indicators.NewDefaultRocForStream(stream)
+	attached["rocp"], _ = indicators.NewDefaultRocPForStream(stream)
+	attached["rocr"], _ = indicators.NewDefaultRocRForStream(stream)
+	attached["rocr100"], _ = indicators.NewDefaultRocR100ForStream(stream)
+	attached["rsi"], _ = indicators.NewDefaultRsiForStream(stream)
+	attached["sar"], _ = indicators.NewDefaultSarForStream(stream)
+	attached["sma"], _ = indicators.NewDefaultSmaForStream(stream)
+	attached["stddev"], _ = indicators.NewDefaultStdDevForStream(stream)
+	attached["stochosc"], _ = indicators.NewDefaultStochOscForStream(stream)
+	attached["stochrsi"], _ = indicators.NewDefaultStochRsiForStream(stream)
+	attached["tema"], _ = indicators.NewDefaultTemaForStream(stream)
+	attached["trima"], _ = indicators.NewDefaultTrimaForStream(stream)
+	attached["truerange"], _ = indicators.NewTrueRangeForStream(stream)
+	attached["tsf"], _ = indicators.NewDefaultTsfForStream(stream)
+	attached["typprice"], _ = indicators.NewTypPriceForStream(stream)
+	attached["var"], _ = indicators.NewDefaultVarForStream(stream)
+	attached["willr"], _ = indicators.NewDefaultWillRForStream(stream)
+	attached["wma"], _ = indicators.NewDefaultWmaForStream(stream)
+	attached["zigzag"], _ = indicators.NewZigZagForStream(stream, 2.0)
+	attached["atrzigzag"], _ = indicators.NewAtrZigZagForStream(stream, 14, 2.0)
+	return attached
+}
+
+// indicatorResults returns the stored results and bounds of an indicator
+func indicatorResults(indicator interface{}) []interface{} {
+	var results []interface{}
+	value := reflect.ValueOf(indicator).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).PkgPath == "" && value.Field(i).Kind() == reflect.Slice {
+			results = append(results, value.Field(i).Interface())
+		}
+	}
+
+	if bounded, ok := indicator.(indicators.IndicatorWithFloatBounds); ok {
+		results = append(results, bounded.MinValue(), bounded.MaxValue())
+	}
+	if bounded, ok := indicator.(indicators.IndicatorWithIntBounds); ok {
+		results = append(results, bounded.MinValue(), bounded.MaxValue())
+	}
+	return append(results, indicator.(indicators.Indicator).Length(), indicator.(indicators.Indicator).ValidFromBar())
+}
+
+func reviseBar(bar gotrade.DOHLCV, priceChange float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(bar.D(), bar.O(), bar.H()+priceChange, bar.L()-priceChange, bar.C()+priceChange, bar.V()*2.0)
+}
+
+var _ = Describe("when revising the latest bar of a stream with indicators attached", func() {
+	var (
+		revisedStream     *gotrade.InterDayDOHLCVStream
+		finalStream       *gotrade.InterDayDOHLCVStream
+		revisedIndicators map[string]interface{}
+		finalIndicators   map[string]interface{}
+		withoutStorage    *indicators.SmaWithoutStorage
+	)
+
+	BeforeEach(func() {
+		source := gotrade.NewDailyDOHLCVStream()
+		csvFeed.FillDOHLCVStream(source)
+
+		revisedStream = gotrade.NewDailyDOHLCVStream()
+		revisedStream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		finalStream = gotrade.NewDailyDOHLCVStream()
+		finalStream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		revisedIndicators = attachRevisionIndicators(revisedStream)
+		finalIndicators = attachRevisionIndicators(finalStream)
+
+		// each bar forms over a few revisions before reaching its final value
+		for _, bar := range source.Data {
+			revisedStream.ReceiveTick(reviseBar(bar, 50.0))
+			revisedStream.ReceiveTickUpdate(reviseBar(bar, -25.0))
+			revisedStream.ReceiveTickUpdate(bar)
+			finalStream.ReceiveTick(bar)
+		}
+		withoutStorage, _ = indicators.NewSmaWithoutStorage(3, fakeFloatValAvailable)
+	})
+
+	It("the stream should hold the final value of each bar", func() {
+		Expect(revisedStream.Data).To(Equal(finalStream.Data))
+		Expect(revisedStream.MinValue()).To(Equal(finalStream.MinValue()))
+		Expect(revisedStream.MaxValue()).To(Equal(finalStream.MaxValue()))
+	})
+
+	It("every indicator should have the same results as if it had only received the final value of each bar", func() {
+		Expect(len(revisedIndicators)).To(Equal(51))
+		Expect(len(finalIndicators["macd"].(*indicators.Macd).Macd)).To(BeNumerically(">", 100))
+		Expect(len(finalIndicators["atrzigzag"].(*indicators.ZigZag).Data)).To(BeNumerically(">", 10))
+		for name := range finalIndicators {
+			Expect(indicatorResults(revisedIndicators[name])).To(Equal(indicatorResults(finalIndicators[name])), name)
+		}
+	})
+
+	It("an indicator should ignore a revision of a bar before its latest bar", func() {
+		withoutStorage.ReceiveTick(10.0, 1)
+		withoutStorage.ReceiveTick(11.0, 2)
+		withoutStorage.ReceiveTick(12.0, 3)
+		withoutStorage.ReceiveTickUpdate(100.0, 2)
+		withoutStorage.ReceiveTick(13.0, 4)
+		Expect(withoutStorage.Length()).To(Equal(2))
+		Expect(withoutStorage.MaxValue()).To(Equal(12.0))
+	})
+})
+
+var _ = Describe("when revising the latest bar of an indicator with capped history", func() {
+	var (
+		indicator *indicators.Sma
+		uncapped  *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSma(3, gotrade.UseClosePrice)
+		indicator.SetMaxHistory(4)
+		uncapped, _ = indicators.NewSma(3, gotrade.UseClosePrice)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(reviseBar(sourceDOHLCVData[i], 5.0), i+1)
+			indicator.ReceiveDOHLCVTickUpdate(sourceDOHLCVData[i], i+1)
+			uncapped.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("the indicator should replace its latest stored result", func() {
+		Expect(indicator.Data).To(Equal(uncapped.Data[len(uncapped.Data)-4:]))
+		Expect(indicator.Length()).To(Equal(uncapped.Length()))
+	})
+})
@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -12,8 +11,11 @@ type RocWithoutStorage struct {
 
 	// private variables
 	periodCounter int
-	periodHistory *list.List
+	periodHistory *gotrade.FloatRingBuffer
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *RocWithoutStorage
 }
 
 // NewRocWithoutStorage creates a Rate of Change Indicator (Roc) without storage
@@ -38,7 +40,7 @@ func NewRocWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	ind := RocWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                (timePeriod * -1),
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:                   timePeriod,
 	}
 
@@ -66,9 +68,13 @@ func NewRoc(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 
 	ind.RocWithoutStorage, err = NewRocWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -137,15 +143,23 @@ func (ind *Roc) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Roc) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *RocWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
+	// the price timePeriod bars ago is discarded by the history once it is full
+	previousPrice, _ := ind.periodHistory.Push(tickData)
 
 	if ind.periodCounter > 0 {
 
 		//    Roc = (price/previousPrice - 1) * 100
-		previousPrice := ind.periodHistory.Front().Value.(float64)
-
 		var result float64
 		if previousPrice != 0 {
 			result = 100.0 * ((tickData / previousPrice) - 1)
@@ -155,9 +169,34 @@ func (ind *RocWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
+}
 
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *RocWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
 	}
 }
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *RocWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(RocWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *RocWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -12,8 +11,11 @@ type RocRWithoutStorage struct {
 
 	// private variables
 	periodCounter int
-	periodHistory *list.List
+	periodHistory *gotrade.FloatRingBuffer
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *RocRWithoutStorage
 }
 
 // NewRocRWithoutStorage creates a Rate of Change Ratio Indicator (RocR) without storage
@@ -38,7 +40,7 @@ func NewRocRWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAc
 	ind := RocRWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                (timePeriod * -1),
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:                   timePeriod,
 	}
 
@@ -66,9 +68,13 @@ func NewRocR(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indica
 
 	ind.RocRWithoutStorage, err = NewRocRWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -137,15 +143,23 @@ func (ind *RocR) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *RocR) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *RocRWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
+	// the price timePeriod bars ago is discarded by the history once it is full
+	previousPrice, _ := ind.periodHistory.Push(tickData)
 
 	if ind.periodCounter > 0 {
 
 		//    RocR = (price/previousPrice - 1) * 100
-		previousPrice := ind.periodHistory.Front().Value.(float64)
-
 		var result float64
 		if previousPrice != 0 {
 			result = (tickData / previousPrice)
@@ -155,9 +169,34 @@ func (ind *RocRWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
+}
 
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *RocRWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
 	}
 }
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *RocRWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(RocRWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *RocRWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
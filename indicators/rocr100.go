@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -13,8 +12,11 @@ type RocR100WithoutStorage struct {
 	// private variables
 	valueAvailableAction ValueAvailableActionFloat
 	periodCounter        int
-	periodHistory        *list.List
+	periodHistory        *gotrade.FloatRingBuffer
 	timePeriod           int
+
+	// the state prior to the latest source data bar
+	savedState *RocR100WithoutStorage
 }
 
 // NewRocR100WithoutStorage creates a Rate of Change Ratio 100 Scale Indicator (RocR100) without storage
@@ -39,7 +41,7 @@ func NewRocR100WithoutStorage(timePeriod int, valueAvailableAction ValueAvailabl
 	ind := RocR100WithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                (timePeriod * -1),
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:                   timePeriod,
 	}
 
@@ -67,9 +69,13 @@ func NewRocR100(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (ind
 
 	newRocR100.RocR100WithoutStorage, err = NewRocR100WithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			newRocR100.Data = append(newRocR100.Data, dataItem)
+			newRocR100.Data = newRocR100.storeFloat(0, newRocR100.Data, dataItem)
 		})
 
+	if err == nil {
+		newRocR100.nameOutputs("Data")
+	}
+
 	return &newRocR100, err
 }
 
@@ -138,15 +144,23 @@ func (ind *RocR100) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex in
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *RocR100) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *RocR100WithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
+	// the price timePeriod bars ago is discarded by the history once it is full
+	previousPrice, _ := ind.periodHistory.Push(tickData)
 
 	if ind.periodCounter > 0 {
 
 		//    RocR100 = (price/previousPrice - 1) * 100
-		previousPrice := ind.periodHistory.Front().Value.(float64)
-
 		var result float64
 		if previousPrice != 0 {
 			result = (tickData / previousPrice) * 100.0
@@ -156,9 +170,34 @@ func (ind *RocR100WithoutStorage) ReceiveTick(tickData float64, streamBarIndex i
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
+}
 
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *RocR100WithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
 	}
 }
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *RocR100WithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(RocR100WithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *RocR100WithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
@@ -22,6 +22,9 @@ type SarWithoutStorage struct {
 	previousLow           float64
 	minusDM               *MinusDmWithoutStorage
 	hasInitialDirection   bool
+
+	// the state prior to the latest source data bar
+	savedState *SarWithoutStorage
 }
 
 func NewSarWithoutStorage(accelerationFactor float64, accelerationFactorMax float64, valueAvailableAction ValueAvailableActionFloat) (indicator *SarWithoutStorage, err error) {
@@ -90,9 +93,13 @@ type Sar struct {
 func NewSar(accelerationFactor float64, accelerationFactorMax float64) (indicator *Sar, err error) {
 	ind := Sar{}
 	ind.SarWithoutStorage, err = NewSarWithoutStorage(accelerationFactor, accelerationFactorMax, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -159,6 +166,8 @@ func NewDefaultSarForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOH
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *SarWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 	if ind.hasInitialDirection == false {
 		ind.minusDM.ReceiveDOHLCVTick(tickData, streamBarIndex)
@@ -319,3 +328,33 @@ func (ind *SarWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamB
 	ind.previousHigh = tickData.H()
 	ind.previousLow = tickData.L()
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *SarWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *SarWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.minusDM.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(SarWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *SarWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.minusDM.restoreState()
+	*ind = *ind.savedState
+}
@@ -2,7 +2,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -12,10 +11,12 @@ type SmaWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodTotal   float64
-	periodHistory *list.List
-	periodCounter int
+	periodTotal   KahanSum
+	periodHistory *gotrade.FloatRingBuffer
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *SmaWithoutStorage
 }
 
 // NewSmaWithoutStorage creates a Simple Moving Average Indicator (Sma) without storage
@@ -39,8 +40,7 @@ func NewSmaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	lookback := timePeriod - 1
 	ind := SmaWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		periodCounter:                timePeriod * -1,
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:                   timePeriod,
 	}
 
@@ -68,9 +68,13 @@ func NewSma(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 	ind.SmaWithoutStorage, err = NewSmaWithoutStorage(
 		timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -139,22 +143,55 @@ func (ind *Sma) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Sma) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *SmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
+	ind.saveState(streamBarIndex)
 
-	if ind.periodCounter > 0 {
-		var valueToRemove = ind.periodHistory.Front()
-		ind.periodTotal -= valueToRemove.Value.(float64)
-	}
-	if ind.periodHistory.Len() > ind.timePeriod {
-		var first = ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+	// the value leaving the period is discarded by the history once it is full
+	if valueToRemove, discarded := ind.periodHistory.Push(tickData); discarded {
+		ind.periodTotal.Sub(valueToRemove)
 	}
-	ind.periodTotal += tickData
-	var result float64 = ind.periodTotal / float64(ind.timePeriod)
-	if ind.periodCounter >= 0 {
+	ind.periodTotal.Add(tickData)
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		var result float64 = ind.periodTotal.Value() / float64(ind.timePeriod)
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *SmaWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *SmaWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(SmaWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *SmaWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
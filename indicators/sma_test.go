@@ -405,3 +405,77 @@ var _ = Describe("when calculating a simple moving average (sma) with DOHLCV sou
 		})
 	})
 })
+
+var _ = Describe("when calculating a simple moving average (sma) with a capped history", func() {
+	var (
+		period     int = 3
+		maxHistory int = 4
+		indicator  *indicators.Sma
+		uncapped   *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		indicator.SetMaxHistory(maxHistory)
+		uncapped, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			uncapped.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("the indicator should only store the most recent results", func() {
+		Expect(indicator.Data).To(Equal(uncapped.Data[len(uncapped.Data)-maxHistory:]))
+	})
+
+	It("the indicator length and valid from bar should account for every result", func() {
+		Expect(indicator.Length()).To(Equal(uncapped.Length()))
+		Expect(indicator.ValidFromBar()).To(Equal(uncapped.ValidFromBar()))
+	})
+
+	It("the indicator should locate results by offset from the latest result", func() {
+		index, ok := indicator.DataIndexFromLatest(0)
+		Expect(ok).To(BeTrue())
+		Expect(indicator.Data[index]).To(Equal(uncapped.Data[len(uncapped.Data)-1]))
+
+		_, ok = indicator.DataIndexFromLatest(maxHistory)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("the indicator should locate results by stream bar index", func() {
+		lastBar := len(sourceDOHLCVData)
+		index, ok := indicator.DataIndexForBar(lastBar - 1)
+		Expect(ok).To(BeTrue())
+		Expect(indicator.Data[index]).To(Equal(uncapped.Data[len(uncapped.Data)-2]))
+
+		_, ok = indicator.DataIndexForBar(lastBar - maxHistory)
+		Expect(ok).To(BeFalse())
+
+		_, ok = indicator.DataIndexForBar(lastBar + 1)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("the indicator should not allow the max history to change once it has results", func() {
+		Expect(indicator.SetMaxHistory(10)).To(Equal(indicators.ErrMaxHistoryMustBeSetBeforeFirstValue))
+	})
+})
+
+var _ = Describe("when calculating a simple moving average (sma) of a long series", func() {
+	It("should not drift from the average of its period", func() {
+		var result float64
+		indicator, _ := indicators.NewSmaWithoutStorage(10, func(dataItem float64, streamBarIndex int) {
+			result = dataItem
+		})
+
+		// large values leave the period for 100000 bars, followed by a period of small values
+		streamBarIndex := 1
+		for ; streamBarIndex <= 100000; streamBarIndex++ {
+			indicator.ReceiveTick(1000000.0+float64(streamBarIndex%7)*0.1, streamBarIndex)
+		}
+		for i := 0; i < 10; i++ {
+			indicator.ReceiveTick(0.1, streamBarIndex+i)
+		}
+
+		Expect(result).To(BeNumerically("~", 0.1, 1e-15))
+	})
+})
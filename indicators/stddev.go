@@ -13,6 +13,9 @@ type StdDevWithoutStorage struct {
 	// private variables
 	variance   *VarWithoutStorage
 	timePeriod int
+
+	// the state prior to the latest source data bar
+	savedState *StdDevWithoutStorage
 }
 
 func NewStdDevWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *StdDevWithoutStorage, err error) {
@@ -72,9 +75,13 @@ func NewStdDev(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indi
 
 	ind.StdDevWithoutStorage, err = NewStdDevWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -143,6 +150,45 @@ func (stdDev *StdDev) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex
 	stdDev.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (stdDev *StdDev) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if stdDev.prepareRevision(stdDev, streamBarIndex) {
+		stdDev.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (stdDev *StdDevWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	stdDev.saveState(streamBarIndex)
+
 	stdDev.variance.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (stdDev *StdDevWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if stdDev.prepareRevision(stdDev, streamBarIndex) {
+		stdDev.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (stdDev *StdDevWithoutStorage) saveState(streamBarIndex int) {
+	if !stdDev.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	stdDev.saveBounds()
+	stdDev.variance.saveState(streamBarIndex)
+
+	if stdDev.savedState == nil {
+		stdDev.savedState = new(StdDevWithoutStorage)
+	}
+	*stdDev.savedState = *stdDev
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (stdDev *StdDevWithoutStorage) restoreState() {
+	stdDev.restoreBaseState()
+	stdDev.restoreBounds()
+	stdDev.variance.restoreState()
+	*stdDev = *stdDev.savedState
+}
@@ -20,6 +20,9 @@ type StochOscWithoutStorage struct {
 	currentFastK      float64
 	currentSlowKMA    float64
 	currentSlowDMA    float64
+
+	// the state prior to the latest source data bar
+	savedState *StochOscWithoutStorage
 }
 
 // NewStochOscWithoutStorage creates a Stochastic Oscillator Indicator (StochOsc) without storage
@@ -107,10 +110,14 @@ func NewStochOsc(fastKTimePeriod int, slowKTimePeriod int, slowDTimePeriod int)
 	ind := StochOsc{}
 	ind.StochOscWithoutStorage, err = NewStochOscWithoutStorage(fastKTimePeriod, slowKTimePeriod, slowDTimePeriod,
 		func(dataItemK float64, dataItemD float64, streamBarIndex int) {
-			ind.SlowK = append(ind.SlowK, dataItemK)
-			ind.SlowD = append(ind.SlowD, dataItemD)
+			ind.SlowK = ind.storeFloat(0, ind.SlowK, dataItemK)
+			ind.SlowD = ind.storeFloat(1, ind.SlowD, dataItemD)
 		})
 
+	if err == nil {
+		ind.nameOutputs("SlowK", "SlowD")
+	}
+
 	return &ind, err
 }
 
@@ -181,6 +188,8 @@ func NewDefaultStochOscForStreamWithSrcLen(sourceLength uint, priceStream gotrad
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *StochOscWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 	ind.hhv.ReceiveTick(tickData.H(), streamBarIndex)
 	ind.llv.ReceiveTick(tickData.L(), streamBarIndex)
@@ -190,3 +199,39 @@ func (ind *StochOscWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, st
 		ind.slowKMA.ReceiveTick(ind.currentFastK, streamBarIndex)
 	}
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *StochOscWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *StochOscWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.slowKMA.saveState(streamBarIndex)
+	ind.slowDMA.saveState(streamBarIndex)
+	ind.hhv.saveState(streamBarIndex)
+	ind.llv.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(StochOscWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *StochOscWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.slowKMA.restoreState()
+	ind.slowDMA.restoreState()
+	ind.hhv.restoreState()
+	ind.llv.restoreState()
+	*ind = *ind.savedState
+}
@@ -20,6 +20,9 @@ type StochRsiWithoutStorage struct {
 	currentPeriodLow  float64
 	currentFastK      float64
 	currentFastDMA    float64
+
+	// the state prior to the latest source data bar
+	savedState *StochRsiWithoutStorage
 }
 
 // NewStochRsiWithoutStorage creates a Stochastic Relative Strength Indicator (StochRsi) without storage
@@ -118,10 +121,14 @@ func NewStochRsi(timePeriod int, fastKTimePeriod int, fastDTimePeriod int) (indi
 	newStochRsi := StochRsi{}
 	newStochRsi.StochRsiWithoutStorage, err = NewStochRsiWithoutStorage(timePeriod, fastKTimePeriod, fastDTimePeriod,
 		func(dataItemK float64, dataItemD float64, streamBarIndex int) {
-			newStochRsi.SlowK = append(newStochRsi.SlowK, dataItemK)
-			newStochRsi.SlowD = append(newStochRsi.SlowD, dataItemD)
+			newStochRsi.SlowK = newStochRsi.storeFloat(0, newStochRsi.SlowK, dataItemK)
+			newStochRsi.SlowD = newStochRsi.storeFloat(1, newStochRsi.SlowD, dataItemD)
 		})
 
+	if err == nil {
+		newStochRsi.nameOutputs("SlowK", "SlowD")
+	}
+
 	return &newStochRsi, err
 }
 
@@ -192,6 +199,43 @@ func NewDefaultStochRsiForStreamWithSrcLen(sourceLength uint, priceStream gotrad
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *StochRsiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
 	ind.rsi.ReceiveTick(tickData.C(), streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *StochRsiWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *StochRsiWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.fastDMA.saveState(streamBarIndex)
+	ind.rsi.saveState(streamBarIndex)
+	ind.hhv.saveState(streamBarIndex)
+	ind.llv.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(StochRsiWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *StochRsiWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.fastDMA.restoreState()
+	ind.rsi.restoreState()
+	ind.hhv.restoreState()
+	ind.llv.restoreState()
+	*ind = *ind.savedState
+}
@@ -18,6 +18,9 @@ type TemaWithoutStorage struct {
 	currentEMA  float64
 	currentEMA2 float64
 	timePeriod  int
+
+	// the state prior to the latest source data bar
+	savedState *TemaWithoutStorage
 }
 
 func NewTemaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *TemaWithoutStorage, err error) {
@@ -85,8 +88,13 @@ func NewTema(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indica
 
 	ind.TemaWithoutStorage, err = NewTemaWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
+
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -155,6 +163,49 @@ func (ind *Tema) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Tema) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (ind *TemaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.ema1.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *TemaWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *TemaWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.ema1.saveState(streamBarIndex)
+	ind.ema2.saveState(streamBarIndex)
+	ind.ema3.saveState(streamBarIndex)
+
+	if ind.savedState == nil {
+		ind.savedState = new(TemaWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *TemaWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.ema1.restoreState()
+	ind.ema2.restoreState()
+	ind.ema3.restoreState()
+	*ind = *ind.savedState
+}
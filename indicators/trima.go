@@ -14,6 +14,9 @@ type TrimaWithoutStorage struct {
 	sma2       *SmaWithoutStorage
 	currentSma float64
 	timePeriod int
+
+	// the state prior to the latest source data bar
+	savedState *TrimaWithoutStorage
 }
 
 // NewTrimaWithoutStorage creates a Triangular Moving Average Indicator (Trima) without storage
@@ -88,8 +91,13 @@ func NewTrima(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indic
 
 	ind.TrimaWithoutStorage, err = NewTrimaWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
+
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -158,6 +166,47 @@ func (tema *Trima) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int
 	tema.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (tema *Trima) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if tema.prepareRevision(tema, streamBarIndex) {
+		tema.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 func (tema *TrimaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	tema.saveState(streamBarIndex)
+
 	tema.sma1.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (tema *TrimaWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if tema.prepareRevision(tema, streamBarIndex) {
+		tema.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (tema *TrimaWithoutStorage) saveState(streamBarIndex int) {
+	if !tema.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	tema.saveBounds()
+	tema.sma1.saveState(streamBarIndex)
+	tema.sma2.saveState(streamBarIndex)
+
+	if tema.savedState == nil {
+		tema.savedState = new(TrimaWithoutStorage)
+	}
+	*tema.savedState = *tema
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (tema *TrimaWithoutStorage) restoreState() {
+	tema.restoreBaseState()
+	tema.restoreBounds()
+	tema.sma1.restoreState()
+	tema.sma2.restoreState()
+	*tema = *tema.savedState
+}
@@ -16,6 +16,9 @@ type TrueRangeWithoutStorage struct {
 	// private variables
 	periodCounter int
 	previousClose float64
+
+	// the state prior to the latest source data bar
+	savedState *TrueRangeWithoutStorage
 }
 
 // NewTrueRangeWithoutStorage creates a True Range Indicator (TrueRange) without storage
@@ -47,8 +50,13 @@ type TrueRange struct {
 func NewTrueRange() (indicator *TrueRange, err error) {
 	ind := TrueRange{}
 	ind.TrueRangeWithoutStorage, err = NewTrueRangeWithoutStorage(func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
+
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -80,6 +88,8 @@ func NewTrueRangeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHL
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *TrueRangeWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
 	ind.periodCounter += 1
 
 	if ind.periodCounter > 0 {
@@ -93,3 +103,31 @@ func (ind *TrueRangeWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, s
 
 	ind.previousClose = tickData.C()
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *TrueRangeWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *TrueRangeWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(TrueRangeWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *TrueRangeWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
@@ -6,6 +6,9 @@ import (
 
 type TypPriceWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
+
+	// the state prior to the latest source data bar
+	savedState *TypPriceWithoutStorage
 }
 
 func NewTypPriceWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *TypPriceWithoutStorage, err error) {
@@ -35,9 +38,13 @@ type TypPrice struct {
 func NewTypPrice() (indicator *TypPrice, err error) {
 	ind := TypPrice{}
 	ind.TypPriceWithoutStorage, err = NewTypPriceWithoutStorage(func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -69,8 +76,37 @@ func NewTypPriceForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLC
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *TypPriceWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
 	result := (tickData.H() + tickData.L() + tickData.C()) / float64(3.0)
 
 	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 }
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *TypPriceWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *TypPriceWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+
+	if ind.savedState == nil {
+		ind.savedState = new(TypPriceWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *TypPriceWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	*ind = *ind.savedState
+}
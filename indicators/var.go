@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -12,10 +11,13 @@ type VarWithoutStorage struct {
 
 	// private variables
 	periodCounter int
-	periodHistory *list.List
+	periodHistory *gotrade.FloatRingBuffer
 	mean          float64
 	variance      float64
 	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *VarWithoutStorage
 }
 
 // NewVarWithoutStorage creates a Variance Indicator (Var) without storage
@@ -41,7 +43,7 @@ func NewVarWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	ind := VarWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                0,
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod),
 		mean:                         0.0,
 		variance:                     0.0,
 		timePeriod:                   timePeriod,
@@ -71,9 +73,13 @@ func NewVar(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 
 	ind.VarWithoutStorage, err = NewVarWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -142,10 +148,19 @@ func (ind *Var) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Var) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
 // http://en.wikipedia.org/wiki/Algorithms_for_calculating_variance - Knuth
 func (ind *VarWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-	firstValue := ind.periodHistory.Front().Value.(float64)
+	ind.saveState(streamBarIndex)
+
+	// the value leaving the period is discarded by the history once it is full
+	firstValue, _ := ind.periodHistory.Push(tickData)
 
 	previousMean := ind.mean
 	previousVar := ind.variance
@@ -164,11 +179,6 @@ func (ind *VarWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.variance = previousVar + (dOld+dNew)*(delta)
 	}
 
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-	}
-
 	if ind.periodCounter >= ind.timePeriod {
 
 		result := ind.variance / float64(ind.timePeriod)
@@ -176,3 +186,33 @@ func (ind *VarWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *VarWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *VarWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(VarWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *VarWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
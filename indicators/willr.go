@@ -1,10 +1,8 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
 )
 
 // A Williamns Percent R Indicator
@@ -12,10 +10,13 @@ type WillRWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodHighHistory *list.List
-	periodLowHistory  *list.List
-	periodCounter     int
-	timePeriod        int
+	periodHigh    *RollingExtreme
+	periodLow     *RollingExtreme
+	periodCounter int
+	timePeriod    int
+
+	// the state prior to the latest source data bar
+	savedState *WillRWithoutStorage
 }
 
 // NewWillRWithoutStorage creates a Williams Percent R Indicator (WillR) without storage
@@ -40,8 +41,8 @@ func NewWillRWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableA
 	ind := WillRWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                timePeriod * -1,
-		periodHighHistory:            list.New(),
-		periodLowHistory:             list.New(),
+		periodHigh:                   NewRollingMax(timePeriod, false),
+		periodLow:                    NewRollingMin(timePeriod, false),
 		timePeriod:                   timePeriod,
 	}
 
@@ -60,9 +61,13 @@ type WillR struct {
 func NewWillR(timePeriod int) (indicator *WillR, err error) {
 	ind := WillR{}
 	ind.WillRWithoutStorage, err = NewWillRWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.Data = append(ind.Data, dataItem)
+		ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 	})
 
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -127,57 +132,50 @@ func NewDefaultWillRForStreamWithSrcLen(sourceLength uint, priceStream gotrade.D
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *WillRWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
 	ind.periodCounter += 1
-	ind.periodHighHistory.PushBack(tickData.H())
-	ind.periodLowHistory.PushBack(tickData.L())
+	ind.periodHigh.Push(tickData.H())
+	ind.periodLow.Push(tickData.L())
 
-	highestHigh, _ := highestHighofPeriod(ind.periodHighHistory)
-	lowestLow, _ := lowestLowofPeriod(ind.periodLowHistory)
-
-	var result float64 = (highestHigh - tickData.C()) / (highestHigh - lowestLow) * -100.0
 	if ind.periodCounter >= 0 {
+		highestHigh := ind.periodHigh.Value()
+		lowestLow := ind.periodLow.Value()
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-	}
+		var result float64 = (highestHigh - tickData.C()) / (highestHigh - lowestLow) * -100.0
 
-	if ind.periodHighHistory.Len() >= ind.timePeriod {
-		var first = ind.periodHighHistory.Front()
-		ind.periodHighHistory.Remove(first)
-	}
-	if ind.periodLowHistory.Len() >= ind.timePeriod {
-		var first = ind.periodLowHistory.Front()
-		ind.periodLowHistory.Remove(first)
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
 }
 
-func highestHighofPeriod(l *list.List) (result float64, err error) {
-	if l.Len() == 0 {
-		err = errors.New("list is empty no high can be calculated.")
-	}
-
-	high := math.SmallestNonzeroFloat64
-	for e := l.Front(); e != nil; e = e.Next() {
-		value := e.Value.(float64)
-		if value > high {
-			high = value
-		}
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *WillRWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
 	}
-	return high, err
 }
 
-func lowestLowofPeriod(l *list.List) (result float64, err error) {
-	if l.Len() == 0 {
-		err = errors.New("list is empty no low can be calculated.")
+// saveState records the state of the indicator prior to the source data bar
+func (ind *WillRWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
 	}
 
-	low := math.MaxFloat64
-	for e := l.Front(); e != nil; e = e.Next() {
-		value := e.Value.(float64)
-		if value < low {
-			low = value
-		}
+	ind.saveBounds()
+	ind.periodHigh.SaveState()
+	ind.periodLow.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(WillRWithoutStorage)
 	}
+	*ind.savedState = *ind
+}
 
-	return low, err
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *WillRWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHigh.RestoreState()
+	ind.periodLow.RestoreState()
+	*ind = *ind.savedState
 }
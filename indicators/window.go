@@ -0,0 +1,160 @@
+package indicators
+
+import (
+	"math"
+)
+
+// The period histories of the indicators are typed ring buffers, a gotrade.FloatRingBuffer or the RollingExtreme
+// below, so that no value is boxed and receiving a tick never allocates once the history is created. Each history can
+// undo the changes made to it since its state was last saved, so that an indicator can revise its latest bar. Only
+// writing a value to a slot loses information, the values removed from a history remain in their slots until they are
+// overwritten, so undoing a bar restores the overwritten slots and the saved positions in O(1) per value received.
+
+// A RollingExtreme tracks the highest, or lowest, of the most recent period values received in O(1) amortised per
+// value. It keeps a monotonic deque of the values which may yet become the extreme, a value is dropped from the
+// back of the deque when a value which dominates it is received, and from the front when it leaves the period.
+type RollingExtreme struct {
+	values    []float64
+	indexes   []int
+	period    int
+	highest   bool
+	newest    bool
+	head      int
+	count     int
+	nextIndex int
+
+	// the state when last saved and the slots overwritten since, only recorded once the state has been saved
+	recording      bool
+	savedHead      int
+	savedCount     int
+	savedNextIndex int
+	overwritten    []extremeSlot
+}
+
+// extremeSlot is the content of a RollingExtreme slot prior to it being overwritten
+type extremeSlot struct {
+	slot  int
+	value float64
+	index int
+}
+
+// NewRollingMax creates a RollingExtreme tracking the highest value of the period, of equal highest values the
+// newest is the extreme when newest is true, otherwise the oldest
+func NewRollingMax(period int, newest bool) *RollingExtreme {
+	return newRollingExtreme(period, true, newest)
+}
+
+// NewRollingMin creates a RollingExtreme tracking the lowest value of the period, of equal lowest values the
+// newest is the extreme when newest is true, otherwise the oldest
+func NewRollingMin(period int, newest bool) *RollingExtreme {
+	return newRollingExtreme(period, false, newest)
+}
+
+func newRollingExtreme(period int, highest bool, newest bool) *RollingExtreme {
+	return &RollingExtreme{
+		values:      make([]float64, period),
+		indexes:     make([]int, period),
+		period:      period,
+		highest:     highest,
+		newest:      newest,
+		overwritten: make([]extremeSlot, 0, 1),
+	}
+}
+
+// dominates returns true when the value received after other prevents other from ever becoming the extreme
+func (r *RollingExtreme) dominates(value float64, other float64) bool {
+	if r.highest {
+		return value > other || (r.newest && value == other)
+	}
+	return value < other || (r.newest && value == other)
+}
+
+// Push adds a value, the oldest value leaves the period once it is full
+func (r *RollingExtreme) Push(value float64) {
+	// the values the new value dominates can never be the extreme
+	for r.count > 0 {
+		back := (r.head + r.count - 1) % r.period
+		if !r.dominates(value, r.values[back]) {
+			break
+		}
+		r.count--
+	}
+
+	// the front value leaves the deque with the period, the deque cannot be full after this
+	if r.count > 0 && r.indexes[r.head] <= r.nextIndex-r.period {
+		r.head = (r.head + 1) % r.period
+		r.count--
+	}
+
+	slot := (r.head + r.count) % r.period
+	if r.recording {
+		r.overwritten = append(r.overwritten, extremeSlot{slot: slot, value: r.values[slot], index: r.indexes[slot]})
+	}
+	r.values[slot] = value
+	r.indexes[slot] = r.nextIndex
+	r.count++
+	r.nextIndex++
+}
+
+// Value returns the extreme of the period, at least one value must have been received
+func (r *RollingExtreme) Value() float64 {
+	return r.values[r.head]
+}
+
+// Age returns the number of values received after the extreme, 0 when the latest value is the extreme
+func (r *RollingExtreme) Age() int {
+	return r.nextIndex - 1 - r.indexes[r.head]
+}
+
+// SaveState makes the current contents the state restored by RestoreState. From then on the slots each push
+// overwrites are recorded, and forgotten at the next save.
+func (r *RollingExtreme) SaveState() {
+	r.recording = true
+	r.savedHead = r.head
+	r.savedCount = r.count
+	r.savedNextIndex = r.nextIndex
+	r.overwritten = r.overwritten[:0]
+}
+
+// RestoreState undoes the values pushed since the state was last saved, the state must have been saved
+func (r *RollingExtreme) RestoreState() {
+	for i := len(r.overwritten) - 1; i >= 0; i-- {
+		previous := r.overwritten[i]
+		r.values[previous.slot] = previous.value
+		r.indexes[previous.slot] = previous.index
+	}
+	r.overwritten = r.overwritten[:0]
+	r.head = r.savedHead
+	r.count = r.savedCount
+	r.nextIndex = r.savedNextIndex
+}
+
+// A KahanSum is a Kahan-Babuska (Neumaier) compensated sum, it accumulates the low order bits lost by each addition
+// separately so that a rolling sum, which adds and removes every value of a long series, does not drift from the sum
+// of its period. Unlike the original Kahan summation it is also exact when a value is larger than the sum, as when
+// a large value leaves the period.
+type KahanSum struct {
+	sum          float64
+	compensation float64
+}
+
+// Add adds a value to the sum
+func (k *KahanSum) Add(value float64) {
+	t := k.sum + value
+	if math.Abs(k.sum) >= math.Abs(value) {
+		k.compensation += (k.sum - t) + value
+	} else {
+		k.compensation += (value - t) + k.sum
+	}
+	k.sum = t
+}
+
+// Sub subtracts a value from the sum
+func (k *KahanSum) Sub(value float64) {
+	k.Add(-value)
+}
+
+// Value returns the sum
+func (k *KahanSum) Value() float64 {
+	return k.sum + k.compensation
+}
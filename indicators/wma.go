@@ -1,7 +1,6 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
 )
@@ -12,10 +11,13 @@ type WmaWithoutStorage struct {
 
 	// private variables
 	periodTotal       float64
-	periodHistory     *list.List
+	periodHistory     *gotrade.FloatRingBuffer
 	periodCounter     int
 	periodWeightTotal int
 	timePeriod        int
+
+	// the state prior to the latest source data bar
+	savedState *WmaWithoutStorage
 }
 
 // NewWmaWithoutStorage creates a Weighted Moving Average Indicator (Wma) without storage
@@ -40,7 +42,7 @@ func NewWmaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	ind := WmaWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
 		periodCounter:                timePeriod * -1,
-		periodHistory:                list.New(),
+		periodHistory:                gotrade.NewFloatRingBuffer(timePeriod),
 		timePeriod:                   timePeriod,
 	}
 
@@ -74,8 +76,13 @@ func NewWma(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 
 	ind.WmaWithoutStorage, err = NewWmaWithoutStorage(timePeriod,
 		func(dataItem float64, streamBarIndex int) {
-			ind.Data = append(ind.Data, dataItem)
+			ind.Data = ind.storeFloat(0, ind.Data, dataItem)
 		})
+
+	if err == nil {
+		ind.nameOutputs("Data")
+	}
+
 	return &ind, err
 }
 
@@ -144,27 +151,28 @@ func (ind *Wma) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
-func (ind *WmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodCounter += 1
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing the latest result
+func (ind *Wma) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
 
-	ind.periodHistory.PushBack(tickData)
+func (ind *WmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
 
-	if ind.periodCounter > 0 {
+	ind.periodCounter += 1
 
-	}
-	if ind.periodHistory.Len() > ind.timePeriod {
-		var first = ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-	}
+	ind.periodHistory.Push(tickData)
 
 	if ind.periodCounter >= 0 {
 		// calculate the ind
 		var iter int = 1
 		var sum float64 = 0
-		for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
+		for _, value := range ind.periodHistory.Values() {
 			var localSum float64 = 0
 			for i := 1; i <= iter; i++ {
-				localSum += e.Value.(float64)
+				localSum += value
 			}
 			sum += localSum
 			iter++
@@ -174,3 +182,33 @@ func (ind *WmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
 }
+
+// ReceiveTickUpdate consumes a revision of the latest source data price tick, replacing the latest result
+func (ind *WmaWithoutStorage) ReceiveTickUpdate(tickData float64, streamBarIndex int) {
+	if ind.prepareRevision(ind, streamBarIndex) {
+		ind.ReceiveTick(tickData, streamBarIndex)
+	}
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *WmaWithoutStorage) saveState(streamBarIndex int) {
+	if !ind.saveBaseState(streamBarIndex) {
+		return
+	}
+
+	ind.saveBounds()
+	ind.periodHistory.SaveState()
+
+	if ind.savedState == nil {
+		ind.savedState = new(WmaWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *WmaWithoutStorage) restoreState() {
+	ind.restoreBaseState()
+	ind.restoreBounds()
+	ind.periodHistory.RestoreState()
+	*ind = *ind.savedState
+}
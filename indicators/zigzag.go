@@ -0,0 +1,371 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+)
+
+// ZigZagThresholdMethod determines how the reversal which confirms a ZigZag pivot is measured
+type ZigZagThresholdMethod int
+
+const (
+	// the reversal is a percentage of the pivot's price
+	PercentageZigZagThreshold ZigZagThresholdMethod = iota
+	// the reversal is a multiple of the average true range
+	AtrZigZagThreshold
+)
+
+// A ZigZagPivot is a turning point of the ZigZag, the high of a swing high or the low of a swing low
+type ZigZagPivot struct {
+	High           bool
+	Price          float64
+	StreamBarIndex int
+}
+
+// ZigZagEventType is the type of change to the ZigZag
+type ZigZagEventType int
+
+const (
+	// the price reversed from the previous pivot and the extreme of the new leg is a provisional pivot
+	ZigZagPivotProvisional ZigZagEventType = iota
+	// the leg made a new extreme and the provisional pivot was repainted at a later bar
+	ZigZagPivotRevised
+	// the price reversed by the threshold from the provisional pivot, which will not be repainted
+	ZigZagPivotConfirmed
+)
+
+// A ZigZagEvent describes a change to the ZigZag
+//	- pivot: the pivot as of the event
+//	- originalBarIndex: for a revision the stream bar index the provisional pivot was repainted from,
+//	  otherwise the pivot's own stream bar index
+//	- streamBarIndex: the stream bar index of the source data bar which caused the event
+type ZigZagEvent struct {
+	EventType        ZigZagEventType
+	Pivot            ZigZagPivot
+	OriginalBarIndex int
+	StreamBarIndex   int
+}
+
+// ZigZagEventAction is called for each change to a ZigZag, unlike a ValueAvailableActionFloat it reports changes
+// to results of earlier bars
+type ZigZagEventAction func(event ZigZagEvent)
+
+// A ZigZag Indicator (ZigZag), no storage, for use in other indicators
+type ZigZagWithoutStorage struct {
+	// private variables
+	method         ZigZagThresholdMethod
+	percentage     float64
+	atrMultiple    float64
+	atr            *AtrWithoutStorage
+	currentAtr     float64
+	hasAtr         bool
+	lookback       int
+	validFromBar   int
+	confirmedCount int
+	direction      int
+	provisional    ZigZagPivot
+	highCandidate  ZigZagPivot
+	lowCandidate   ZigZagPivot
+	hasCandidates  bool
+	eventAction    ZigZagEventAction
+	stateBarIndex  int
+	savedState     *ZigZagWithoutStorage
+}
+
+// NewZigZagWithoutStorage creates a ZigZag Indicator (ZigZag) without storage whose pivots are confirmed by a
+// reversal of percentage percent, e.g. 5.0 for 5%
+func NewZigZagWithoutStorage(percentage float64, eventAction ZigZagEventAction) (indicator *ZigZagWithoutStorage, err error) {
+
+	// an indicator without storage MUST have an event action
+	if eventAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	if percentage <= 0.0 {
+		return nil, errors.New("percentage is less than the minimum (0.0)")
+	}
+
+	ind := ZigZagWithoutStorage{
+		method:       PercentageZigZagThreshold,
+		percentage:   percentage,
+		validFromBar: -1,
+		eventAction:  eventAction,
+	}
+
+	return &ind, nil
+}
+
+// NewAtrZigZagWithoutStorage creates a ZigZag Indicator (ZigZag) without storage whose pivots are confirmed by a
+// reversal of atrMultiple times the average true range of atrPeriod bars
+func NewAtrZigZagWithoutStorage(atrPeriod int, atrMultiple float64, eventAction ZigZagEventAction) (indicator *ZigZagWithoutStorage, err error) {
+
+	// an indicator without storage MUST have an event action
+	if eventAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	if atrMultiple <= 0.0 {
+		return nil, errors.New("atrMultiple is less than the minimum (0.0)")
+	}
+
+	ind := ZigZagWithoutStorage{
+		method:       AtrZigZagThreshold,
+		atrMultiple:  atrMultiple,
+		validFromBar: -1,
+		eventAction:  eventAction,
+	}
+
+	ind.atr, err = NewAtrWithoutStorage(atrPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentAtr = dataItem
+		ind.hasAtr = true
+	})
+	if err != nil {
+		return nil, err
+	}
+	ind.lookback = ind.atr.GetLookbackPeriod()
+
+	return &ind, nil
+}
+
+// A ZigZag Indicator (ZigZag)
+type ZigZag struct {
+	*ZigZagWithoutStorage
+
+	// public variables
+	// the confirmed pivots, the latest pivot is last
+	Data []ZigZagPivot
+}
+
+// NewZigZag creates a ZigZag Indicator (ZigZag) for online usage
+func NewZigZag(percentage float64) (indicator *ZigZag, err error) {
+	ind := ZigZag{}
+	ind.ZigZagWithoutStorage, err = NewZigZagWithoutStorage(percentage, ind.storePivot)
+	if err != nil {
+		return nil, err
+	}
+	return &ind, nil
+}
+
+// NewDefaultZigZag creates a ZigZag Indicator (ZigZag) for online usage with default parameters
+//	- percentage: 5.0
+func NewDefaultZigZag() (indicator *ZigZag, err error) {
+	percentage := 5.0
+	return NewZigZag(percentage)
+}
+
+// NewAtrZigZag creates a ZigZag Indicator (ZigZag) for online usage with an average true range threshold
+func NewAtrZigZag(atrPeriod int, atrMultiple float64) (indicator *ZigZag, err error) {
+	ind := ZigZag{}
+	ind.ZigZagWithoutStorage, err = NewAtrZigZagWithoutStorage(atrPeriod, atrMultiple, ind.storePivot)
+	if err != nil {
+		return nil, err
+	}
+	return &ind, nil
+}
+
+// NewZigZagForStream creates a ZigZag Indicator (ZigZag) for online usage with a source data stream
+func NewZigZagForStream(priceStream gotrade.DOHLCVStreamSubscriber, percentage float64) (indicator *ZigZag, err error) {
+	ind, err := NewZigZag(percentage)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultZigZagForStream creates a ZigZag Indicator (ZigZag) for online usage with a source data stream
+func NewDefaultZigZagForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ZigZag, err error) {
+	ind, err := NewDefaultZigZag()
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewAtrZigZagForStream creates a ZigZag Indicator (ZigZag) for online usage with a source data stream and an
+// average true range threshold
+func NewAtrZigZagForStream(priceStream gotrade.DOHLCVStreamSubscriber, atrPeriod int, atrMultiple float64) (indicator *ZigZag, err error) {
+	ind, err := NewAtrZigZag(atrPeriod, atrMultiple)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+func (ind *ZigZag) storePivot(event ZigZagEvent) {
+	if event.EventType == ZigZagPivotConfirmed {
+		ind.Data = append(ind.Data, event.Pivot)
+	}
+}
+
+// ValidFromBar returns the source data bar number from which the first pivot was confirmed, starting at bar 1
+func (ind *ZigZagWithoutStorage) ValidFromBar() int {
+	return ind.validFromBar
+}
+
+// GetLookbackPeriod returns the number of source data bars before a reversal can be measured
+func (ind *ZigZagWithoutStorage) GetLookbackPeriod() int {
+	return ind.lookback
+}
+
+// Length returns the number of confirmed pivots
+func (ind *ZigZagWithoutStorage) Length() int {
+	return ind.confirmedCount
+}
+
+// ThresholdMethod returns how the reversal which confirms a pivot is measured
+func (ind *ZigZagWithoutStorage) ThresholdMethod() ZigZagThresholdMethod {
+	return ind.method
+}
+
+// ProvisionalPivot returns the extreme of the latest leg, which is repainted while the leg extends
+func (ind *ZigZagWithoutStorage) ProvisionalPivot() (pivot ZigZagPivot, ok bool) {
+	if ind.direction == 0 {
+		return ZigZagPivot{}, false
+	}
+	return ind.provisional, true
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ZigZagWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.saveState(streamBarIndex)
+
+	if ind.method == AtrZigZagThreshold {
+		ind.atr.ReceiveDOHLCVTick(tickData, streamBarIndex)
+		if !ind.hasAtr {
+			return
+		}
+	}
+
+	high := ZigZagPivot{High: true, Price: tickData.H(), StreamBarIndex: streamBarIndex}
+	low := ZigZagPivot{High: false, Price: tickData.L(), StreamBarIndex: streamBarIndex}
+
+	// until the first reversal both the highest high and the lowest low are candidates for the first pivot
+	if !ind.hasCandidates {
+		ind.highCandidate, ind.lowCandidate = high, low
+		ind.hasCandidates = true
+		return
+	}
+
+	switch ind.direction {
+	case 0:
+		if high.Price > ind.highCandidate.Price {
+			ind.highCandidate = high
+		}
+		if low.Price < ind.lowCandidate.Price {
+			ind.lowCandidate = low
+		}
+
+		if low.Price <= ind.highCandidate.Price-ind.threshold(ind.highCandidate.Price) {
+			ind.confirm(ind.highCandidate, low, -1, streamBarIndex)
+		} else if high.Price >= ind.lowCandidate.Price+ind.threshold(ind.lowCandidate.Price) {
+			ind.confirm(ind.lowCandidate, high, 1, streamBarIndex)
+		}
+	case 1:
+		if high.Price > ind.provisional.Price {
+			ind.revise(high, streamBarIndex)
+		} else if low.Price <= ind.provisional.Price-ind.threshold(ind.provisional.Price) {
+			ind.confirm(ind.provisional, low, -1, streamBarIndex)
+		}
+	case -1:
+		if low.Price < ind.provisional.Price {
+			ind.revise(low, streamBarIndex)
+		} else if high.Price >= ind.provisional.Price+ind.threshold(ind.provisional.Price) {
+			ind.confirm(ind.provisional, high, 1, streamBarIndex)
+		}
+	}
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick. The events of the revised
+// bar are published again, they replace the events previously published with the same stream bar index.
+func (ind *ZigZagWithoutStorage) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(streamBarIndex) {
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// ReceiveDOHLCVTickUpdate consumes a revision of the latest source data DOHLCV price tick, replacing a pivot
+// confirmed by the bar prior to its revision
+func (ind *ZigZag) ReceiveDOHLCVTickUpdate(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.prepareRevision(streamBarIndex) {
+		ind.Data = ind.Data[:ind.confirmedCount]
+		ind.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// prepareRevision returns the indicator to its state prior to the source data bar when it is the latest bar received.
+// Returns false when the bar precedes the latest bar and can no longer be revised.
+func (ind *ZigZagWithoutStorage) prepareRevision(streamBarIndex int) bool {
+	if streamBarIndex < ind.stateBarIndex {
+		return false
+	}
+
+	if streamBarIndex == ind.stateBarIndex {
+		ind.restoreState()
+	}
+	return true
+}
+
+// saveState records the state of the indicator prior to the source data bar
+func (ind *ZigZagWithoutStorage) saveState(streamBarIndex int) {
+	if ind.stateBarIndex == streamBarIndex {
+		return
+	}
+	ind.stateBarIndex = streamBarIndex
+
+	if ind.atr != nil {
+		ind.atr.saveState(streamBarIndex)
+	}
+
+	if ind.savedState == nil {
+		ind.savedState = new(ZigZagWithoutStorage)
+	}
+	*ind.savedState = *ind
+}
+
+// restoreState returns the indicator to the state recorded by saveState
+func (ind *ZigZagWithoutStorage) restoreState() {
+	if ind.atr != nil {
+		ind.atr.restoreState()
+	}
+	*ind = *ind.savedState
+}
+
+// threshold returns the reversal from the price which confirms a pivot
+func (ind *ZigZagWithoutStorage) threshold(price float64) float64 {
+	if ind.method == AtrZigZagThreshold {
+		return ind.atrMultiple * ind.currentAtr
+	}
+	return price * ind.percentage / 100.0
+}
+
+// confirm confirms the pivot and starts a new leg with the provisional pivot
+func (ind *ZigZagWithoutStorage) confirm(pivot ZigZagPivot, provisional ZigZagPivot, direction int, streamBarIndex int) {
+	ind.confirmedCount++
+	if ind.validFromBar == -1 {
+		ind.validFromBar = streamBarIndex
+	}
+	ind.eventAction(ZigZagEvent{EventType: ZigZagPivotConfirmed,
+		Pivot:            pivot,
+		OriginalBarIndex: pivot.StreamBarIndex,
+		StreamBarIndex:   streamBarIndex})
+
+	ind.provisional = provisional
+	ind.direction = direction
+	ind.eventAction(ZigZagEvent{EventType: ZigZagPivotProvisional,
+		Pivot:            provisional,
+		OriginalBarIndex: provisional.StreamBarIndex,
+		StreamBarIndex:   streamBarIndex})
+}
+
+// revise repaints the provisional pivot at the new extreme of the leg
+func (ind *ZigZagWithoutStorage) revise(pivot ZigZagPivot, streamBarIndex int) {
+	originalBarIndex := ind.provisional.StreamBarIndex
+	ind.provisional = pivot
+	ind.eventAction(ZigZagEvent{EventType: ZigZagPivotRevised,
+		Pivot:            pivot,
+		OriginalBarIndex: originalBarIndex,
+		StreamBarIndex:   streamBarIndex})
+}
@@ -0,0 +1,239 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+func newZigZagBar(day int, high float64, low float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Date(2014, time.January, day, 0, 0, 0, 0, time.UTC), low, high, low, high, 1000.0)
+}
+
+var _ = Describe("when creating a zigzagwithoutstorage", func() {
+	var (
+		indicator      *indicators.ZigZagWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given an event action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewZigZagWithoutStorage(5.0, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a percentage of zero", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewZigZagWithoutStorage(0.0, func(event indicators.ZigZagEvent) {})
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).ToNot(BeNil())
+		})
+	})
+
+	Context("and the indicator was given an atr multiple of zero", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAtrZigZagWithoutStorage(14, 0.0, func(event indicators.ZigZagEvent) {})
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).ToNot(BeNil())
+		})
+	})
+
+	Context("and the indicator was given an atr period below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAtrZigZagWithoutStorage(0, 3.0, func(event indicators.ZigZagEvent) {})
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).ToNot(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when calculating a zigzag with a percentage threshold", func() {
+	var (
+		indicator *indicators.ZigZag
+		events    []indicators.ZigZagEvent
+	)
+
+	BeforeEach(func() {
+		events = nil
+		indicator, _ = indicators.NewZigZag(10.0)
+		inner, _ := indicators.NewZigZagWithoutStorage(10.0, func(event indicators.ZigZagEvent) {
+			events = append(events, event)
+		})
+		bars := []gotrade.DOHLCV{
+			newZigZagBar(1, 100.0, 95.0),
+			newZigZagBar(2, 110.0, 104.0),
+			newZigZagBar(3, 120.0, 112.0),
+			newZigZagBar(4, 115.0, 110.0),
+			newZigZagBar(5, 125.0, 118.0),
+			newZigZagBar(6, 118.0, 114.0),
+			newZigZagBar(7, 112.0, 104.0),
+			newZigZagBar(8, 110.0, 100.0),
+			newZigZagBar(9, 108.0, 103.0),
+		}
+		for i, bar := range bars {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+			inner.ReceiveDOHLCVTick(bar, i+1)
+		}
+	})
+
+	It("should have a lookback period of zero", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+	})
+
+	It("should confirm the first pivot when the price reverses by the threshold", func() {
+		Expect(events[0]).To(Equal(indicators.ZigZagEvent{EventType: indicators.ZigZagPivotConfirmed,
+			Pivot:            indicators.ZigZagPivot{High: false, Price: 95.0, StreamBarIndex: 1},
+			OriginalBarIndex: 1,
+			StreamBarIndex:   2}))
+		Expect(events[1].EventType).To(Equal(indicators.ZigZagPivotProvisional))
+		Expect(events[1].Pivot).To(Equal(indicators.ZigZagPivot{High: true, Price: 110.0, StreamBarIndex: 2}))
+		Expect(indicator.ValidFromBar()).To(Equal(2))
+	})
+
+	It("should revise the provisional pivot with the bar index it was repainted from", func() {
+		Expect(events[2]).To(Equal(indicators.ZigZagEvent{EventType: indicators.ZigZagPivotRevised,
+			Pivot:            indicators.ZigZagPivot{High: true, Price: 120.0, StreamBarIndex: 3},
+			OriginalBarIndex: 2,
+			StreamBarIndex:   3}))
+		Expect(events[3]).To(Equal(indicators.ZigZagEvent{EventType: indicators.ZigZagPivotRevised,
+			Pivot:            indicators.ZigZagPivot{High: true, Price: 125.0, StreamBarIndex: 5},
+			OriginalBarIndex: 3,
+			StreamBarIndex:   5}))
+	})
+
+	It("should confirm the provisional pivot when the price reverses by the threshold", func() {
+		Expect(events[4]).To(Equal(indicators.ZigZagEvent{EventType: indicators.ZigZagPivotConfirmed,
+			Pivot:            indicators.ZigZagPivot{High: true, Price: 125.0, StreamBarIndex: 5},
+			OriginalBarIndex: 5,
+			StreamBarIndex:   7}))
+		Expect(events[5].EventType).To(Equal(indicators.ZigZagPivotProvisional))
+		Expect(events[5].Pivot).To(Equal(indicators.ZigZagPivot{High: false, Price: 104.0, StreamBarIndex: 7}))
+		Expect(events[6].EventType).To(Equal(indicators.ZigZagPivotRevised))
+		Expect(events).To(HaveLen(7))
+	})
+
+	It("should store the confirmed pivots and report the provisional pivot", func() {
+		Expect(indicator.Length()).To(Equal(2))
+		Expect(indicator.Data).To(Equal([]indicators.ZigZagPivot{
+			{High: false, Price: 95.0, StreamBarIndex: 1},
+			{High: true, Price: 125.0, StreamBarIndex: 5}}))
+		pivot, ok := indicator.ProvisionalPivot()
+		Expect(ok).To(BeTrue())
+		Expect(pivot).To(Equal(indicators.ZigZagPivot{High: false, Price: 100.0, StreamBarIndex: 8}))
+	})
+})
+
+var _ = Describe("when calculating a zigzag with an atr threshold", func() {
+	var (
+		indicator *indicators.ZigZag
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewAtrZigZag(3, 2.0)
+	})
+
+	It("should have the lookback period of the atr", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(3))
+		Expect(indicator.ThresholdMethod()).To(Equal(indicators.AtrZigZagThreshold))
+	})
+
+	Context("and the indicator has received the source data", func() {
+		BeforeEach(func() {
+			for i := range sourceDOHLCVData {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should confirm pivots which alternate between highs and lows", func() {
+			Expect(indicator.Length()).To(BeNumerically(">", 1))
+			for i := 1; i < len(indicator.Data); i++ {
+				Expect(indicator.Data[i].High).ToNot(Equal(indicator.Data[i-1].High))
+				Expect(indicator.Data[i].StreamBarIndex).To(BeNumerically(">", indicator.Data[i-1].StreamBarIndex))
+			}
+		})
+
+		It("should not confirm a pivot before the lookback period", func() {
+			Expect(indicator.ValidFromBar()).To(BeNumerically(">", indicator.GetLookbackPeriod()))
+		})
+	})
+})
+
+var _ = Describe("when creating a zigzag for a stream", func() {
+	It("should subscribe to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultZigZagForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
+
+var _ = Describe("when revising the latest bar of a zigzag", func() {
+	var (
+		indicator *indicators.ZigZag
+		events    []indicators.ZigZagEvent
+	)
+
+	BeforeEach(func() {
+		events = nil
+		indicator, _ = indicators.NewZigZag(10.0)
+		inner, _ := indicators.NewZigZagWithoutStorage(10.0, func(event indicators.ZigZagEvent) {
+			events = append(events, event)
+		})
+		bars := []gotrade.DOHLCV{
+			newZigZagBar(1, 100.0, 95.0),
+			newZigZagBar(2, 110.0, 104.0),
+			newZigZagBar(3, 125.0, 118.0),
+		}
+		for i, bar := range bars {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+			inner.ReceiveDOHLCVTick(bar, i+1)
+		}
+
+		// the fourth bar first confirms the high then is revised to extend the leg
+		indicator.ReceiveDOHLCVTick(newZigZagBar(4, 115.0, 110.0), 4)
+		indicator.ReceiveDOHLCVTickUpdate(newZigZagBar(4, 130.0, 120.0), 4)
+		inner.ReceiveDOHLCVTick(newZigZagBar(4, 115.0, 110.0), 4)
+		events = nil
+		inner.ReceiveDOHLCVTickUpdate(newZigZagBar(4, 130.0, 120.0), 4)
+	})
+
+	It("should replace the pivot confirmed by the bar prior to its revision", func() {
+		Expect(indicator.Length()).To(Equal(1))
+		Expect(indicator.Data).To(Equal([]indicators.ZigZagPivot{{High: false, Price: 95.0, StreamBarIndex: 1}}))
+	})
+
+	It("should restore the direction and provisional pivot prior to the bar", func() {
+		pivot, ok := indicator.ProvisionalPivot()
+		Expect(ok).To(BeTrue())
+		Expect(pivot).To(Equal(indicators.ZigZagPivot{High: true, Price: 130.0, StreamBarIndex: 4}))
+	})
+
+	It("should publish the events of the revised bar again", func() {
+		Expect(events).To(Equal([]indicators.ZigZagEvent{{EventType: indicators.ZigZagPivotRevised,
+			Pivot:            indicators.ZigZagPivot{High: true, Price: 130.0, StreamBarIndex: 4},
+			OriginalBarIndex: 3,
+			StreamBarIndex:   4}}))
+	})
+
+	It("should ignore a revision of a bar before its latest bar", func() {
+		indicator.ReceiveDOHLCVTickUpdate(newZigZagBar(3, 200.0, 50.0), 3)
+		pivot, _ := indicator.ProvisionalPivot()
+		Expect(pivot.Price).To(Equal(130.0))
+		Expect(indicator.Length()).To(Equal(1))
+	})
+})
@@ -0,0 +1,108 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrBarThresholdMustBeGreaterThanZero = errors.New("Bar threshold must be greater than 0")
+)
+
+// InformationBarType determines the activity measured by an InformationBarBuilder to decide when a bar is complete
+type InformationBarType int
+
+const (
+	// a bar completes once the traded volume reaches the threshold
+	VolumeBar InformationBarType = iota
+	// a bar completes once the number of trades reaches the threshold
+	TickBar
+	// a bar completes once the range between its high and low reaches the threshold
+	RangeBar
+	// a bar completes once the traded value, price times size, reaches the threshold
+	DollarBar
+)
+
+// An InformationBarBuilder aggregates trades, or the bars of a finer grained source data stream, into DOHLCV bars
+// which each cover the same amount of market activity rather than the same amount of time.
+// A bar completes on the trade which takes its activity to the threshold, that trade belongs to the bar,
+// so bars can exceed the threshold. Each bar is dated with the time of the trade which completed it.
+// When built from source bars each bar counts as one trade of its volume at its close, and its high and low
+// extend the range.
+type InformationBarBuilder struct {
+	// private variables
+	barType    InformationBarType
+	threshold  float64
+	target     DOHLCVStreamTickReceiver
+	currentBar *DOHLCVDataItem
+	activity   float64
+}
+
+// NewInformationBarBuilder creates an InformationBarBuilder which publishes bars to the target
+//	- barType: the activity measured
+//	- threshold: the activity of each bar, e.g. the volume of a VolumeBar
+func NewInformationBarBuilder(barType InformationBarType, threshold float64, target DOHLCVStreamTickReceiver) (builder *InformationBarBuilder, err error) {
+	if threshold <= 0.0 {
+		return nil, ErrBarThresholdMustBeGreaterThanZero
+	}
+
+	b := InformationBarBuilder{
+		barType:   barType,
+		threshold: threshold,
+		target:    target,
+	}
+	return &b, nil
+}
+
+// ReceiveTradeTick consumes a trade, publishing the bar under construction once it reaches the threshold
+func (b *InformationBarBuilder) ReceiveTradeTick(tickData Trade) {
+	b.merge(NewDOHLCVDataItem(tickData.D(), tickData.P(), tickData.P(), tickData.P(), tickData.P(), tickData.S()))
+}
+
+// ReceiveDOHLCVTick consumes a bar of a finer grained source data stream, publishing the bar under construction
+// once it reaches the threshold
+func (b *InformationBarBuilder) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	b.merge(tickData)
+}
+
+// Flush publishes the bar under construction, for use at the end of the source data
+func (b *InformationBarBuilder) Flush() {
+	if b.currentBar != nil {
+		b.publish()
+	}
+}
+
+func (b *InformationBarBuilder) merge(tickData DOHLCV) {
+	if b.currentBar == nil {
+		b.currentBar = NewDOHLCVDataItem(tickData.D(), tickData.O(), tickData.H(), tickData.L(), tickData.C(), tickData.V())
+	} else {
+		b.currentBar.date = tickData.D()
+		b.currentBar.highPrice = math.Max(b.currentBar.highPrice, tickData.H())
+		b.currentBar.lowPrice = math.Min(b.currentBar.lowPrice, tickData.L())
+		b.currentBar.closePrice = tickData.C()
+		b.currentBar.volumePrice += tickData.V()
+	}
+
+	switch b.barType {
+	case VolumeBar:
+		b.activity += tickData.V()
+	case TickBar:
+		b.activity++
+	case RangeBar:
+		b.activity = b.currentBar.highPrice - b.currentBar.lowPrice
+	case DollarBar:
+		b.activity += tickData.C() * tickData.V()
+	}
+
+	// allow for floating point error in activity which reaches the threshold exactly
+	if b.activity >= b.threshold-1e-9 {
+		b.publish()
+	}
+}
+
+func (b *InformationBarBuilder) publish() {
+	bar := b.currentBar
+	b.currentBar = nil
+	b.activity = 0.0
+	b.target.ReceiveTick(bar)
+}
@@ -0,0 +1,105 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+var _ = Describe("when building information bars", func() {
+	var (
+		stream      *gotrade.InformationDOHLCVStream
+		streamError error
+		start       time.Time
+	)
+
+	BeforeEach(func() {
+		start = time.Date(2014, time.March, 3, 9, 0, 0, 0, time.UTC)
+	})
+
+	receiveTrades := func(prices []float64, sizes []float64) {
+		for i := range prices {
+			stream.ReceiveTradeTick(gotrade.NewTradeDataItem(start.Add(time.Duration(i)*time.Minute), prices[i], sizes[i]))
+		}
+	}
+
+	It("should reject a threshold below the minimum", func() {
+		stream, streamError = gotrade.NewVolumeBarDOHLCVStream(0.0)
+		Expect(stream).To(BeNil())
+		Expect(streamError).To(Equal(gotrade.ErrBarThresholdMustBeGreaterThanZero))
+	})
+
+	Context("and the bars are volume bars", func() {
+		BeforeEach(func() {
+			stream, streamError = gotrade.NewVolumeBarDOHLCVStream(100.0)
+			receiveTrades([]float64{10.0, 12.0, 9.0, 11.0, 10.5}, []float64{40.0, 40.0, 30.0, 50.0, 20.0})
+		})
+
+		It("should complete a bar once the volume reaches the threshold", func() {
+			Expect(streamError).To(BeNil())
+			Expect(len(stream.Data)).To(Equal(1))
+			Expect(stream.Data[0].O()).To(Equal(10.0))
+			Expect(stream.Data[0].H()).To(Equal(12.0))
+			Expect(stream.Data[0].L()).To(Equal(9.0))
+			Expect(stream.Data[0].C()).To(Equal(9.0))
+			Expect(stream.Data[0].V()).To(Equal(110.0))
+		})
+
+		It("should date the bar with the trade which completed it", func() {
+			Expect(stream.Data[0].D()).To(Equal(start.Add(2 * time.Minute)))
+		})
+
+		It("should publish the bar under construction when flushed", func() {
+			stream.Flush()
+			Expect(len(stream.Data)).To(Equal(2))
+			Expect(stream.Data[1].V()).To(Equal(70.0))
+		})
+	})
+
+	Context("and the bars are tick bars", func() {
+		BeforeEach(func() {
+			stream, _ = gotrade.NewTickBarDOHLCVStream(2)
+			receiveTrades([]float64{10.0, 12.0, 9.0, 11.0, 10.5}, []float64{40.0, 40.0, 30.0, 50.0, 20.0})
+		})
+
+		It("should complete a bar every number of trades", func() {
+			Expect(len(stream.Data)).To(Equal(2))
+			Expect(stream.Data[1].O()).To(Equal(9.0))
+			Expect(stream.Data[1].C()).To(Equal(11.0))
+		})
+	})
+
+	Context("and the bars are range bars", func() {
+		BeforeEach(func() {
+			stream, _ = gotrade.NewRangeBarDOHLCVStream(2.0)
+			receiveTrades([]float64{10.0, 11.0, 12.0, 11.5, 9.5}, []float64{10.0, 10.0, 10.0, 10.0, 10.0})
+		})
+
+		It("should complete a bar once its range reaches the threshold", func() {
+			Expect(len(stream.Data)).To(Equal(2))
+			Expect(stream.Data[0].C()).To(Equal(12.0))
+			Expect(stream.Data[1].O()).To(Equal(11.5))
+			Expect(stream.Data[1].L()).To(Equal(9.5))
+		})
+	})
+
+	Context("and the bars are dollar bars built from a finer grained stream", func() {
+		BeforeEach(func() {
+			source := gotrade.NewDailyDOHLCVStream()
+			source.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+			stream, _ = gotrade.NewInformationDOHLCVStreamForStream(source, gotrade.DollarBar, 2000.0)
+			source.ReceiveTick(gotrade.NewDOHLCVDataItem(start, 10.0, 11.0, 9.0, 10.0, 100.0))
+			source.ReceiveTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, 1), 10.0, 13.0, 10.0, 12.0, 100.0))
+			source.ReceiveTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, 2), 12.0, 12.0, 11.0, 11.0, 100.0))
+		})
+
+		It("should complete a bar once the traded value reaches the threshold", func() {
+			Expect(len(stream.Data)).To(Equal(1))
+			Expect(stream.Data[0].H()).To(Equal(13.0))
+			Expect(stream.Data[0].L()).To(Equal(9.0))
+			Expect(stream.Data[0].V()).To(Equal(200.0))
+			Expect(stream.Data[0].D()).To(Equal(start.AddDate(0, 0, 1)))
+		})
+	})
+})
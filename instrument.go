@@ -0,0 +1,116 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrInstrumentSymbolIsEmpty = errors.New("An instrument symbol is required")
+)
+
+// An Instrument describes what the data of a stream represents
+//	- symbol: the instrument's ticker symbol, e.g. J200
+//	- exchange: the exchange the instrument trades on, e.g. JSE
+//	- currency: the currency prices are quoted in, e.g. ZAR
+//	- tickSize: the minimum price increment, 0 when prices are not restricted to ticks
+//	- lotSize: the minimum tradable quantity, 0 when quantities are not restricted to lots
+//	- location: the trading timezone of the exchange
+type Instrument struct {
+	// private variables
+	symbol   string
+	exchange string
+	currency string
+	tickSize float64
+	lotSize  float64
+	location *time.Location
+}
+
+// NewInstrument creates an Instrument, a nil location is treated as UTC
+func NewInstrument(symbol string, exchange string, currency string, tickSize float64, lotSize float64, location *time.Location) (instrument *Instrument, err error) {
+	if symbol == "" {
+		return nil, ErrInstrumentSymbolIsEmpty
+	}
+
+	// the minimum tick size is 0, i.e. unrestricted
+	if tickSize < 0.0 {
+		return nil, errors.New("tickSize is less than the minimum (0)")
+	}
+
+	// the minimum lot size is 0, i.e. unrestricted
+	if lotSize < 0.0 {
+		return nil, errors.New("lotSize is less than the minimum (0)")
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	i := Instrument{symbol: symbol,
+		exchange: exchange,
+		currency: currency,
+		tickSize: tickSize,
+		lotSize:  lotSize,
+		location: location}
+	return &i, nil
+}
+
+func (i *Instrument) Symbol() string {
+	return i.symbol
+}
+
+func (i *Instrument) Exchange() string {
+	return i.exchange
+}
+
+func (i *Instrument) Currency() string {
+	return i.currency
+}
+
+func (i *Instrument) TickSize() float64 {
+	return i.tickSize
+}
+
+func (i *Instrument) LotSize() float64 {
+	return i.lotSize
+}
+
+func (i *Instrument) Location() *time.Location {
+	return i.location
+}
+
+// String returns the exchange qualified symbol, e.g. JSE:J200
+func (i *Instrument) String() string {
+	if i.exchange == "" {
+		return i.symbol
+	}
+	return i.exchange + ":" + i.symbol
+}
+
+// RoundToTick rounds the price to the nearest valid tick
+func (i *Instrument) RoundToTick(price float64) float64 {
+	if i.tickSize == 0.0 {
+		return price
+	}
+	return math.Floor(price/i.tickSize+0.5) * i.tickSize
+}
+
+// RoundToLot rounds the quantity down to a whole number of lots
+func (i *Instrument) RoundToLot(quantity float64) float64 {
+	if i.lotSize == 0.0 {
+		return quantity
+	}
+	// allow for floating point error in quantities that are already whole lots
+	return math.Floor(quantity/i.lotSize+1e-9) * i.lotSize
+}
+
+// InLocation returns the time in the instrument's trading timezone
+func (i *Instrument) InLocation(date time.Time) time.Time {
+	return date.In(i.location)
+}
+
+// InstrumentHolder is implemented by streams which know the instrument their data represents
+type InstrumentHolder interface {
+	Instrument() *Instrument
+}
@@ -0,0 +1,144 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+var _ = Describe("when creating an instrument", func() {
+	var (
+		instrument      *gotrade.Instrument
+		instrumentError error
+		johannesburg    *time.Location
+	)
+
+	BeforeEach(func() {
+		johannesburg = time.FixedZone("SAST", 2*60*60)
+	})
+
+	Context("and the instrument is given an empty symbol", func() {
+		BeforeEach(func() {
+			instrument, instrumentError = gotrade.NewInstrument("", "JSE", "ZAR", 1.0, 1.0, johannesburg)
+		})
+
+		It("should return the appropriate error", func() {
+			Expect(instrument).To(BeNil())
+			Expect(instrumentError).To(Equal(gotrade.ErrInstrumentSymbolIsEmpty))
+		})
+	})
+
+	Context("and the instrument is given a tick size below the minimum", func() {
+		BeforeEach(func() {
+			instrument, instrumentError = gotrade.NewInstrument("J200", "JSE", "ZAR", -1.0, 1.0, johannesburg)
+		})
+
+		It("should return the appropriate error", func() {
+			Expect(instrument).To(BeNil())
+			Expect(instrumentError).To(HaveOccurred())
+		})
+	})
+
+	Context("and the instrument is given a lot size below the minimum", func() {
+		BeforeEach(func() {
+			instrument, instrumentError = gotrade.NewInstrument("J200", "JSE", "ZAR", 1.0, -1.0, johannesburg)
+		})
+
+		It("should return the appropriate error", func() {
+			Expect(instrument).To(BeNil())
+			Expect(instrumentError).To(HaveOccurred())
+		})
+	})
+
+	Context("and the instrument is given no location", func() {
+		BeforeEach(func() {
+			instrument, instrumentError = gotrade.NewInstrument("J200", "", "ZAR", 0.0, 0.0, nil)
+		})
+
+		It("should trade in UTC", func() {
+			Expect(instrumentError).To(BeNil())
+			Expect(instrument.Location()).To(Equal(time.UTC))
+		})
+
+		It("should be labelled with the symbol only", func() {
+			Expect(instrument.String()).To(Equal("J200"))
+		})
+
+		It("should not round prices or quantities", func() {
+			Expect(instrument.RoundToTick(10.123)).To(Equal(10.123))
+			Expect(instrument.RoundToLot(7.5)).To(Equal(7.5))
+		})
+	})
+
+	Context("and the instrument is given valid parameters", func() {
+		BeforeEach(func() {
+			instrument, instrumentError = gotrade.NewInstrument("J200", "JSE", "ZAR", 0.05, 100.0, johannesburg)
+		})
+
+		It("should be labelled with the exchange and symbol", func() {
+			Expect(instrumentError).To(BeNil())
+			Expect(instrument.String()).To(Equal("JSE:J200"))
+			Expect(instrument.Currency()).To(Equal("ZAR"))
+		})
+
+		It("should round prices to the nearest tick", func() {
+			Expect(instrument.RoundToTick(10.12)).To(BeNumerically("~", 10.10, 1e-9))
+			Expect(instrument.RoundToTick(10.13)).To(BeNumerically("~", 10.15, 1e-9))
+		})
+
+		It("should round quantities down to whole lots", func() {
+			Expect(instrument.RoundToLot(250.0)).To(Equal(200.0))
+			Expect(instrument.RoundToLot(300.0)).To(Equal(300.0))
+		})
+
+		It("should give times in its trading timezone", func() {
+			date := instrument.InLocation(time.Date(2014, time.March, 3, 7, 0, 0, 0, time.UTC))
+			Expect(date.Hour()).To(Equal(9))
+		})
+
+		Context("and an inter day stream is created for it", func() {
+			var (
+				stream *gotrade.InterDayDOHLCVStream
+			)
+
+			BeforeEach(func() {
+				stream = gotrade.NewDailyDOHLCVStreamForInstrument(instrument)
+			})
+
+			It("the stream should hold the instrument", func() {
+				Expect(stream.Instrument()).To(Equal(instrument))
+			})
+
+			It("a stream aggregating it should inherit the instrument", func() {
+				weekly := gotrade.NewInterDayDOHLCVStreamForStream(stream, gotrade.WeeklyBar)
+				Expect(weekly.Instrument()).To(Equal(instrument))
+			})
+		})
+
+		Context("and an intra day stream is created for it", func() {
+			var (
+				stream      *gotrade.IntraDayDOHLCVStream
+				streamError error
+			)
+
+			BeforeEach(func() {
+				stream, streamError = gotrade.NewIntraDayDOHLCVStreamForInstrument(instrument, 5, 9*time.Hour, gotrade.BarCloseOnNextTick)
+				// 07:01 UTC is 09:01 in Johannesburg
+				stream.ReceiveTradeTick(gotrade.NewTradeDataItem(time.Date(2014, time.March, 3, 7, 1, 0, 0, time.UTC), 10.0, 100.0))
+				stream.Flush()
+			})
+
+			It("the stream should hold the instrument", func() {
+				Expect(streamError).To(BeNil())
+				Expect(stream.Instrument()).To(Equal(instrument))
+			})
+
+			It("should align the bars to the session start in the instrument's timezone", func() {
+				Expect(len(stream.Data)).To(Equal(1))
+				Expect(stream.Data[0].D().Equal(time.Date(2014, time.March, 3, 9, 0, 0, 0, johannesburg))).To(BeTrue())
+				Expect(stream.Data[0].D().Location()).To(Equal(johannesburg))
+			})
+		})
+	})
+})
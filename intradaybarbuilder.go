@@ -0,0 +1,128 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrBarIntervalMustBeGreaterThanZero = errors.New("Bar interval must be greater than 0")
+	ErrSessionStartOutOfRange           = errors.New("Session start must be within a day")
+)
+
+// BarClosePolicy determines when an intra day bar under construction is considered complete
+type BarClosePolicy int
+
+const (
+	// the bar closes as soon as the clock passes the end of the bar interval, see ReceiveClockTick
+	BarCloseOnTimeBoundary BarClosePolicy = iota
+	// the bar closes when the first trade belonging to a later bar arrives
+	BarCloseOnNextTick
+)
+
+// An IntraDayBarBuilder aggregates trades into DOHLCV bars of a fixed number of minutes.
+// Bars are aligned to the session clock, i.e. bar boundaries fall on the session start plus a
+// multiple of the bar interval, and each bar is dated with the time at which it started.
+// Intervals without any trades do not produce a bar.
+type IntraDayBarBuilder struct {
+	// private variables
+	barInterval    time.Duration
+	sessionStart   time.Duration
+	barClosePolicy BarClosePolicy
+	target         DOHLCVStreamTickReceiver
+	currentBar     *DOHLCVDataItem
+	currentBarEnd  time.Time
+	location       *time.Location
+}
+
+// NewIntraDayBarBuilder creates an IntraDayBarBuilder which publishes bars to the target
+//	- barIntervalInMins: the length of each bar in minutes
+//	- sessionStart: the offset from midnight at which the trading session opens
+//	- barClosePolicy: when a bar under construction is published
+func NewIntraDayBarBuilder(barIntervalInMins int, sessionStart time.Duration, barClosePolicy BarClosePolicy, target DOHLCVStreamTickReceiver) (builder *IntraDayBarBuilder, err error) {
+
+	// the minimum bar interval is 1 minute
+	if barIntervalInMins < 1 {
+		return nil, ErrBarIntervalMustBeGreaterThanZero
+	}
+
+	if sessionStart < 0 || sessionStart >= 24*time.Hour {
+		return nil, ErrSessionStartOutOfRange
+	}
+
+	b := IntraDayBarBuilder{
+		barInterval:    time.Duration(barIntervalInMins) * time.Minute,
+		sessionStart:   sessionStart,
+		barClosePolicy: barClosePolicy,
+		target:         target,
+	}
+	return &b, nil
+}
+
+// SetLocation sets the timezone in which the session start applies and in which bars are dated,
+// by default the timezone of each trade's date is used
+func (b *IntraDayBarBuilder) SetLocation(location *time.Location) {
+	b.location = location
+}
+
+// ReceiveTradeTick consumes a trade, publishing the bar under construction if the trade falls after it
+func (b *IntraDayBarBuilder) ReceiveTradeTick(tickData Trade) {
+	barStart := b.barStart(tickData.D())
+
+	if b.currentBar != nil && !barStart.Equal(b.currentBar.date) {
+		b.publish()
+	}
+
+	if b.currentBar == nil {
+		b.currentBar = NewDOHLCVDataItem(barStart, tickData.P(), tickData.P(), tickData.P(), tickData.P(), tickData.S())
+		b.currentBarEnd = barStart.Add(b.barInterval)
+		return
+	}
+
+	b.currentBar.highPrice = math.Max(b.currentBar.highPrice, tickData.P())
+	b.currentBar.lowPrice = math.Min(b.currentBar.lowPrice, tickData.P())
+	b.currentBar.closePrice = tickData.P()
+	b.currentBar.volumePrice += tickData.S()
+}
+
+// ReceiveClockTick advances the builder's clock, when closing on the time boundary the bar under
+// construction is published once the time reaches the end of the bar's interval
+func (b *IntraDayBarBuilder) ReceiveClockTick(now time.Time) {
+	if b.barClosePolicy != BarCloseOnTimeBoundary || b.currentBar == nil {
+		return
+	}
+
+	if !now.Before(b.currentBarEnd) {
+		b.publish()
+	}
+}
+
+// Flush publishes the bar under construction, for use at the end of a session or of the source data
+func (b *IntraDayBarBuilder) Flush() {
+	if b.currentBar != nil {
+		b.publish()
+	}
+}
+
+func (b *IntraDayBarBuilder) publish() {
+	bar := b.currentBar
+	b.currentBar = nil
+	b.target.ReceiveTick(bar)
+}
+
+// barStart returns the start of the session aligned bar containing the time
+func (b *IntraDayBarBuilder) barStart(date time.Time) time.Time {
+	if b.location != nil {
+		date = date.In(b.location)
+	}
+
+	session := startOfDay(date).Add(b.sessionStart)
+	intervals := date.Sub(session) / b.barInterval
+
+	// round towards the earlier bar for trades before the session start
+	if date.Before(session) && date.Sub(session)%b.barInterval != 0 {
+		intervals--
+	}
+	return session.Add(intervals * b.barInterval)
+}
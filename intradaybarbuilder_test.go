@@ -0,0 +1,90 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+var _ = Describe("when building intra day bars from trades", func() {
+	var (
+		stream       *gotrade.IntraDayDOHLCVStream
+		streamError  error
+		sessionStart time.Time
+	)
+
+	BeforeEach(func() {
+		sessionStart = time.Date(2014, time.March, 3, 9, 0, 0, 0, time.UTC)
+	})
+
+	Context("and the stream is given a bar interval below the minimum", func() {
+		BeforeEach(func() {
+			stream, streamError = gotrade.NewIntraDayDOHLCVStreamForSession(0, 9*time.Hour, gotrade.BarCloseOnTimeBoundary)
+		})
+
+		It("should return the appropriate error", func() {
+			Expect(streamError).To(Equal(gotrade.ErrBarIntervalMustBeGreaterThanZero))
+		})
+	})
+
+	Context("and the bars close on the time boundary", func() {
+		BeforeEach(func() {
+			stream, streamError = gotrade.NewIntraDayDOHLCVStreamForSession(5, 9*time.Hour, gotrade.BarCloseOnTimeBoundary)
+			stream.ReceiveTradeTick(gotrade.NewTradeDataItem(sessionStart.Add(30*time.Second), 10.0, 100.0))
+			stream.ReceiveTradeTick(gotrade.NewTradeDataItem(sessionStart.Add(2*time.Minute), 12.0, 50.0))
+			stream.ReceiveTradeTick(gotrade.NewTradeDataItem(sessionStart.Add(4*time.Minute), 9.0, 25.0))
+			stream.ReceiveTradeTick(gotrade.NewTradeDataItem(sessionStart.Add(4*time.Minute+59*time.Second), 11.0, 25.0))
+		})
+
+		It("should not publish the bar before the boundary is reached", func() {
+			stream.ReceiveClockTick(sessionStart.Add(4*time.Minute + 59*time.Second))
+			Expect(stream.Data).To(BeEmpty())
+		})
+
+		Context("and the clock reaches the boundary", func() {
+			BeforeEach(func() {
+				stream.ReceiveClockTick(sessionStart.Add(5 * time.Minute))
+			})
+
+			It("should publish the bar aggregated from the trades", func() {
+				Expect(len(stream.Data)).To(Equal(1))
+				Expect(stream.Data[0].D()).To(Equal(sessionStart))
+				Expect(stream.Data[0].O()).To(Equal(10.0))
+				Expect(stream.Data[0].H()).To(Equal(12.0))
+				Expect(stream.Data[0].L()).To(Equal(9.0))
+				Expect(stream.Data[0].C()).To(Equal(11.0))
+				Expect(stream.Data[0].V()).To(Equal(200.0))
+			})
+		})
+	})
+
+	Context("and the bars close on the first tick of the next bar", func() {
+		BeforeEach(func() {
+			stream, streamError = gotrade.NewIntraDayDOHLCVStreamForSession(5, 9*time.Hour, gotrade.BarCloseOnNextTick)
+			stream.ReceiveTradeTick(gotrade.NewTradeDataItem(sessionStart.Add(time.Minute), 10.0, 100.0))
+			stream.ReceiveClockTick(sessionStart.Add(6 * time.Minute))
+		})
+
+		It("should ignore the clock", func() {
+			Expect(stream.Data).To(BeEmpty())
+		})
+
+		Context("and a trade for a later bar arrives", func() {
+			BeforeEach(func() {
+				stream.ReceiveTradeTick(gotrade.NewTradeDataItem(sessionStart.Add(12*time.Minute), 11.0, 100.0))
+			})
+
+			It("should publish the completed bar", func() {
+				Expect(len(stream.Data)).To(Equal(1))
+				Expect(stream.Data[0].C()).To(Equal(10.0))
+			})
+
+			It("should align the next bar to the session clock", func() {
+				stream.Flush()
+				Expect(len(stream.Data)).To(Equal(2))
+				Expect(stream.Data[1].D()).To(Equal(sessionStart.Add(10 * time.Minute)))
+			})
+		})
+	})
+})
@@ -0,0 +1,307 @@
+package gotrade
+
+import (
+	"sync"
+	"time"
+)
+
+// PnFPatternType is a classic point and figure signal pattern
+type PnFPatternType int
+
+const (
+	// an X column rises above the top of the previous X column
+	PnFDoubleTopBreakout PnFPatternType = iota
+	// an O column falls below the bottom of the previous O column
+	PnFDoubleBottomBreakdown
+	// an X column rises above the equal tops of the previous two X columns
+	PnFTripleTopBreakout
+	// an O column falls below the equal bottoms of the previous two O columns
+	PnFTripleBottomBreakdown
+	// an X column rises above the equal tops of the previous X column and the X column three before, the top between being lower
+	PnFSpreadTripleTopBreakout
+	// an O column falls below the equal bottoms of the previous O column and the O column three before, the bottom between being higher
+	PnFSpreadTripleBottomBreakdown
+	// an X column rises above the previous X column which itself rose above the X column before it
+	PnFAscendingTripleTopBreakout
+	// an O column falls below the previous O column which itself fell below the O column before it
+	PnFDescendingTripleBottomBreakdown
+	// a triple top breakout, a reversal which does not fall below the previous O column, then a double top breakout
+	PnFBullishCatapult
+	// a triple bottom breakdown, a reversal which does not rise above the previous X column, then a double bottom breakdown
+	PnFBearishCatapult
+	// a triple top breakout by a single box which then reverses
+	PnFBullTrap
+	// a triple bottom breakdown by a single box which then reverses
+	PnFBearTrap
+	// an O column falls through the 45 degree bullish support line
+	PnFBullishSupportLineBreak
+	// an X column rises through the 45 degree bearish resistance line
+	PnFBearishResistanceLineBreak
+)
+
+var pnfPatternNames = []string{"Double Top Breakout",
+	"Double Bottom Breakdown",
+	"Triple Top Breakout",
+	"Triple Bottom Breakdown",
+	"Spread Triple Top Breakout",
+	"Spread Triple Bottom Breakdown",
+	"Ascending Triple Top Breakout",
+	"Descending Triple Bottom Breakdown",
+	"Bullish Catapult",
+	"Bearish Catapult",
+	"Bull Trap",
+	"Bear Trap",
+	"Bullish Support Line Break",
+	"Bearish Resistance Line Break"}
+
+func (t PnFPatternType) String() string {
+	return pnfPatternNames[t]
+}
+
+// A PnFPattern is a pattern detected in a point and figure chart
+//	- columnIndex: the index of the column which completed the pattern
+//	- box: the level of the box which completed the pattern
+//	- price: the price of the box
+//	- date: the date of the source data bar which completed the pattern
+//	- streamBarIndex: the stream bar index of the source data bar which completed the pattern
+type PnFPattern struct {
+	PatternType    PnFPatternType
+	ColumnIndex    int
+	Box            int
+	Price          float64
+	Date           time.Time
+	StreamBarIndex int
+}
+
+type PnFPatternReceiver interface {
+	ReceivePnFPattern(pattern PnFPattern)
+}
+
+// A PnFPatternDetector detects the classic signal patterns as a point and figure stream's columns are built,
+// publishing each pattern to its subscribers and keeping the history of the patterns detected.
+// A breakout or breakdown is reported once, as the most specific pattern it completes, on the box which first
+// passes the previous top or bottom. The 45 degree trend lines start from the first column, a support line one
+// box below the low of a first X column or a resistance line one box above the high of a first O column, and
+// rise or fall a box per column. Once a line is broken the opposite line starts from the extreme of the
+// columns since the broken line started.
+type PnFPatternDetector struct {
+	// private variables
+	stream           *PnFStream
+	patterns         []PnFPattern
+	breakouts        map[int]pnfBreakout
+	hasTrendLine     bool
+	supportLine      bool
+	trendLineColumn  int
+	trendLineBox     int
+	subscribers      []PnFPatternReceiver
+	subscribersMutex sync.Mutex
+}
+
+// pnfBreakout records the triple top or bottom a column broke, for detecting catapults and traps
+type pnfBreakout struct {
+	tripleBreak bool
+	brokenBox   int
+}
+
+// NewPnFPatternDetector creates a detector for the patterns of the point and figure stream
+func NewPnFPatternDetector(stream *PnFStream) *PnFPatternDetector {
+	d := PnFPatternDetector{stream: stream, breakouts: make(map[int]pnfBreakout)}
+	stream.AddEventSubscription(&d)
+	return &d
+}
+
+// Patterns returns a copy of the patterns detected, the latest pattern is last
+func (d *PnFPatternDetector) Patterns() []PnFPattern {
+	patterns := make([]PnFPattern, len(d.patterns))
+	copy(patterns, d.patterns)
+	return patterns
+}
+
+func (d *PnFPatternDetector) AddPatternSubscription(subscriber PnFPatternReceiver) {
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+	d.subscribers = append(d.subscribers, subscriber)
+}
+
+func (d *PnFPatternDetector) RemovePatternSubscription(subscriber PnFPatternReceiver) {
+	d.subscribersMutex.Lock()
+	defer d.subscribersMutex.Unlock()
+	subscribers := make([]PnFPatternReceiver, 0, len(d.subscribers))
+	for _, existing := range d.subscribers {
+		if existing != subscriber {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	d.subscribers = subscribers
+}
+
+// ReceivePnFEvent consumes a change to the point and figure chart, detecting the patterns it completes
+func (d *PnFPatternDetector) ReceivePnFEvent(event PnFEvent) {
+	if !d.hasTrendLine {
+		d.startTrendLine(event.ColumnIndex, event.Column.Direction == XColumn)
+	}
+
+	if event.EventType == PnFNewColumn {
+		d.detectTrap(event)
+		return
+	}
+
+	d.detectBreakout(event)
+	d.detectTrendLineBreak(event)
+}
+
+// detectBreakout detects the breakout patterns completed by an X column rising above a previous top,
+// or the breakdown patterns completed by an O column falling below a previous bottom
+func (d *PnFPatternDetector) detectBreakout(event PnFEvent) {
+	c := event.ColumnIndex
+	if c < 2 {
+		return
+	}
+
+	rising := event.Column.Direction == XColumn
+	previous := d.extreme(c-2, rising)
+	if event.Box != previous+d.direction(rising) {
+		return
+	}
+
+	patternType := PnFDoubleTopBreakout
+	breakout := pnfBreakout{brokenBox: previous}
+	switch {
+	case d.isCatapult(c, rising):
+		patternType = PnFBullishCatapult
+	case c >= 4 && d.extreme(c-4, rising) == previous:
+		patternType = PnFTripleTopBreakout
+		breakout.tripleBreak = true
+	case c >= 6 && d.isSpreadTriple(c, rising):
+		patternType = PnFSpreadTripleTopBreakout
+	case c >= 4 && d.beyond(previous, d.extreme(c-4, rising), rising):
+		patternType = PnFAscendingTripleTopBreakout
+	}
+	d.breakouts[c] = breakout
+
+	if !rising {
+		// each bearish pattern follows its bullish counterpart
+		patternType++
+	}
+	d.publish(patternType, event)
+}
+
+// isCatapult returns true when the column before the previous column was a triple breakout and the reversal
+// between them did not break the column before it
+func (d *PnFPatternDetector) isCatapult(c int, rising bool) bool {
+	if c < 3 || !d.breakouts[c-2].tripleBreak {
+		return false
+	}
+	return !d.beyond(d.extreme(c-1, !rising), d.extreme(c-3, !rising), !rising)
+}
+
+// isSpreadTriple returns true when the previous column in the direction shares the extreme which is broken with
+// the column in the direction three before, and the column between them does not reach it
+func (d *PnFPatternDetector) isSpreadTriple(c int, rising bool) bool {
+	first, second, third := d.extreme(c-6, rising), d.extreme(c-4, rising), d.extreme(c-2, rising)
+	return first == third && d.beyond(third, second, rising)
+}
+
+// detectTrap detects a reversal of a column which broke a triple top or bottom by a single box
+func (d *PnFPatternDetector) detectTrap(event PnFEvent) {
+	c := event.ColumnIndex
+	breakout, ok := d.breakouts[c-1]
+	if !ok || !breakout.tripleBreak {
+		return
+	}
+
+	// the trap is sprung by a reversal against the column, i.e. an O column after a rising breakout
+	rising := event.Column.Direction == OColumn
+	if d.extreme(c-1, rising) != breakout.brokenBox+d.direction(rising) {
+		return
+	}
+
+	patternType := PnFBullTrap
+	if !rising {
+		patternType = PnFBearTrap
+	}
+	d.publish(patternType, event)
+}
+
+// detectTrendLineBreak detects a column passing through the active 45 degree trend line, starting the opposite line
+func (d *PnFPatternDetector) detectTrendLineBreak(event PnFEvent) {
+	c := event.ColumnIndex
+	level := d.trendLineBox + (c - d.trendLineColumn)
+	if !d.supportLine {
+		level = d.trendLineBox - (c - d.trendLineColumn)
+	}
+
+	switch {
+	case d.supportLine && event.Column.Direction == OColumn && event.Box <= level:
+		d.publish(PnFBullishSupportLineBreak, event)
+		d.restartTrendLine(c, false)
+	case !d.supportLine && event.Column.Direction == XColumn && event.Box >= level:
+		d.publish(PnFBearishResistanceLineBreak, event)
+		d.restartTrendLine(c, true)
+	}
+}
+
+// startTrendLine starts a support line below the low of the column, or a resistance line above its high
+func (d *PnFPatternDetector) startTrendLine(c int, supportLine bool) {
+	column := d.stream.columns[c]
+	d.hasTrendLine = true
+	d.supportLine = supportLine
+	d.trendLineColumn = c
+	if supportLine {
+		d.trendLineBox = column.LowBox - 1
+	} else {
+		d.trendLineBox = column.HighBox + 1
+	}
+}
+
+// restartTrendLine starts the opposite trend line from the extreme column since the broken line started
+func (d *PnFPatternDetector) restartTrendLine(c int, supportLine bool) {
+	anchor := d.trendLineColumn
+	for i := d.trendLineColumn; i <= c; i++ {
+		if d.beyond(d.extreme(i, !supportLine), d.extreme(anchor, !supportLine), !supportLine) {
+			anchor = i
+		}
+	}
+	d.startTrendLine(anchor, supportLine)
+}
+
+// extreme returns the top box of the column when rising, otherwise its bottom box
+func (d *PnFPatternDetector) extreme(c int, rising bool) int {
+	if rising {
+		return d.stream.columns[c].HighBox
+	}
+	return d.stream.columns[c].LowBox
+}
+
+// beyond returns true when the box is above the other box when rising, otherwise below it
+func (d *PnFPatternDetector) beyond(box int, other int, rising bool) bool {
+	if rising {
+		return box > other
+	}
+	return box < other
+}
+
+func (d *PnFPatternDetector) direction(rising bool) int {
+	if rising {
+		return 1
+	}
+	return -1
+}
+
+func (d *PnFPatternDetector) publish(patternType PnFPatternType, event PnFEvent) {
+	pattern := PnFPattern{PatternType: patternType,
+		ColumnIndex:    event.ColumnIndex,
+		Box:            event.Box,
+		Price:          event.Price,
+		Date:           event.Date,
+		StreamBarIndex: event.StreamBarIndex}
+	d.patterns = append(d.patterns, pattern)
+
+	d.subscribersMutex.Lock()
+	subscribers := d.subscribers
+	d.subscribersMutex.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.ReceivePnFPattern(pattern)
+	}
+}
@@ -0,0 +1,167 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+type pnfPatternRecorder struct {
+	patterns []gotrade.PnFPattern
+}
+
+func (r *pnfPatternRecorder) ReceivePnFPattern(pattern gotrade.PnFPattern) {
+	r.patterns = append(r.patterns, pattern)
+}
+
+var _ = Describe("when detecting point and figure patterns", func() {
+	var (
+		source   *gotrade.InterDayDOHLCVStream
+		detector *gotrade.PnFPatternDetector
+		recorder *pnfPatternRecorder
+	)
+
+	BeforeEach(func() {
+		source = gotrade.NewDailyDOHLCVStream()
+		source.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		boxSize, _ := gotrade.NewFixedPnFBoxSize(1.0)
+		stream, _ := gotrade.NewPnFStreamForStream(source, boxSize, 3, gotrade.PnFCloseOnly)
+		detector = gotrade.NewPnFPatternDetector(stream)
+		recorder = &pnfPatternRecorder{}
+		detector.AddPatternSubscription(recorder)
+	})
+
+	receiveCloses := func(closes ...float64) {
+		for day, closePrice := range closes {
+			source.ReceiveTick(newPnFBar(day+1, closePrice, closePrice, closePrice))
+		}
+	}
+
+	// patternsOfType filters out the patterns of the other types, e.g. the trend line breaks of a flat market
+	patternsOfType := func(patternTypes ...gotrade.PnFPatternType) []gotrade.PnFPattern {
+		var patterns []gotrade.PnFPattern
+		for _, pattern := range detector.Patterns() {
+			for _, patternType := range patternTypes {
+				if pattern.PatternType == patternType {
+					patterns = append(patterns, pattern)
+				}
+			}
+		}
+		return patterns
+	}
+
+	Context("and an X column rises above the previous X column", func() {
+		BeforeEach(func() {
+			receiveCloses(10.0, 13.0, 10.0, 14.0)
+		})
+
+		It("should detect a double top breakout on the box above the previous top", func() {
+			patterns := patternsOfType(gotrade.PnFDoubleTopBreakout)
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].ColumnIndex).To(Equal(2))
+			Expect(patterns[0].Box).To(Equal(14))
+			Expect(patterns[0].Price).To(Equal(14.0))
+			Expect(patterns[0].Date).To(Equal(time.Date(2013, time.January, 4, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should publish the patterns to the subscribers", func() {
+			Expect(recorder.patterns).To(Equal(detector.Patterns()))
+		})
+	})
+
+	Context("and an X column rises above two equal tops then reverses", func() {
+		BeforeEach(func() {
+			receiveCloses(10.0, 13.0, 10.0, 13.0, 10.0, 14.0, 11.0)
+		})
+
+		It("should detect a triple top breakout", func() {
+			patterns := patternsOfType(gotrade.PnFDoubleTopBreakout, gotrade.PnFTripleTopBreakout)
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].PatternType).To(Equal(gotrade.PnFTripleTopBreakout))
+			Expect(patterns[0].ColumnIndex).To(Equal(4))
+			Expect(patterns[0].Price).To(Equal(14.0))
+		})
+
+		It("should detect a bull trap when the breakout by a single box reverses", func() {
+			patterns := patternsOfType(gotrade.PnFBullTrap)
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].ColumnIndex).To(Equal(5))
+		})
+	})
+
+	Context("and an O column falls below two equal bottoms", func() {
+		BeforeEach(func() {
+			receiveCloses(10.0, 7.0, 10.0, 7.0, 10.0, 6.0)
+		})
+
+		It("should detect a triple bottom breakdown", func() {
+			patterns := patternsOfType(gotrade.PnFDoubleBottomBreakdown, gotrade.PnFTripleBottomBreakdown)
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].PatternType).To(Equal(gotrade.PnFTripleBottomBreakdown))
+			Expect(patterns[0].ColumnIndex).To(Equal(4))
+			Expect(patterns[0].Price).To(Equal(6.0))
+		})
+	})
+
+	Context("and a triple top breakout is followed by a shallow reversal and a double top breakout", func() {
+		BeforeEach(func() {
+			receiveCloses(10.0, 13.0, 10.0, 13.0, 10.0, 15.0, 12.0, 16.0)
+		})
+
+		It("should detect a bullish catapult and no bull trap", func() {
+			patterns := patternsOfType(gotrade.PnFBullishCatapult)
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].ColumnIndex).To(Equal(6))
+			Expect(patterns[0].Price).To(Equal(16.0))
+			Expect(patternsOfType(gotrade.PnFBullTrap)).To(BeEmpty())
+		})
+	})
+
+	Context("and an X column rises above equal tops separated by a lower top", func() {
+		BeforeEach(func() {
+			receiveCloses(10.0, 13.0, 9.0, 12.0, 8.0, 13.0, 9.0, 14.0)
+		})
+
+		It("should detect a spread triple top breakout", func() {
+			patterns := patternsOfType(gotrade.PnFSpreadTripleTopBreakout)
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].ColumnIndex).To(Equal(6))
+			Expect(patterns[0].Price).To(Equal(14.0))
+		})
+	})
+
+	Context("and an X column rises above a series of rising tops", func() {
+		BeforeEach(func() {
+			receiveCloses(10.0, 12.0, 9.0, 13.0, 10.0, 14.0)
+		})
+
+		It("should detect an ascending triple top breakout", func() {
+			patterns := patternsOfType(gotrade.PnFAscendingTripleTopBreakout)
+			Expect(len(patterns)).To(Equal(1))
+			Expect(patterns[0].ColumnIndex).To(Equal(4))
+			Expect(patterns[0].Price).To(Equal(14.0))
+		})
+	})
+
+	Context("and the columns pass through the 45 degree trend lines", func() {
+		BeforeEach(func() {
+			receiveCloses(10.0, 13.0, 10.0, 13.0)
+		})
+
+		It("should detect the break of the support line then of the resistance line which replaces it", func() {
+			patterns := patternsOfType(gotrade.PnFBullishSupportLineBreak, gotrade.PnFBearishResistanceLineBreak)
+			Expect(len(patterns)).To(Equal(2))
+			Expect(patterns[0].PatternType).To(Equal(gotrade.PnFBullishSupportLineBreak))
+			Expect(patterns[0].ColumnIndex).To(Equal(1))
+			Expect(patterns[0].Box).To(Equal(11))
+			Expect(patterns[1].PatternType).To(Equal(gotrade.PnFBearishResistanceLineBreak))
+			Expect(patterns[1].ColumnIndex).To(Equal(2))
+			Expect(patterns[1].Box).To(Equal(12))
+		})
+	})
+
+	It("should name the pattern types", func() {
+		Expect(gotrade.PnFBullishCatapult.String()).To(Equal("Bullish Catapult"))
+	})
+})
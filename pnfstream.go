@@ -1 +1,415 @@
-package gotrade
\ No newline at end of file
+package gotrade
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+var (
+	ErrBoxSizeMustBeGreaterThanZero = errors.New("Box size must be greater than zero")
+	ErrPriceMustBeGreaterThanZero   = errors.New("Price must be greater than zero for percentage box sizes")
+)
+
+// PnFBoxSizeMethod determines how the price levels of a point and figure chart's boxes are spaced
+type PnFBoxSizeMethod int
+
+const (
+	// every box covers the same fixed price range
+	FixedPnFBoxSize PnFBoxSizeMethod = iota
+	// every box covers the same percentage of its price, i.e. a log scale, so prices must be greater than zero
+	PercentagePnFBoxSize
+	// every box covers the average true range of the source data's first bars, the chart starts once it is known
+	AtrPnFBoxSize
+)
+
+// PnFConstructionMethod determines which prices of a bar are plotted
+type PnFConstructionMethod int
+
+const (
+	// the close of each bar is plotted
+	PnFCloseOnly PnFConstructionMethod = iota
+	// the high of each bar extends a column of Xs and the low a column of Os, a reversal is only
+	// considered when the column is not extended
+	PnFHighLow
+)
+
+// PnFColumnDirection is the direction of a point and figure column
+type PnFColumnDirection int
+
+const (
+	// a rising column of Xs
+	XColumn PnFColumnDirection = iota
+	// a falling column of Os
+	OColumn
+)
+
+// A PnFBoxSize describes the spacing of the boxes of a point and figure chart
+type PnFBoxSize struct {
+	// private variables
+	method     PnFBoxSizeMethod
+	size       float64
+	percentage float64
+	atrPeriod  int
+}
+
+// NewFixedPnFBoxSize creates boxes which each cover the price range boxSize
+func NewFixedPnFBoxSize(boxSize float64) (pnfBoxSize PnFBoxSize, err error) {
+	if boxSize <= 0.0 {
+		return PnFBoxSize{}, ErrBoxSizeMustBeGreaterThanZero
+	}
+	return PnFBoxSize{method: FixedPnFBoxSize, size: boxSize}, nil
+}
+
+// NewPercentagePnFBoxSize creates boxes which each cover percentage percent of their price, e.g. 1.0 for 1%
+func NewPercentagePnFBoxSize(percentage float64) (pnfBoxSize PnFBoxSize, err error) {
+	if percentage <= 0.0 {
+		return PnFBoxSize{}, ErrBoxSizeMustBeGreaterThanZero
+	}
+	return PnFBoxSize{method: PercentagePnFBoxSize, percentage: percentage}, nil
+}
+
+// NewAtrPnFBoxSize creates boxes which each cover the average true range of the first atrPeriod bars
+func NewAtrPnFBoxSize(atrPeriod int) (pnfBoxSize PnFBoxSize, err error) {
+	// the minimum atrPeriod is 1
+	if atrPeriod < 1 {
+		return PnFBoxSize{}, errors.New("atrPeriod is less than the minimum (1)")
+	}
+	return PnFBoxSize{method: AtrPnFBoxSize, atrPeriod: atrPeriod}, nil
+}
+
+// A PnFColumn is a column of a point and figure chart, boxes are identified by their level where the box
+// at level n has the price BoxPrice(n)
+type PnFColumn struct {
+	Direction     PnFColumnDirection
+	LowBox        int
+	HighBox       int
+	LowPrice      float64
+	HighPrice     float64
+	StartDate     time.Time
+	EndDate       time.Time
+	StartBarIndex int
+	EndBarIndex   int
+}
+
+// BoxCount returns the number of boxes in the column
+func (c PnFColumn) BoxCount() int {
+	return c.HighBox - c.LowBox + 1
+}
+
+// PnFEventType is the type of change to a point and figure chart
+type PnFEventType int
+
+const (
+	// a new column was started, its boxes are each published as a PnFBoxAdded event
+	PnFNewColumn PnFEventType = iota
+	// a box was added to the latest column
+	PnFBoxAdded
+)
+
+// A PnFEvent describes a change to a point and figure chart
+//	- column: the column as of the event
+//	- columnIndex: the index of the column in the column history, the first column is 0
+//	- box: the level of the box added, the first box of a new column
+//	- price: the price of the box
+//	- date: the date of the source data bar which caused the change
+//	- streamBarIndex: the stream bar index of the source data bar which caused the change
+type PnFEvent struct {
+	EventType      PnFEventType
+	Column         PnFColumn
+	ColumnIndex    int
+	Box            int
+	Price          float64
+	Date           time.Time
+	StreamBarIndex int
+}
+
+type PnFEventReceiver interface {
+	ReceivePnFEvent(event PnFEvent)
+}
+
+// A PnFStream builds a point and figure chart from the bars of a source data stream, publishing each new
+// column and box to its subscribers and keeping the history of its columns
+type PnFStream struct {
+	// private variables
+	boxSize            PnFBoxSize
+	reversal           int
+	constructionMethod PnFConstructionMethod
+	boxPriceRange      float64
+	columns            []PnFColumn
+	referenceBox       int
+	hasReference       bool
+	subscribers        []PnFEventReceiver
+	subscribersMutex   sync.Mutex
+
+	// the true ranges of the first bars, for sizing the boxes by the average true range
+	trueRangeSum  float64
+	trueRangeBars int
+	previousClose float64
+}
+
+// NewPnFStream creates a point and figure stream, a reversal of reversal boxes against the latest column
+// starts a new column
+func NewPnFStream(boxSize PnFBoxSize, reversal int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	// the minimum reversal is 1
+	if reversal < 1 {
+		return nil, errors.New("reversal is less than the minimum (1)")
+	}
+
+	s := PnFStream{boxSize: boxSize,
+		reversal:           reversal,
+		constructionMethod: constructionMethod}
+	switch boxSize.method {
+	case FixedPnFBoxSize:
+		s.boxPriceRange = boxSize.size
+	case PercentagePnFBoxSize:
+		s.boxPriceRange = math.Log(1.0 + boxSize.percentage/100.0)
+	default:
+		if boxSize.atrPeriod < 1 {
+			return nil, ErrBoxSizeMustBeGreaterThanZero
+		}
+	}
+	return &s, nil
+}
+
+// NewDefaultPnFStream creates a point and figure stream of the closes, box sized by a 14 bar average true range, with
+// a reversal of 3 boxes
+func NewDefaultPnFStream() (stream *PnFStream, err error) {
+	boxSize, _ := NewAtrPnFBoxSize(14)
+	return NewPnFStream(boxSize, 3, PnFCloseOnly)
+}
+
+// NewPnFStreamForStream creates a point and figure stream which subscribes to the source data stream
+func NewPnFStreamForStream(sourceStream DOHLCVStreamSubscriber, boxSize PnFBoxSize, reversal int, constructionMethod PnFConstructionMethod) (stream *PnFStream, err error) {
+	s, err := NewPnFStream(boxSize, reversal, constructionMethod)
+	if err != nil {
+		return s, err
+	}
+	sourceStream.AddTickSubscription(s)
+	return s, nil
+}
+
+func NewDefaultPnFStreamForStream(sourceStream DOHLCVStreamSubscriber) (stream *PnFStream, err error) {
+	s, err := NewDefaultPnFStream()
+	sourceStream.AddTickSubscription(s)
+	return s, err
+}
+
+func (s *PnFStream) Reversal() int {
+	return s.reversal
+}
+
+// BoxSizeKnown returns false while an average true range box size is being measured
+func (s *PnFStream) BoxSizeKnown() bool {
+	return s.boxPriceRange > 0.0
+}
+
+// BoxPrice returns the price of the box at the level
+func (s *PnFStream) BoxPrice(box int) float64 {
+	if s.boxSize.method == PercentagePnFBoxSize {
+		return math.Exp(float64(box) * s.boxPriceRange)
+	}
+	return float64(box) * s.boxPriceRange
+}
+
+// Columns returns a copy of the column history, the latest column is last
+func (s *PnFStream) Columns() []PnFColumn {
+	columns := make([]PnFColumn, len(s.columns))
+	copy(columns, s.columns)
+	return columns
+}
+
+func (s *PnFStream) ColumnCount() int {
+	return len(s.columns)
+}
+
+// ColumnFromLatest returns the column offset columns before the latest column, an offset of 0 is the latest column
+func (s *PnFStream) ColumnFromLatest(offset int) (column PnFColumn, ok bool) {
+	if offset < 0 || offset >= len(s.columns) {
+		return PnFColumn{}, false
+	}
+	return s.columns[len(s.columns)-1-offset], true
+}
+
+func (s *PnFStream) AddEventSubscription(subscriber PnFEventReceiver) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+	s.subscribers = append(s.subscribers, subscriber)
+}
+
+func (s *PnFStream) RemoveEventSubscription(subscriber PnFEventReceiver) {
+	s.subscribersMutex.Lock()
+	defer s.subscribersMutex.Unlock()
+	subscribers := make([]PnFEventReceiver, 0, len(s.subscribers))
+	for _, existing := range s.subscribers {
+		if existing != subscriber {
+			subscribers = append(subscribers, existing)
+		}
+	}
+	s.subscribers = subscribers
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, adding boxes to the latest column or starting a new column.
+// A bar the stream can not plot is dropped, use ValidateAndReceiveDOHLCVTick to learn why a bar was rejected.
+func (s *PnFStream) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	s.ValidateAndReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// ValidateAndReceiveDOHLCVTick consumes a source data DOHLCV price tick as ReceiveDOHLCVTick does, returning
+// ErrPriceMustBeGreaterThanZero, without plotting the bar, when the boxes are percentage sized and a plotted price
+// is not greater than zero
+func (s *PnFStream) ValidateAndReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) error {
+	if s.boxSize.method == PercentagePnFBoxSize {
+		low := tickData.C()
+		if s.constructionMethod == PnFHighLow {
+			low = math.Min(low, tickData.L())
+		}
+		if low <= 0.0 {
+			return ErrPriceMustBeGreaterThanZero
+		}
+	}
+
+	s.receiveDOHLCVTick(tickData, streamBarIndex)
+	return nil
+}
+
+func (s *PnFStream) receiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	if !s.BoxSizeKnown() {
+		s.measureBoxSize(tickData)
+		if !s.BoxSizeKnown() {
+			return
+		}
+	}
+
+	high, low := tickData.C(), tickData.C()
+	if s.constructionMethod == PnFHighLow {
+		high, low = tickData.H(), tickData.L()
+	}
+
+	// the first price is the reference from which the first column must move a box
+	if !s.hasReference {
+		s.referenceBox = s.nearestBox(tickData.C())
+		s.hasReference = true
+		return
+	}
+
+	if len(s.columns) == 0 {
+		if up := s.boxAtOrBelow(high); up > s.referenceBox {
+			s.startColumn(XColumn, s.referenceBox+1, up, tickData, streamBarIndex)
+		} else if down := s.boxAtOrAbove(low); down < s.referenceBox {
+			s.startColumn(OColumn, s.referenceBox-1, down, tickData, streamBarIndex)
+		}
+		return
+	}
+
+	column := &s.columns[len(s.columns)-1]
+	if column.Direction == XColumn {
+		if up := s.boxAtOrBelow(high); up > column.HighBox {
+			s.extendColumn(up, tickData, streamBarIndex)
+		} else if down := s.boxAtOrAbove(low); down <= column.HighBox-s.reversal {
+			s.startColumn(OColumn, column.HighBox-1, down, tickData, streamBarIndex)
+		}
+	} else {
+		if down := s.boxAtOrAbove(low); down < column.LowBox {
+			s.extendColumn(down, tickData, streamBarIndex)
+		} else if up := s.boxAtOrBelow(high); up >= column.LowBox+s.reversal {
+			s.startColumn(XColumn, column.LowBox+1, up, tickData, streamBarIndex)
+		}
+	}
+}
+
+// measureBoxSize accumulates the true range of the bar, setting the box size once the average true range is known
+func (s *PnFStream) measureBoxSize(tickData DOHLCV) {
+	trueRange := tickData.H() - tickData.L()
+	if s.trueRangeBars > 0 {
+		trueRange = math.Max(trueRange, math.Max(math.Abs(tickData.H()-s.previousClose), math.Abs(tickData.L()-s.previousClose)))
+	}
+	s.previousClose = tickData.C()
+	s.trueRangeSum += trueRange
+	s.trueRangeBars++
+
+	if s.trueRangeBars == s.boxSize.atrPeriod && s.trueRangeSum > 0.0 {
+		s.boxPriceRange = s.trueRangeSum / float64(s.trueRangeBars)
+	}
+}
+
+// startColumn starts a new column from the box firstBox to the box lastBox
+func (s *PnFStream) startColumn(direction PnFColumnDirection, firstBox int, lastBox int, tickData DOHLCV, streamBarIndex int) {
+	column := PnFColumn{Direction: direction,
+		LowBox:        firstBox,
+		HighBox:       firstBox,
+		StartDate:     tickData.D(),
+		EndDate:       tickData.D(),
+		StartBarIndex: streamBarIndex,
+		EndBarIndex:   streamBarIndex}
+	column.LowPrice = s.BoxPrice(firstBox)
+	column.HighPrice = column.LowPrice
+	s.columns = append(s.columns, column)
+
+	s.publish(PnFNewColumn, firstBox, tickData, streamBarIndex)
+	s.publish(PnFBoxAdded, firstBox, tickData, streamBarIndex)
+	s.extendColumn(lastBox, tickData, streamBarIndex)
+}
+
+// extendColumn adds boxes to the latest column until it reaches the box lastBox
+func (s *PnFStream) extendColumn(lastBox int, tickData DOHLCV, streamBarIndex int) {
+	column := &s.columns[len(s.columns)-1]
+	column.EndDate = tickData.D()
+	column.EndBarIndex = streamBarIndex
+
+	for column.HighBox < lastBox {
+		column.HighBox++
+		column.HighPrice = s.BoxPrice(column.HighBox)
+		s.publish(PnFBoxAdded, column.HighBox, tickData, streamBarIndex)
+	}
+
+	for column.LowBox > lastBox {
+		column.LowBox--
+		column.LowPrice = s.BoxPrice(column.LowBox)
+		s.publish(PnFBoxAdded, column.LowBox, tickData, streamBarIndex)
+	}
+}
+
+func (s *PnFStream) publish(eventType PnFEventType, box int, tickData DOHLCV, streamBarIndex int) {
+	s.subscribersMutex.Lock()
+	subscribers := s.subscribers
+	s.subscribersMutex.Unlock()
+
+	if len(subscribers) == 0 {
+		return
+	}
+
+	event := PnFEvent{EventType: eventType,
+		Column:         s.columns[len(s.columns)-1],
+		ColumnIndex:    len(s.columns) - 1,
+		Box:            box,
+		Price:          s.BoxPrice(box),
+		Date:           tickData.D(),
+		StreamBarIndex: streamBarIndex}
+	for _, subscriber := range subscribers {
+		subscriber.ReceivePnFEvent(event)
+	}
+}
+
+// boxLevel returns the fractional box level of the price
+func (s *PnFStream) boxLevel(price float64) float64 {
+	if s.boxSize.method == PercentagePnFBoxSize {
+		return math.Log(price) / s.boxPriceRange
+	}
+	return price / s.boxPriceRange
+}
+
+// boxAtOrBelow returns the highest box the price reaches, allowing for floating point error at box boundaries
+func (s *PnFStream) boxAtOrBelow(price float64) int {
+	return int(math.Floor(s.boxLevel(price) + 1e-9))
+}
+
+// boxAtOrAbove returns the lowest box the price reaches, allowing for floating point error at box boundaries
+func (s *PnFStream) boxAtOrAbove(price float64) int {
+	return int(math.Ceil(s.boxLevel(price) - 1e-9))
+}
+
+func (s *PnFStream) nearestBox(price float64) int {
+	return int(math.Floor(s.boxLevel(price) + 0.5))
+}
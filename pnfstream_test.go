@@ -1 +1,157 @@
-package gotrade_test
\ No newline at end of file
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+type pnfEventRecorder struct {
+	events []gotrade.PnFEvent
+}
+
+func (r *pnfEventRecorder) ReceivePnFEvent(event gotrade.PnFEvent) {
+	r.events = append(r.events, event)
+}
+
+func newPnFBar(day int, high float64, low float64, closePrice float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Date(2013, time.January, day, 0, 0, 0, 0, time.UTC), closePrice, high, low, closePrice, 100.0)
+}
+
+var _ = Describe("when building a point and figure stream", func() {
+	var (
+		source   *gotrade.InterDayDOHLCVStream
+		stream   *gotrade.PnFStream
+		recorder *pnfEventRecorder
+		err      error
+	)
+
+	BeforeEach(func() {
+		source = gotrade.NewDailyDOHLCVStream()
+		source.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+		recorder = &pnfEventRecorder{}
+	})
+
+	Context("and the stream is given a reversal below the minimum", func() {
+		BeforeEach(func() {
+			boxSize, _ := gotrade.NewFixedPnFBoxSize(1.0)
+			stream, err = gotrade.NewPnFStreamForStream(source, boxSize, 0, gotrade.PnFCloseOnly)
+		})
+
+		It("should return the appropriate error", func() {
+			Expect(stream).To(BeNil())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("should reject a box size below the minimum", func() {
+		_, err = gotrade.NewFixedPnFBoxSize(0.0)
+		Expect(err).To(Equal(gotrade.ErrBoxSizeMustBeGreaterThanZero))
+		_, err = gotrade.NewPercentagePnFBoxSize(-1.0)
+		Expect(err).To(Equal(gotrade.ErrBoxSizeMustBeGreaterThanZero))
+		_, err = gotrade.NewAtrPnFBoxSize(0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("and the stream plots the closes with a fixed box size", func() {
+		BeforeEach(func() {
+			boxSize, _ := gotrade.NewFixedPnFBoxSize(1.0)
+			stream, err = gotrade.NewPnFStreamForStream(source, boxSize, 3, gotrade.PnFCloseOnly)
+			stream.AddEventSubscription(recorder)
+			for day, closePrice := range []float64{10.0, 11.2, 12.5, 13.0, 11.9, 9.8, 10.5, 13.1} {
+				source.ReceiveTick(newPnFBar(day+1, closePrice+5.0, closePrice-5.0, closePrice))
+			}
+		})
+
+		It("should reverse once the price moves the reversal amount against the column", func() {
+			Expect(err).To(BeNil())
+			Expect(stream.ColumnCount()).To(Equal(3))
+			columns := stream.Columns()
+			Expect(columns[0].Direction).To(Equal(gotrade.XColumn))
+			Expect(columns[0].LowBox).To(Equal(11))
+			Expect(columns[0].HighBox).To(Equal(13))
+			Expect(columns[1].Direction).To(Equal(gotrade.OColumn))
+			Expect(columns[1].LowBox).To(Equal(10))
+			Expect(columns[1].HighBox).To(Equal(12))
+			Expect(columns[2].Direction).To(Equal(gotrade.XColumn))
+			Expect(columns[2].HighPrice).To(Equal(13.0))
+		})
+
+		It("should record the bars spanned by each column", func() {
+			column, ok := stream.ColumnFromLatest(1)
+			Expect(ok).To(BeTrue())
+			Expect(column.StartBarIndex).To(Equal(6))
+			Expect(column.EndBarIndex).To(Equal(6))
+			Expect(column.BoxCount()).To(Equal(3))
+		})
+
+		It("should publish an event for every new column and box", func() {
+			Expect(len(recorder.events)).To(Equal(12))
+			Expect(recorder.events[0].EventType).To(Equal(gotrade.PnFNewColumn))
+			Expect(recorder.events[1].EventType).To(Equal(gotrade.PnFBoxAdded))
+			Expect(recorder.events[1].Box).To(Equal(11))
+			Expect(recorder.events[4].EventType).To(Equal(gotrade.PnFNewColumn))
+			Expect(recorder.events[4].ColumnIndex).To(Equal(1))
+			Expect(recorder.events[4].StreamBarIndex).To(Equal(6))
+		})
+	})
+
+	Context("and the stream plots the highs and lows", func() {
+		BeforeEach(func() {
+			boxSize, _ := gotrade.NewFixedPnFBoxSize(1.0)
+			stream, _ = gotrade.NewPnFStreamForStream(source, boxSize, 3, gotrade.PnFHighLow)
+			source.ReceiveTick(newPnFBar(1, 10.0, 10.0, 10.0))
+			source.ReceiveTick(newPnFBar(2, 12.0, 10.0, 11.0))
+			// extends the column of Xs, so the reversal in the low is not considered
+			source.ReceiveTick(newPnFBar(3, 13.0, 8.0, 9.0))
+		})
+
+		It("should extend the column in preference to reversing it", func() {
+			Expect(stream.ColumnCount()).To(Equal(1))
+			column, _ := stream.ColumnFromLatest(0)
+			Expect(column.HighBox).To(Equal(13))
+		})
+	})
+
+	Context("and the stream has a percentage box size", func() {
+		BeforeEach(func() {
+			boxSize, _ := gotrade.NewPercentagePnFBoxSize(10.0)
+			stream, _ = gotrade.NewPnFStreamForStream(source, boxSize, 1, gotrade.PnFCloseOnly)
+			source.ReceiveTick(newPnFBar(1, 100.0, 100.0, 100.0))
+			source.ReceiveTick(newPnFBar(2, 125.0, 125.0, 125.0))
+		})
+
+		It("should space the boxes by the percentage", func() {
+			column, _ := stream.ColumnFromLatest(0)
+			Expect(column.BoxCount()).To(Equal(2))
+			Expect(stream.BoxPrice(column.HighBox) / stream.BoxPrice(column.HighBox-1)).To(BeNumerically("~", 1.1, 1e-9))
+		})
+
+		It("should reject a price which is not greater than zero", func() {
+			Expect(stream.ValidateAndReceiveDOHLCVTick(newPnFBar(3, 0.0, 0.0, 0.0), 3)).To(Equal(gotrade.ErrPriceMustBeGreaterThanZero))
+			source.ReceiveTick(newPnFBar(4, 1.0, -1.0, -1.0))
+			column, _ := stream.ColumnFromLatest(0)
+			Expect(stream.ColumnCount()).To(Equal(1))
+			Expect(column.BoxCount()).To(Equal(2))
+		})
+	})
+
+	Context("and the stream sizes its boxes by the average true range", func() {
+		BeforeEach(func() {
+			boxSize, _ := gotrade.NewAtrPnFBoxSize(2)
+			stream, _ = gotrade.NewPnFStreamForStream(source, boxSize, 3, gotrade.PnFCloseOnly)
+			source.ReceiveTick(newPnFBar(1, 11.0, 9.0, 10.0))
+		})
+
+		It("should not know the box size before the period has passed", func() {
+			Expect(stream.BoxSizeKnown()).To(BeFalse())
+		})
+
+		It("should size the boxes by the average true range", func() {
+			source.ReceiveTick(newPnFBar(2, 12.0, 10.0, 11.0))
+			Expect(stream.BoxSizeKnown()).To(BeTrue())
+			Expect(stream.BoxPrice(1)).To(Equal(2.0))
+		})
+	})
+})
@@ -0,0 +1,219 @@
+package gotrade
+
+// The ring buffers below store each value twice, at its slot and at its slot plus the capacity,
+// so that the retained values are always available as one contiguous slice, oldest first,
+// without copying. Pushing a value is O(1) and never allocates once the buffer is created.
+
+// A FloatRingBuffer retains the most recent values pushed to it, up to its capacity. Once its state has been saved
+// it can undo the values pushed since, e.g. to revise the latest bar of an indicator's period history.
+type FloatRingBuffer struct {
+	buffer   []float64
+	capacity int
+	next     int
+	count    int
+
+	// the state when last saved and the values overwritten since, only recorded once the state has been saved
+	recording   bool
+	savedNext   int
+	savedCount  int
+	overwritten []float64
+}
+
+// NewFloatRingBuffer creates a FloatRingBuffer retaining at most capacity values, capacity must be greater than 0
+func NewFloatRingBuffer(capacity int) *FloatRingBuffer {
+	return &FloatRingBuffer{buffer: make([]float64, 2*capacity), capacity: capacity}
+}
+
+// Push adds a value, returning the oldest value and true when the buffer was full and the oldest value is discarded
+func (rb *FloatRingBuffer) Push(value float64) (oldest float64, discarded bool) {
+	oldest = rb.buffer[rb.next]
+	discarded = rb.count == rb.capacity
+	if rb.recording {
+		rb.overwritten = append(rb.overwritten, oldest)
+	}
+
+	rb.buffer[rb.next] = value
+	rb.buffer[rb.next+rb.capacity] = value
+	rb.next = (rb.next + 1) % rb.capacity
+	if !discarded {
+		rb.count++
+	}
+	return oldest, discarded
+}
+
+// ReplaceLatest replaces the most recent value, the buffer must not be empty
+func (rb *FloatRingBuffer) ReplaceLatest(value float64) {
+	latest := (rb.next + rb.capacity - 1) % rb.capacity
+	rb.buffer[latest] = value
+	rb.buffer[latest+rb.capacity] = value
+}
+
+// Len returns the number of values retained
+func (rb *FloatRingBuffer) Len() int {
+	return rb.count
+}
+
+// Capacity returns the maximum number of values retained
+func (rb *FloatRingBuffer) Capacity() int {
+	return rb.capacity
+}
+
+// At returns the value offset values before the most recent value, an offset of 0 is the most recent value
+func (rb *FloatRingBuffer) At(offset int) (value float64, ok bool) {
+	if offset < 0 || offset >= rb.count {
+		return 0.0, false
+	}
+	values := rb.Values()
+	return values[rb.count-1-offset], true
+}
+
+// Oldest returns the oldest value retained, the buffer must not be empty
+func (rb *FloatRingBuffer) Oldest() float64 {
+	return rb.Values()[0]
+}
+
+// Values returns the retained values, oldest first. The slice shares the buffer's storage
+// and is overwritten by subsequent pushes.
+func (rb *FloatRingBuffer) Values() []float64 {
+	if rb.count < rb.capacity {
+		return rb.buffer[0:rb.count:rb.count]
+	}
+	return rb.buffer[rb.next : rb.next+rb.capacity : rb.next+rb.capacity]
+}
+
+// SaveState makes the current contents the state restored by RestoreState. From then on the buffer records the value
+// each push overwrites, which it forgets at the next save, so that restoring the state is O(1) per value pushed.
+func (rb *FloatRingBuffer) SaveState() {
+	rb.recording = true
+	rb.savedNext = rb.next
+	rb.savedCount = rb.count
+	rb.overwritten = rb.overwritten[:0]
+}
+
+// RestoreState undoes the values pushed since the state was last saved, the state must have been saved
+func (rb *FloatRingBuffer) RestoreState() {
+	for i := len(rb.overwritten) - 1; i >= 0; i-- {
+		rb.next = (rb.next + rb.capacity - 1) % rb.capacity
+		rb.buffer[rb.next] = rb.overwritten[i]
+		rb.buffer[rb.next+rb.capacity] = rb.overwritten[i]
+	}
+	rb.overwritten = rb.overwritten[:0]
+	rb.next = rb.savedNext
+	rb.count = rb.savedCount
+}
+
+// An IntRingBuffer retains the most recent values pushed to it, up to its capacity
+type IntRingBuffer struct {
+	buffer   []int64
+	capacity int
+	next     int
+	count    int
+}
+
+// NewIntRingBuffer creates an IntRingBuffer retaining at most capacity values, capacity must be greater than 0
+func NewIntRingBuffer(capacity int) *IntRingBuffer {
+	return &IntRingBuffer{buffer: make([]int64, 2*capacity), capacity: capacity}
+}
+
+// Push adds a value, discarding the oldest value when the buffer is full
+func (rb *IntRingBuffer) Push(value int64) {
+	rb.buffer[rb.next] = value
+	rb.buffer[rb.next+rb.capacity] = value
+	rb.next = (rb.next + 1) % rb.capacity
+	if rb.count < rb.capacity {
+		rb.count++
+	}
+}
+
+// ReplaceLatest replaces the most recent value, the buffer must not be empty
+func (rb *IntRingBuffer) ReplaceLatest(value int64) {
+	latest := (rb.next + rb.capacity - 1) % rb.capacity
+	rb.buffer[latest] = value
+	rb.buffer[latest+rb.capacity] = value
+}
+
+// Len returns the number of values retained
+func (rb *IntRingBuffer) Len() int {
+	return rb.count
+}
+
+// Capacity returns the maximum number of values retained
+func (rb *IntRingBuffer) Capacity() int {
+	return rb.capacity
+}
+
+// At returns the value offset values before the most recent value, an offset of 0 is the most recent value
+func (rb *IntRingBuffer) At(offset int) (value int64, ok bool) {
+	if offset < 0 || offset >= rb.count {
+		return 0, false
+	}
+	values := rb.Values()
+	return values[rb.count-1-offset], true
+}
+
+// Values returns the retained values, oldest first. The slice shares the buffer's storage
+// and is overwritten by subsequent pushes.
+func (rb *IntRingBuffer) Values() []int64 {
+	if rb.count < rb.capacity {
+		return rb.buffer[0:rb.count:rb.count]
+	}
+	return rb.buffer[rb.next : rb.next+rb.capacity : rb.next+rb.capacity]
+}
+
+// A DOHLCVRingBuffer retains the most recent bars pushed to it, up to its capacity
+type DOHLCVRingBuffer struct {
+	buffer   []DOHLCV
+	capacity int
+	next     int
+	count    int
+}
+
+// NewDOHLCVRingBuffer creates a DOHLCVRingBuffer retaining at most capacity bars, capacity must be greater than 0
+func NewDOHLCVRingBuffer(capacity int) *DOHLCVRingBuffer {
+	return &DOHLCVRingBuffer{buffer: make([]DOHLCV, 2*capacity), capacity: capacity}
+}
+
+// Push adds a bar, discarding the oldest bar when the buffer is full
+func (rb *DOHLCVRingBuffer) Push(value DOHLCV) {
+	rb.buffer[rb.next] = value
+	rb.buffer[rb.next+rb.capacity] = value
+	rb.next = (rb.next + 1) % rb.capacity
+	if rb.count < rb.capacity {
+		rb.count++
+	}
+}
+
+// ReplaceLatest replaces the most recent bar, the buffer must not be empty
+func (rb *DOHLCVRingBuffer) ReplaceLatest(value DOHLCV) {
+	latest := (rb.next + rb.capacity - 1) % rb.capacity
+	rb.buffer[latest] = value
+	rb.buffer[latest+rb.capacity] = value
+}
+
+// Len returns the number of bars retained
+func (rb *DOHLCVRingBuffer) Len() int {
+	return rb.count
+}
+
+// Capacity returns the maximum number of bars retained
+func (rb *DOHLCVRingBuffer) Capacity() int {
+	return rb.capacity
+}
+
+// At returns the bar offset bars before the most recent bar, an offset of 0 is the most recent bar
+func (rb *DOHLCVRingBuffer) At(offset int) (value DOHLCV, ok bool) {
+	if offset < 0 || offset >= rb.count {
+		return nil, false
+	}
+	values := rb.Values()
+	return values[rb.count-1-offset], true
+}
+
+// Values returns the retained bars, oldest first. The slice shares the buffer's storage
+// and is overwritten by subsequent pushes.
+func (rb *DOHLCVRingBuffer) Values() []DOHLCV {
+	if rb.count < rb.capacity {
+		return rb.buffer[0:rb.count:rb.count]
+	}
+	return rb.buffer[rb.next : rb.next+rb.capacity : rb.next+rb.capacity]
+}
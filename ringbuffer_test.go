@@ -0,0 +1,84 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+)
+
+var _ = Describe("when pushing values into a ring buffer", func() {
+	var buffer *gotrade.FloatRingBuffer
+
+	BeforeEach(func() {
+		buffer = gotrade.NewFloatRingBuffer(3)
+	})
+
+	Context("and the buffer is not yet full", func() {
+		BeforeEach(func() {
+			buffer.Push(1.0)
+			buffer.Push(2.0)
+		})
+
+		It("should retain every value oldest first", func() {
+			Expect(buffer.Len()).To(Equal(2))
+			Expect(buffer.Values()).To(Equal([]float64{1.0, 2.0}))
+		})
+
+		It("should return values by offset from the latest", func() {
+			value, ok := buffer.At(0)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(2.0))
+
+			_, ok = buffer.At(2)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("and more values than the capacity have been pushed", func() {
+		BeforeEach(func() {
+			for i := 1; i <= 7; i++ {
+				buffer.Push(float64(i))
+			}
+		})
+
+		It("should only retain the most recent values oldest first", func() {
+			Expect(buffer.Len()).To(Equal(3))
+			Expect(buffer.Values()).To(Equal([]float64{5.0, 6.0, 7.0}))
+		})
+
+		It("should return values by offset from the latest", func() {
+			value, ok := buffer.At(2)
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal(5.0))
+		})
+
+		It("should not let an append to the values overwrite the buffer", func() {
+			values := append(buffer.Values(), 100.0)
+			Expect(values[3]).To(Equal(100.0))
+			Expect(buffer.Values()).To(Equal([]float64{5.0, 6.0, 7.0}))
+		})
+	})
+	Context("and the state was saved before pushing more values", func() {
+		BeforeEach(func() {
+			for i := 1; i <= 4; i++ {
+				buffer.Push(float64(i))
+			}
+			buffer.SaveState()
+		})
+
+		It("should return the value discarded by a push", func() {
+			oldest, discarded := buffer.Push(5.0)
+			Expect(discarded).To(BeTrue())
+			Expect(oldest).To(Equal(2.0))
+			Expect(buffer.Oldest()).To(Equal(3.0))
+		})
+
+		It("should undo the values pushed since the state was saved", func() {
+			buffer.Push(5.0)
+			buffer.Push(6.0)
+			buffer.RestoreState()
+			Expect(buffer.Len()).To(Equal(3))
+			Expect(buffer.Values()).To(Equal([]float64{2.0, 3.0, 4.0}))
+		})
+	})
+})
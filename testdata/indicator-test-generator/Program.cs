@@ -34,6 +34,26 @@ namespace indicatortestgenerator
 				}
 			}
 
+			// the candlestick patterns are recognised in a synthetic series of random candles with the rarer patterns set into it,
+			// a year of the index is too smooth for TA-Lib to recognise most of them
+			List<double> candleOpenPrices = new List<double>();
+			List<double> candleClosingPrices = new List<double>();
+			List<double> candleHighPrices = new List<double>();
+			List<double> candleLowPrices = new List<double>();
+			using (var reader = new StreamReader (@"/home/eugened/Development/local/indicator-test-generator/indicator-test-generator/SYNTHETIC.candlesticks.data")) 
+			{
+				string line = null;
+				while((line = reader.ReadLine()) != null)
+				{
+					string[] parts = line.Split (new char[]{ ',' });
+					// format is date, O, H, L, C, V
+					candleOpenPrices.Add (Convert.ToDouble (parts [1].Replace (".", ",")));
+					candleHighPrices.Add (Convert.ToDouble (parts [2].Replace (".", ",")));
+					candleLowPrices.Add (Convert.ToDouble (parts [3].Replace (".", ",")));
+					candleClosingPrices.Add (Convert.ToDouble (parts [4].Replace(".", ",")));
+				}
+			}
+
 			// now we need to create an output file for each indicator
 
 			// SMA
@@ -996,6 +1016,500 @@ namespace indicatortestgenerator
 				}
 				writer.Flush ();
 			}
+
+			// CDLDOJI
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdldoji_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlDojiLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlDoji(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLDRAGONFLYDOJI
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdldragonflydoji_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlDragonflyDojiLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlDragonflyDoji(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLGRAVESTONEDOJI
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlgravestonedoji_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlGravestoneDojiLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlGravestoneDoji(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLLONGLEGGEDDOJI
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdllongleggeddoji_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlLongLeggedDojiLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlLongLeggedDoji(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLDOJISTAR
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdldojistar_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlDojiStarLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlDojiStar(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLHAMMER
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlhammer_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlHammerLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlHammer(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLHANGINGMAN
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlhangingman_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlHangingManLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlHangingMan(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLINVERTEDHAMMER
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlinvertedhammer_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlInvertedHammerLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlInvertedHammer(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLSHOOTINGSTAR
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlshootingstar_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlShootingStarLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlShootingStar(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLENGULFING
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlengulfing_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlEngulfingLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlEngulfing(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLHARAMI
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlharami_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlHaramiLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlHarami(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLPIERCING
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlpiercing_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlPiercingLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlPiercing(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLDARKCLOUDCOVER
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdldarkcloudcover_05_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlDarkCloudCoverLookback(0.5);
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlDarkCloudCover(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), 0.5, out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLMORNINGSTAR
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlmorningstar_03_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlMorningStarLookback(0.3);
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlMorningStar(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), 0.3, out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLEVENINGSTAR
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdleveningstar_03_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlEveningStarLookback(0.3);
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlEveningStar(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), 0.3, out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDL3WHITESOLDIERS
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdl3whitesoldiers_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.Cdl3WhiteSoldiersLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.Cdl3WhiteSoldiers(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDL3BLACKCROWS
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdl3blackcrows_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.Cdl3BlackCrowsLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.Cdl3BlackCrows(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLMARUBOZU
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlmarubozu_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlMarubozuLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlMarubozu(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLSPINNINGTOP
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlspinningtop_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlSpinningTopLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlSpinningTop(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLMORNINGDOJISTAR
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlmorningdojistar_03_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlMorningDojiStarLookback(0.3);
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlMorningDojiStar(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), 0.3, out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLEVENINGDOJISTAR
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdleveningdojistar_03_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlEveningDojiStarLookback(0.3);
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlEveningDojiStar(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), 0.3, out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDL3INSIDE
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdl3inside_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.Cdl3InsideLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.Cdl3Inside(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDL3OUTSIDE
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdl3outside_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.Cdl3OutsideLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.Cdl3Outside(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLABANDONEDBABY
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlabandonedbaby_03_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlAbandonedBabyLookback(0.3);
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlAbandonedBaby(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), 0.3, out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLKICKING
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdlkicking_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlKickingLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlKicking(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
+
+			// CDLTAKURI
+			using (var writer = new StreamWriter (@"/home/eugened/Development/go/src/github.com/thetruetrade/gotrade/testdata/cdltakuri_expectedresult.data")) 
+			{
+				int outBeginIndex = 0;
+				int outNBElement = 0;
+				int lookback = talib.Core.CdlTakuriLookback();
+				int dataLength = candleClosingPrices.Count - 1;
+				int[] outData = new int[dataLength - lookback +1];
+				talib.Core.RetCode retCode =talib.Core.CdlTakuri(0, dataLength, candleOpenPrices.ToArray(), candleHighPrices.ToArray(), candleLowPrices.ToArray(), candleClosingPrices.ToArray(), out outBeginIndex, out outNBElement, outData);
+				if (retCode == TicTacTec.TA.Library.Core.RetCode.Success) 
+				{
+					foreach (var item in outData) 
+					{
+						writer.WriteLine (item.ToString(CultureInfo.InvariantCulture));
+					}
+				}
+				writer.Flush ();
+			}
 		}
 	}
 }
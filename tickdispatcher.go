@@ -0,0 +1,144 @@
+package gotrade
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrWorkerCountMustBeGreaterThanZero = errors.New("Worker count must be greater than 0")
+
+	defaultTickDispatcher TickDispatcher = NewFanOutTickDispatcher()
+)
+
+// A TickDispatcher notifies the subscriptions of a DOHLCVStream of a new tick, or of a revision of the latest tick,
+// returning once every subscription has received it
+type TickDispatcher interface {
+	Dispatch(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription)
+	DispatchUpdate(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription)
+}
+
+// A SynchronousTickDispatcher notifies each subscription in turn on the calling goroutine, in the order they subscribed.
+// It has the lowest overhead and a deterministic ordering.
+type SynchronousTickDispatcher struct {
+}
+
+// NewSynchronousTickDispatcher creates a SynchronousTickDispatcher
+func NewSynchronousTickDispatcher() *SynchronousTickDispatcher {
+	return &SynchronousTickDispatcher{}
+}
+
+func (d *SynchronousTickDispatcher) Dispatch(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription) {
+	for _, subscription := range subscriptions {
+		subscription.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+func (d *SynchronousTickDispatcher) DispatchUpdate(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription) {
+	for _, subscription := range subscriptions {
+		subscription.ReceiveDOHLCVTickUpdate(tickData, streamBarIndex)
+	}
+}
+
+// A FanOutTickDispatcher notifies every subscription on a goroutine of its own and waits for them all to complete
+type FanOutTickDispatcher struct {
+}
+
+// NewFanOutTickDispatcher creates a FanOutTickDispatcher
+func NewFanOutTickDispatcher() *FanOutTickDispatcher {
+	return &FanOutTickDispatcher{}
+}
+
+func (d *FanOutTickDispatcher) Dispatch(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription) {
+	d.dispatch(tickData, streamBarIndex, subscriptions, false)
+}
+
+func (d *FanOutTickDispatcher) DispatchUpdate(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription) {
+	d.dispatch(tickData, streamBarIndex, subscriptions, true)
+}
+
+func (d *FanOutTickDispatcher) dispatch(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription, isUpdate bool) {
+	var waitGroup sync.WaitGroup
+
+	// notify all the subscribers and wait
+	for subscriberIndex := range subscriptions {
+		waitGroup.Add(1)
+		var subscription *DOHLCVTickSubscription = subscriptions[subscriberIndex]
+		go func(subscription *DOHLCVTickSubscription) {
+			defer waitGroup.Done()
+			notify(subscription, tickData, streamBarIndex, isUpdate)
+		}(subscription)
+	}
+
+	waitGroup.Wait()
+}
+
+type tickDispatchJob struct {
+	tickData       DOHLCV
+	streamBarIndex int
+	isUpdate       bool
+	subscription   *DOHLCVTickSubscription
+	waitGroup      *sync.WaitGroup
+}
+
+// notify passes a new tick, or a revision of the latest tick, on to the subscription
+func notify(subscription *DOHLCVTickSubscription, tickData DOHLCV, streamBarIndex int, isUpdate bool) {
+	if isUpdate {
+		subscription.ReceiveDOHLCVTickUpdate(tickData, streamBarIndex)
+	} else {
+		subscription.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+}
+
+// A WorkerPoolTickDispatcher notifies the subscriptions using a fixed number of long lived worker goroutines
+// and waits for them all to complete. The workers run until the dispatcher is closed.
+// A pool should not be shared by streams that feed each other, e.g. a daily stream and the weekly stream
+// aggregated from it, as a worker waiting on the downstream dispatch would hold up the pool.
+type WorkerPoolTickDispatcher struct {
+	jobs      chan tickDispatchJob
+	closeOnce sync.Once
+}
+
+// NewWorkerPoolTickDispatcher creates a WorkerPoolTickDispatcher with the specified number of workers
+func NewWorkerPoolTickDispatcher(workerCount int) (dispatcher *WorkerPoolTickDispatcher, err error) {
+	if workerCount < 1 {
+		return nil, ErrWorkerCountMustBeGreaterThanZero
+	}
+
+	d := WorkerPoolTickDispatcher{jobs: make(chan tickDispatchJob, workerCount)}
+	for i := 0; i < workerCount; i++ {
+		go d.work()
+	}
+	return &d, nil
+}
+
+func (d *WorkerPoolTickDispatcher) Dispatch(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription) {
+	d.dispatch(tickData, streamBarIndex, subscriptions, false)
+}
+
+func (d *WorkerPoolTickDispatcher) DispatchUpdate(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription) {
+	d.dispatch(tickData, streamBarIndex, subscriptions, true)
+}
+
+func (d *WorkerPoolTickDispatcher) dispatch(tickData DOHLCV, streamBarIndex int, subscriptions []*DOHLCVTickSubscription, isUpdate bool) {
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(len(subscriptions))
+	for _, subscription := range subscriptions {
+		d.jobs <- tickDispatchJob{tickData: tickData, streamBarIndex: streamBarIndex, isUpdate: isUpdate, subscription: subscription, waitGroup: &waitGroup}
+	}
+	waitGroup.Wait()
+}
+
+// Close stops the workers, the dispatcher can not be used once it has been closed
+func (d *WorkerPoolTickDispatcher) Close() {
+	d.closeOnce.Do(func() {
+		close(d.jobs)
+	})
+}
+
+func (d *WorkerPoolTickDispatcher) work() {
+	for job := range d.jobs {
+		notify(job.subscription, job.tickData, job.streamBarIndex, job.isUpdate)
+		job.waitGroup.Done()
+	}
+}
@@ -0,0 +1,143 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/feeds"
+	"github.com/thetruetrade/gotrade/indicators"
+	"sync"
+	"testing"
+	"time"
+)
+
+type orderRecordingTickReceiver struct {
+	id    int
+	mutex *sync.Mutex
+	order *[]int
+}
+
+func (r *orderRecordingTickReceiver) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	*r.order = append(*r.order, r.id)
+}
+
+var _ = Describe("when dispatching ticks to the subscribers of a DOHLCVStream", func() {
+	var (
+		stream *gotrade.InterDayDOHLCVStream
+		order  []int
+		mutex  sync.Mutex
+	)
+
+	BeforeEach(func() {
+		order = nil
+		stream = gotrade.NewDailyDOHLCVStream()
+		for i := 0; i < 20; i++ {
+			stream.AddTickSubscription(&orderRecordingTickReceiver{id: i, mutex: &mutex, order: &order})
+		}
+	})
+
+	Context("and the stream uses a synchronous dispatcher", func() {
+		BeforeEach(func() {
+			stream.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+			stream.ReceiveTick(newStreamTick(10.0))
+		})
+
+		It("should notify the subscribers in the order they subscribed", func() {
+			for i := range order {
+				Expect(order[i]).To(Equal(i))
+			}
+			Expect(len(order)).To(Equal(20))
+		})
+	})
+
+	Context("and the stream uses a worker pool dispatcher", func() {
+		var dispatcher *gotrade.WorkerPoolTickDispatcher
+
+		BeforeEach(func() {
+			dispatcher, _ = gotrade.NewWorkerPoolTickDispatcher(4)
+			stream.SetTickDispatcher(dispatcher)
+			stream.ReceiveTick(newStreamTick(10.0))
+			stream.ReceiveTick(newStreamTick(11.0))
+		})
+
+		AfterEach(func() {
+			dispatcher.Close()
+		})
+
+		It("should notify every subscriber of every tick", func() {
+			Expect(len(order)).To(Equal(40))
+		})
+	})
+
+	Context("and a worker pool dispatcher is created without any workers", func() {
+		It("should return the appropriate error", func() {
+			dispatcher, err := gotrade.NewWorkerPoolTickDispatcher(0)
+			Expect(dispatcher).To(BeNil())
+			Expect(err).To(Equal(gotrade.ErrWorkerCountMustBeGreaterThanZero))
+		})
+	})
+})
+
+// loadBenchmarkData loads the full JSE top 40 history into memory so that the benchmarks only measure dispatch
+func loadBenchmarkData(b *testing.B) []gotrade.DOHLCV {
+	source := gotrade.NewDailyDOHLCVStream()
+	csvFeed := feeds.NewCSVFileFeedWithDOHLCVFormat("testdata/JSETOPI.ALL.data",
+		feeds.DashedYearDayMonthDateParserForLocation(time.Local))
+	if err := csvFeed.FillDOHLCVStream(source); err != nil {
+		b.Fatal(err)
+	}
+	return source.Data
+}
+
+// attachBenchmarkIndicators attaches 50 indicators to the stream
+func attachBenchmarkIndicators(stream *gotrade.InterDayDOHLCVStream) {
+	for period := 2; period < 12; period++ {
+		indicators.NewSmaForStream(stream, period, gotrade.UseClosePrice)
+		indicators.NewEmaForStream(stream, period, gotrade.UseClosePrice)
+		indicators.NewRsiForStream(stream, period, gotrade.UseClosePrice)
+		indicators.NewBollingerBandsForStream(stream, period, gotrade.UseClosePrice)
+		indicators.NewMacdForStream(stream, period, period*2, period, gotrade.UseClosePrice)
+	}
+}
+
+func benchmarkTickDispatcher(b *testing.B, newDispatcher func() gotrade.TickDispatcher) {
+	data := loadBenchmarkData(b)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		stream := gotrade.NewDailyDOHLCVStream()
+		dispatcher := newDispatcher()
+		stream.SetTickDispatcher(dispatcher)
+		attachBenchmarkIndicators(stream)
+		b.StartTimer()
+
+		for i := range data {
+			stream.ReceiveTick(data[i])
+		}
+
+		if pool, ok := dispatcher.(*gotrade.WorkerPoolTickDispatcher); ok {
+			pool.Close()
+		}
+	}
+}
+
+func BenchmarkSynchronousTickDispatcher(b *testing.B) {
+	benchmarkTickDispatcher(b, func() gotrade.TickDispatcher {
+		return gotrade.NewSynchronousTickDispatcher()
+	})
+}
+
+func BenchmarkFanOutTickDispatcher(b *testing.B) {
+	benchmarkTickDispatcher(b, func() gotrade.TickDispatcher {
+		return gotrade.NewFanOutTickDispatcher()
+	})
+}
+
+func BenchmarkWorkerPoolTickDispatcher(b *testing.B) {
+	benchmarkTickDispatcher(b, func() gotrade.TickDispatcher {
+		dispatcher, _ := gotrade.NewWorkerPoolTickDispatcher(4)
+		return dispatcher
+	})
+}
@@ -0,0 +1,128 @@
+package gotrade
+
+import (
+	"time"
+)
+
+// A TradingCalendar describes the days an exchange trades on
+//	- weekend days never trade
+//	- holidays never trade
+//	- half days trade with an early close
+// Dates are compared by their calendar day, the days returned by the calendar are in the calendar's location.
+type TradingCalendar struct {
+	// private variables
+	location *time.Location
+	weekend  map[time.Weekday]bool
+	holidays map[time.Time]string
+	halfDays map[time.Time]time.Duration
+}
+
+// NewTradingCalendar creates a calendar without holidays whose weekend is saturday and sunday, a nil location is treated as UTC
+func NewTradingCalendar(location *time.Location) *TradingCalendar {
+	return NewTradingCalendarWithWeekend(location, time.Saturday, time.Sunday)
+}
+
+// NewTradingCalendarWithWeekend creates a calendar without holidays whose weekend is the specified days
+func NewTradingCalendarWithWeekend(location *time.Location, weekendDays ...time.Weekday) *TradingCalendar {
+	if location == nil {
+		location = time.UTC
+	}
+
+	c := TradingCalendar{location: location,
+		weekend:  make(map[time.Weekday]bool),
+		holidays: make(map[time.Time]string),
+		halfDays: make(map[time.Time]time.Duration)}
+	for _, day := range weekendDays {
+		c.weekend[day] = true
+	}
+	return &c
+}
+
+// NewTradingCalendarForInstrument creates a calendar whose weekend is saturday and sunday in the instrument's timezone
+func NewTradingCalendarForInstrument(instrument *Instrument) *TradingCalendar {
+	return NewTradingCalendar(instrument.Location())
+}
+
+func (c *TradingCalendar) Location() *time.Location {
+	return c.location
+}
+
+// AddHoliday marks the date as a holiday on which the exchange does not trade
+func (c *TradingCalendar) AddHoliday(date time.Time, name string) {
+	c.holidays[c.day(date)] = name
+}
+
+// AddHalfDay marks the date as a half day on which the exchange closes at the time of day closeTime
+func (c *TradingCalendar) AddHalfDay(date time.Time, closeTime time.Duration) {
+	c.halfDays[c.day(date)] = closeTime
+}
+
+// IsTradingDay returns true if the exchange trades on the date, including half days
+func (c *TradingCalendar) IsTradingDay(date time.Time) bool {
+	day := c.day(date)
+	if c.weekend[day.Weekday()] {
+		return false
+	}
+	_, isHoliday := c.holidays[day]
+	return !isHoliday
+}
+
+// Holiday returns the name of the holiday on the date
+func (c *TradingCalendar) Holiday(date time.Time) (name string, ok bool) {
+	name, ok = c.holidays[c.day(date)]
+	return name, ok
+}
+
+// HalfDay returns the time of day the exchange closes on the date if it is a half day
+func (c *TradingCalendar) HalfDay(date time.Time) (closeTime time.Duration, ok bool) {
+	closeTime, ok = c.halfDays[c.day(date)]
+	return closeTime, ok
+}
+
+// NextTradingDay returns the first trading day after the date
+func (c *TradingCalendar) NextTradingDay(date time.Time) time.Time {
+	day := c.day(date).AddDate(0, 0, 1)
+	for !c.IsTradingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// PreviousTradingDay returns the last trading day before the date
+func (c *TradingCalendar) PreviousTradingDay(date time.Time) time.Time {
+	day := c.day(date).AddDate(0, 0, -1)
+	for !c.IsTradingDay(day) {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// TradingDaysBetween returns the trading days after from and before to, exclusive of both dates
+func (c *TradingCalendar) TradingDaysBetween(from time.Time, to time.Time) []time.Time {
+	var days []time.Time
+	last := c.day(to)
+	for day := c.day(from).AddDate(0, 0, 1); day.Before(last); day = day.AddDate(0, 0, 1) {
+		if c.IsTradingDay(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// hasTradingDayBetween returns true if there is a trading day in the inclusive date range
+func (c *TradingCalendar) hasTradingDayBetween(from time.Time, to time.Time) bool {
+	last := c.day(to)
+	for day := c.day(from); !day.After(last); day = day.AddDate(0, 0, 1) {
+		if c.IsTradingDay(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// day returns midnight of the date's calendar day in the calendar's location, a daily bar dated midnight
+// in another timezone is still on its own calendar day
+func (c *TradingCalendar) day(date time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, c.location)
+}
@@ -0,0 +1,158 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+var _ = Describe("when using a trading calendar", func() {
+	var (
+		calendar *gotrade.TradingCalendar
+	)
+
+	BeforeEach(func() {
+		// 1 January 2013 is a tuesday, 5 and 6 January are the weekend
+		calendar = gotrade.NewTradingCalendar(time.UTC)
+		calendar.AddHoliday(newDatedTick(1, 0.0).D(), "New Year's Day")
+		calendar.AddHalfDay(newDatedTick(4, 0.0).D(), 12*time.Hour)
+	})
+
+	It("should not trade on the weekend or holidays", func() {
+		Expect(calendar.IsTradingDay(newDatedTick(1, 0.0).D())).To(BeFalse())
+		Expect(calendar.IsTradingDay(newDatedTick(5, 0.0).D())).To(BeFalse())
+		Expect(calendar.IsTradingDay(newDatedTick(2, 0.0).D())).To(BeTrue())
+	})
+
+	It("should name the holiday", func() {
+		name, ok := calendar.Holiday(newDatedTick(1, 0.0).D())
+		Expect(ok).To(BeTrue())
+		Expect(name).To(Equal("New Year's Day"))
+	})
+
+	It("should trade on a half day with an early close", func() {
+		closeTime, ok := calendar.HalfDay(newDatedTick(4, 0.0).D())
+		Expect(ok).To(BeTrue())
+		Expect(closeTime).To(Equal(12 * time.Hour))
+		Expect(calendar.IsTradingDay(newDatedTick(4, 0.0).D())).To(BeTrue())
+	})
+
+	It("should skip the weekend and holidays to find the next and previous trading days", func() {
+		Expect(calendar.NextTradingDay(newDatedTick(4, 0.0).D())).To(Equal(newDatedTick(7, 0.0).D()))
+		Expect(calendar.PreviousTradingDay(newDatedTick(2, 0.0).D())).To(Equal(time.Date(2012, time.December, 31, 0, 0, 0, 0, time.UTC)))
+	})
+
+	It("should list the trading days between two dates", func() {
+		Expect(calendar.TradingDaysBetween(newDatedTick(2, 0.0).D(), newDatedTick(8, 0.0).D())).To(Equal([]time.Time{newDatedTick(3, 0.0).D(), newDatedTick(4, 0.0).D(), newDatedTick(7, 0.0).D()}))
+	})
+
+	Context("and a daily stream trades on the calendar", func() {
+		var (
+			stream *gotrade.InterDayDOHLCVStream
+		)
+
+		BeforeEach(func() {
+			stream = gotrade.NewDailyDOHLCVStream()
+		})
+
+		It("should not treat the weekend as a gap", func() {
+			stream.SetTradingCalendar(calendar, gotrade.DetectTradingGaps)
+			stream.ReceiveTick(newDatedTick(4, 10.0))
+			stream.ReceiveTick(newDatedTick(7, 11.0))
+			Expect(stream.TradingGaps()).To(BeEmpty())
+		})
+
+		It("should detect the missing trading days", func() {
+			stream.SetTradingCalendar(calendar, gotrade.DetectTradingGaps)
+			stream.ReceiveTick(newDatedTick(2, 10.0))
+			stream.ReceiveTick(newDatedTick(7, 11.0))
+			Expect(stream.TradingGaps()).To(Equal([]time.Time{newDatedTick(3, 0.0).D(), newDatedTick(4, 0.0).D()}))
+			Expect(len(stream.Data)).To(Equal(2))
+		})
+
+		It("should fill the missing trading days forward", func() {
+			stream.SetTradingCalendar(calendar, gotrade.FillForwardTradingGaps)
+			stream.ReceiveTick(newDatedTick(2, 10.0))
+			stream.ReceiveTick(newDatedTick(7, 11.0))
+			Expect(len(stream.Data)).To(Equal(4))
+			Expect(stream.Data[1].D()).To(Equal(newDatedTick(3, 0.0).D()))
+			Expect(stream.Data[1].C()).To(Equal(10.0))
+			Expect(stream.Data[1].V()).To(Equal(0.0))
+			Expect(stream.Data[3].C()).To(Equal(11.0))
+		})
+
+		It("should reject a bar on a holiday when validating bars", func() {
+			stream.SetTradingCalendar(calendar, gotrade.IgnoreTradingGaps)
+			stream.SetBarValidationPolicy(gotrade.RejectInvalidBars)
+			Expect(stream.ValidateAndReceiveTick(newDatedTick(1, 10.0))).To(Equal(gotrade.ErrNonTradingDay))
+		})
+	})
+
+	Context("and a weekly stream aggregates a week ending on a holiday", func() {
+		var (
+			weekly *gotrade.InterDayDOHLCVStream
+		)
+
+		BeforeEach(func() {
+			calendar.AddHoliday(time.Date(2013, time.March, 29, 0, 0, 0, 0, time.UTC), "Good Friday")
+			daily := gotrade.NewDailyDOHLCVStream()
+			daily.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+			weekly = gotrade.NewWeeklyDOHLCVStreamForStream(daily)
+			weekly.SetPartialBarPolicy(gotrade.DropPartialBars)
+			weekly.SetTradingCalendar(calendar, gotrade.IgnoreTradingGaps)
+			for day := 25; day <= 28; day++ {
+				daily.ReceiveTick(gotrade.NewDOHLCVDataItem(time.Date(2013, time.March, day, 0, 0, 0, 0, time.UTC), 10.0, 11.0, 9.0, 10.0, 100.0))
+			}
+			weekly.Flush()
+		})
+
+		It("should not drop the week as partial", func() {
+			Expect(len(weekly.Data)).To(Equal(1))
+			Expect(weekly.Data[0].V()).To(Equal(400.0))
+		})
+	})
+
+	Context("and weekly and monthly streams aggregate daily bars with gaps filled forward", func() {
+		var (
+			daily   *gotrade.InterDayDOHLCVStream
+			weekly  *gotrade.InterDayDOHLCVStream
+			monthly *gotrade.InterDayDOHLCVStream
+		)
+
+		BeforeEach(func() {
+			daily = gotrade.NewDailyDOHLCVStream()
+			daily.SetTickDispatcher(gotrade.NewSynchronousTickDispatcher())
+			weekly = gotrade.NewWeeklyDOHLCVStreamForStream(daily)
+			weekly.SetTradingCalendar(calendar, gotrade.FillForwardTradingGaps)
+			monthly = gotrade.NewMonthlyDOHLCVStreamForStream(daily)
+			monthly.SetTradingCalendar(calendar, gotrade.FillForwardTradingGaps)
+
+			// every weekday from monday the 7th of january 2013 to friday the 29th of march
+			for day := time.Date(2013, time.January, 7, 0, 0, 0, 0, time.UTC); day.Month() <= time.March; day = day.AddDate(0, 0, 1) {
+				if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+					continue
+				}
+				daily.ReceiveTick(gotrade.NewDOHLCVDataItem(day, 10.0, 11.0, 9.0, 10.0, 100.0))
+			}
+			weekly.Flush()
+			monthly.Flush()
+		})
+
+		It("should not treat the trading days between two weeks as gaps", func() {
+			Expect(weekly.TradingGaps()).To(BeEmpty())
+			Expect(len(weekly.Data)).To(Equal(12))
+			Expect(weekly.Data[0].V()).To(Equal(500.0))
+			Expect(weekly.Data[11].D()).To(Equal(time.Date(2013, time.March, 29, 0, 0, 0, 0, time.UTC)))
+			Expect(weekly.Data[11].V()).To(Equal(500.0))
+		})
+
+		It("should not treat the trading days between two months as gaps", func() {
+			Expect(monthly.TradingGaps()).To(BeEmpty())
+			Expect(len(monthly.Data)).To(Equal(3))
+			Expect(monthly.Data[0].V()).To(Equal(1900.0))
+			Expect(monthly.Data[1].V()).To(Equal(2000.0))
+			Expect(monthly.Data[2].V()).To(Equal(2100.0))
+		})
+	})
+})
@@ -0,0 +1,61 @@
+package gotrade
+
+import (
+	"time"
+)
+
+// TradingGapPolicy determines how a DOHLCVStream with a trading calendar handles the trading days missing
+// between the latest bar and a new bar, i.e. gaps in the data as opposed to holidays and weekends
+type TradingGapPolicy int
+
+const (
+	// gaps are neither detected nor filled
+	IgnoreTradingGaps TradingGapPolicy = iota
+	// gaps are recorded, see TradingGaps
+	DetectTradingGaps
+	// gaps are recorded and each missing trading day is filled with a flat bar at the previous close with zero volume
+	FillForwardTradingGaps
+)
+
+// SetTradingCalendar sets the calendar the stream trades on and how gaps in its bars are handled.
+// The calendar is also used to decide if an aggregated bar covers its whole period and, under any
+// BarValidationPolicy other than AcceptAllBars, bars dated on non trading days are invalid.
+// Gaps are only handled on a daily stream, the consecutive bars of a weekly or monthly stream are a period apart
+// and their policy is always IgnoreTradingGaps.
+func (p *InterDayDOHLCVStream) SetTradingCalendar(calendar *TradingCalendar, policy TradingGapPolicy) {
+	p.calendar = calendar
+	p.gapPolicy = IgnoreTradingGaps
+	if p.streamBarType == DailyBar {
+		p.gapPolicy = policy
+	}
+	p.aggregator.SetTradingCalendar(calendar)
+}
+
+// TradingCalendar returns the calendar the stream trades on, nil when the stream has no calendar
+func (p *DOHLCVStream) TradingCalendar() *TradingCalendar {
+	return p.calendar
+}
+
+// TradingGaps returns the trading days found missing from the stream's bars, in the order they were found
+func (p *DOHLCVStream) TradingGaps() []time.Time {
+	return p.tradingGaps
+}
+
+func (p *DOHLCVStream) handleTradingGaps(tickData DOHLCV) {
+	latest, ok := p.BarFromLatest(0)
+	if !ok {
+		return
+	}
+
+	missingDays := p.calendar.TradingDaysBetween(latest.D(), tickData.D())
+	p.tradingGaps = append(p.tradingGaps, missingDays...)
+	if p.gapPolicy != FillForwardTradingGaps {
+		return
+	}
+
+	for _, day := range missingDays {
+		// keep the time of day and timezone of the bars
+		date := time.Date(day.Year(), day.Month(), day.Day(), latest.D().Hour(), latest.D().Minute(), latest.D().Second(), latest.D().Nanosecond(), latest.D().Location())
+		p.appendTick(NewDOHLCVDataItem(date, latest.C(), latest.C(), latest.C(), latest.C(), 0.0))
+	}
+}
@@ -0,0 +1,246 @@
+package gotrade
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrSymbolAlreadyInUniverse = errors.New("The symbol has already been added to the universe")
+	ErrSymbolNotInUniverse     = errors.New("The symbol has not been added to the universe")
+	ErrDuplicateSymbolBar      = errors.New("The symbol has already been fed a bar with the same date")
+)
+
+// A UniverseReplayError reports a bar a symbol's stream rejected during a replay
+type UniverseReplayError struct {
+	Symbol string
+	Date   time.Time
+	Err    error
+}
+
+func (e *UniverseReplayError) Error() string {
+	return e.Symbol + " " + e.Date.Format("2006-01-02") + ": " + e.Err.Error()
+}
+
+// MissingBarFillPolicy determines what a Universe replays to a symbol's stream for a date on which
+// other symbols have a bar and the symbol does not
+type MissingBarFillPolicy int
+
+const (
+	// no bar is replayed, the symbol's stream only receives the bars it was fed
+	SkipMissingBars MissingBarFillPolicy = iota
+	// a flat bar at the previous close with zero volume is replayed, between the symbol's first and last bars
+	FillForwardMissingBars
+	// the symbol's previous bar is replayed with the missing date, between the symbol's first and last bars
+	RepeatPreviousMissingBars
+)
+
+// UniverseDateReceiver receives each date replayed by a Universe once every symbol's stream has received
+// its bar for the date, the bars map holds the bar replayed to each symbol, including filled bars
+type UniverseDateReceiver interface {
+	ReceiveUniverseDate(date time.Time, bars map[string]DOHLCV)
+}
+
+// A Universe owns the streams of many symbols, buffering the bars fed to each symbol and replaying them
+// to the symbol streams in global date order
+type Universe struct {
+	// private variables
+	streamBarType   interDayBarType
+	fillPolicy      MissingBarFillPolicy
+	symbols         []string
+	streams         map[string]*InterDayDOHLCVStream
+	pending         map[string][]DOHLCV
+	dateSubscribers []UniverseDateReceiver
+}
+
+// NewUniverse creates an empty Universe whose symbol streams have the specified bar type
+func NewUniverse(streamBarType interDayBarType) *Universe {
+	return &Universe{streamBarType: streamBarType,
+		streams: make(map[string]*InterDayDOHLCVStream),
+		pending: make(map[string][]DOHLCV)}
+}
+
+func NewDailyUniverse() *Universe {
+	return NewUniverse(DailyBar)
+}
+
+// SetMissingBarFillPolicy sets how missing bars are replayed, the default is SkipMissingBars
+func (u *Universe) SetMissingBarFillPolicy(policy MissingBarFillPolicy) {
+	u.fillPolicy = policy
+}
+
+// AddSymbol adds a symbol to the universe, returning the symbol's stream
+func (u *Universe) AddSymbol(symbol string) (stream *InterDayDOHLCVStream, err error) {
+	if symbol == "" {
+		return nil, ErrInstrumentSymbolIsEmpty
+	}
+
+	if _, ok := u.streams[symbol]; ok {
+		return nil, ErrSymbolAlreadyInUniverse
+	}
+
+	return u.addStream(symbol, NewInterDayDOHLCVStream(u.streamBarType)), nil
+}
+
+// AddInstrument adds the instrument's symbol to the universe, returning the symbol's stream
+func (u *Universe) AddInstrument(instrument *Instrument) (stream *InterDayDOHLCVStream, err error) {
+	if _, ok := u.streams[instrument.Symbol()]; ok {
+		return nil, ErrSymbolAlreadyInUniverse
+	}
+
+	return u.addStream(instrument.Symbol(), NewInterDayDOHLCVStreamForInstrument(instrument, u.streamBarType)), nil
+}
+
+func (u *Universe) addStream(symbol string, stream *InterDayDOHLCVStream) *InterDayDOHLCVStream {
+	u.streams[symbol] = stream
+	u.symbols = append(u.symbols, symbol)
+	sort.Strings(u.symbols)
+	return stream
+}
+
+// Symbols returns the symbols of the universe in sorted order, the order in which the streams receive
+// their bars for a date
+func (u *Universe) Symbols() []string {
+	symbols := make([]string, len(u.symbols))
+	copy(symbols, u.symbols)
+	return symbols
+}
+
+// Stream returns the symbol's stream
+func (u *Universe) Stream(symbol string) (stream *InterDayDOHLCVStream, ok bool) {
+	stream, ok = u.streams[symbol]
+	return stream, ok
+}
+
+// SymbolFeed returns a receiver which buffers the bars fed to it for the symbol until the universe is replayed,
+// e.g. as the target of a CSVFileFeed. The bars are buffered in date order whatever order they are fed in, a bar
+// with the same date as a buffered bar is dropped, or rejected with ErrDuplicateSymbolBar by ValidateAndReceiveTick.
+func (u *Universe) SymbolFeed(symbol string) (feed DOHLCVStreamTickReceiver, err error) {
+	if _, ok := u.streams[symbol]; !ok {
+		return nil, ErrSymbolNotInUniverse
+	}
+	return &universeSymbolFeed{universe: u, symbol: symbol}, nil
+}
+
+// AddDateSubscription subscribes the receiver to each date replayed by the universe
+func (u *Universe) AddDateSubscription(subscriber UniverseDateReceiver) {
+	u.dateSubscribers = append(u.dateSubscribers, subscriber)
+}
+
+// Replay passes the buffered bars to the symbol streams in date order. Every symbol's stream receives its bar
+// for a date, in symbol order, before any stream receives a bar for a later date. The buffers are emptied,
+// so bars fed after a replay are replayed by the next call.
+// A bar rejected by a symbol's stream, according to the stream's BarValidationPolicy, is skipped so that the
+// other streams stay aligned, and the first rejection is returned as a UniverseReplayError.
+func (u *Universe) Replay() (err error) {
+	cursors := make(map[string]int, len(u.symbols))
+	previousBars := make(map[string]DOHLCV, len(u.symbols))
+	for _, symbol := range u.symbols {
+		if latest, ok := u.streams[symbol].BarFromLatest(0); ok {
+			previousBars[symbol] = latest
+		}
+	}
+
+	for {
+		date, ok := u.nextDate(cursors)
+		if !ok {
+			break
+		}
+
+		bars := make(map[string]DOHLCV, len(u.symbols))
+		for _, symbol := range u.symbols {
+			bar, ok := u.barForDate(symbol, date, cursors, previousBars)
+			if !ok {
+				continue
+			}
+
+			if rejection := u.streams[symbol].ValidateAndReceiveTick(bar); rejection != nil {
+				if err == nil {
+					err = &UniverseReplayError{Symbol: symbol, Date: date, Err: rejection}
+				}
+				continue
+			}
+			previousBars[symbol] = bar
+			bars[symbol] = bar
+		}
+
+		for _, subscriber := range u.dateSubscribers {
+			subscriber.ReceiveUniverseDate(date, bars)
+		}
+	}
+
+	for symbol := range u.pending {
+		delete(u.pending, symbol)
+	}
+	return err
+}
+
+// nextDate returns the earliest date of the symbols' next buffered bars
+func (u *Universe) nextDate(cursors map[string]int) (date time.Time, ok bool) {
+	for _, symbol := range u.symbols {
+		pending := u.pending[symbol]
+		if cursors[symbol] < len(pending) {
+			barDate := pending[cursors[symbol]].D()
+			if !ok || barDate.Before(date) {
+				date = barDate
+				ok = true
+			}
+		}
+	}
+	return date, ok
+}
+
+// barForDate returns the symbol's bar for the date, consuming it, or the filled bar if the symbol has none.
+// Once the symbol's buffered bars are used up, e.g. after it was delisted, no bars are filled.
+func (u *Universe) barForDate(symbol string, date time.Time, cursors map[string]int, previousBars map[string]DOHLCV) (bar DOHLCV, ok bool) {
+	pending := u.pending[symbol]
+	if cursors[symbol] >= len(pending) {
+		return nil, false
+	}
+
+	if pending[cursors[symbol]].D().Equal(date) {
+		cursors[symbol]++
+		return pending[cursors[symbol]-1], true
+	}
+
+	previous, ok := previousBars[symbol]
+	if !ok {
+		return nil, false
+	}
+
+	switch u.fillPolicy {
+	case FillForwardMissingBars:
+		return NewDOHLCVDataItem(date, previous.C(), previous.C(), previous.C(), previous.C(), 0.0), true
+	case RepeatPreviousMissingBars:
+		return NewDOHLCVDataItem(date, previous.O(), previous.H(), previous.L(), previous.C(), previous.V()), true
+	}
+	return nil, false
+}
+
+type universeSymbolFeed struct {
+	universe *Universe
+	symbol   string
+}
+
+func (f *universeSymbolFeed) ReceiveTick(tickData DOHLCV) {
+	f.ValidateAndReceiveTick(tickData)
+}
+
+// ValidateAndReceiveTick inserts the bar into the symbol's buffer in date order, rejecting a bar with the same
+// date as a buffered bar
+func (f *universeSymbolFeed) ValidateAndReceiveTick(tickData DOHLCV) error {
+	pending := f.universe.pending[f.symbol]
+	i := sort.Search(len(pending), func(i int) bool {
+		return !pending[i].D().Before(tickData.D())
+	})
+	if i < len(pending) && pending[i].D().Equal(tickData.D()) {
+		return ErrDuplicateSymbolBar
+	}
+
+	pending = append(pending, nil)
+	copy(pending[i+1:], pending[i:])
+	pending[i] = tickData
+	f.universe.pending[f.symbol] = pending
+	return nil
+}
@@ -0,0 +1,194 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+type universeDateRecorder struct {
+	dates       []time.Time
+	streamSizes []int
+	universe    *gotrade.Universe
+}
+
+// ReceiveUniverseDate records the date and the total number of bars held by the universe's streams
+func (r *universeDateRecorder) ReceiveUniverseDate(date time.Time, bars map[string]gotrade.DOHLCV) {
+	r.dates = append(r.dates, date)
+	size := 0
+	for _, symbol := range r.universe.Symbols() {
+		stream, _ := r.universe.Stream(symbol)
+		size += len(stream.Data)
+	}
+	r.streamSizes = append(r.streamSizes, size)
+}
+
+var _ = Describe("when replaying a universe of symbols", func() {
+	var (
+		universe *gotrade.Universe
+		recorder *universeDateRecorder
+		abc      *gotrade.InterDayDOHLCVStream
+		xyz      *gotrade.InterDayDOHLCVStream
+	)
+
+	BeforeEach(func() {
+		universe = gotrade.NewDailyUniverse()
+		abc, _ = universe.AddSymbol("ABC")
+		xyz, _ = universe.AddSymbol("XYZ")
+		recorder = &universeDateRecorder{universe: universe}
+		universe.AddDateSubscription(recorder)
+
+		abcFeed, _ := universe.SymbolFeed("ABC")
+		abcFeed.ReceiveTick(newDatedTick(1, 10.0))
+		abcFeed.ReceiveTick(newDatedTick(2, 11.0))
+		abcFeed.ReceiveTick(newDatedTick(4, 12.0))
+		xyzFeed, _ := universe.SymbolFeed("XYZ")
+		xyzFeed.ReceiveTick(newDatedTick(2, 20.0))
+		xyzFeed.ReceiveTick(newDatedTick(3, 21.0))
+		xyzFeed.ReceiveTick(newDatedTick(4, 22.0))
+	})
+
+	It("should reject a symbol which is already in the universe", func() {
+		_, err := universe.AddSymbol("ABC")
+		Expect(err).To(Equal(gotrade.ErrSymbolAlreadyInUniverse))
+	})
+
+	It("should not feed a symbol which is not in the universe", func() {
+		_, err := universe.SymbolFeed("DEF")
+		Expect(err).To(Equal(gotrade.ErrSymbolNotInUniverse))
+	})
+
+	Context("and missing bars are skipped", func() {
+		BeforeEach(func() {
+			universe.Replay()
+		})
+
+		It("should replay every date in order", func() {
+			Expect(recorder.dates).To(Equal([]time.Time{newDatedTick(1, 0.0).D(), newDatedTick(2, 0.0).D(), newDatedTick(3, 0.0).D(), newDatedTick(4, 0.0).D()}))
+		})
+
+		It("every symbol should have received its bar for a date before the date is published", func() {
+			Expect(recorder.streamSizes).To(Equal([]int{1, 3, 4, 6}))
+		})
+
+		It("the streams should only hold the bars they were fed", func() {
+			Expect(len(abc.Data)).To(Equal(3))
+			Expect(len(xyz.Data)).To(Equal(3))
+		})
+	})
+
+	Context("and missing bars are filled forward", func() {
+		BeforeEach(func() {
+			universe.SetMissingBarFillPolicy(gotrade.FillForwardMissingBars)
+			universe.Replay()
+		})
+
+		It("should fill the missing bar with a flat bar at the previous close", func() {
+			Expect(len(abc.Data)).To(Equal(4))
+			Expect(abc.Data[2].D()).To(Equal(newDatedTick(3, 0.0).D()))
+			Expect(abc.Data[2].O()).To(Equal(11.0))
+			Expect(abc.Data[2].H()).To(Equal(11.0))
+			Expect(abc.Data[2].V()).To(Equal(0.0))
+		})
+
+		It("should not fill the bars before the symbol's first bar", func() {
+			Expect(len(xyz.Data)).To(Equal(3))
+		})
+	})
+
+	Context("and missing bars are filled forward after a symbol's last bar", func() {
+		BeforeEach(func() {
+			universe.SetMissingBarFillPolicy(gotrade.FillForwardMissingBars)
+			abcFeed, _ := universe.SymbolFeed("ABC")
+			abcFeed.ReceiveTick(newDatedTick(6, 13.0))
+			universe.Replay()
+		})
+
+		It("should not fill the bars of a symbol whose bars are used up", func() {
+			Expect(len(abc.Data)).To(Equal(5))
+			Expect(len(xyz.Data)).To(Equal(3))
+			Expect(xyz.Data[2].D()).To(Equal(newDatedTick(4, 0.0).D()))
+		})
+	})
+
+	Context("and missing bars repeat the previous bar", func() {
+		BeforeEach(func() {
+			universe.SetMissingBarFillPolicy(gotrade.RepeatPreviousMissingBars)
+			universe.Replay()
+		})
+
+		It("should fill the missing bar with the previous bar", func() {
+			Expect(abc.Data[2].D()).To(Equal(newDatedTick(3, 0.0).D()))
+			Expect(abc.Data[2].C()).To(Equal(abc.Data[1].C()))
+			Expect(abc.Data[2].V()).To(Equal(abc.Data[1].V()))
+		})
+	})
+
+	Context("and a symbol is fed its bars out of order", func() {
+		BeforeEach(func() {
+			abcFeed, _ := universe.SymbolFeed("ABC")
+			abcFeed.ReceiveTick(newDatedTick(3, 13.0))
+			universe.Replay()
+		})
+
+		It("should replay the symbol's bars in date order", func() {
+			Expect(len(abc.Data)).To(Equal(4))
+			Expect(abc.Data[2].D()).To(Equal(newDatedTick(3, 0.0).D()))
+			Expect(abc.Data[2].C()).To(Equal(13.0))
+			Expect(abc.Data[3].C()).To(Equal(12.0))
+		})
+	})
+
+	Context("and a symbol is fed a bar with the same date as a buffered bar", func() {
+		var (
+			err error
+		)
+
+		BeforeEach(func() {
+			abcFeed, _ := universe.SymbolFeed("ABC")
+			err = abcFeed.(gotrade.DOHLCVStreamValidatingTickReceiver).ValidateAndReceiveTick(newDatedTick(2, 13.0))
+			abcFeed.ReceiveTick(newDatedTick(4, 14.0))
+			universe.Replay()
+		})
+
+		It("should reject the duplicate bar", func() {
+			Expect(err).To(Equal(gotrade.ErrDuplicateSymbolBar))
+		})
+
+		It("should replay the bars first fed for the dates", func() {
+			Expect(len(abc.Data)).To(Equal(3))
+			Expect(abc.Data[1].C()).To(Equal(11.0))
+			Expect(abc.Data[2].C()).To(Equal(12.0))
+		})
+	})
+
+	Context("and a symbol's stream rejects a bar", func() {
+		var (
+			err error
+		)
+
+		BeforeEach(func() {
+			xyz.SetBarValidationPolicy(gotrade.RejectInvalidBars)
+			xyzFeed, _ := universe.SymbolFeed("XYZ")
+			xyzFeed.ReceiveTick(gotrade.NewDOHLCVDataItem(newDatedTick(5, 0.0).D(), 23.0, 22.0, 21.0, 23.0, 100.0))
+			abcFeed, _ := universe.SymbolFeed("ABC")
+			abcFeed.ReceiveTick(newDatedTick(5, 13.0))
+			err = universe.Replay()
+		})
+
+		It("should report the rejected bar", func() {
+			Expect(err).To(BeAssignableToTypeOf(&gotrade.UniverseReplayError{}))
+			replayErr := err.(*gotrade.UniverseReplayError)
+			Expect(replayErr.Symbol).To(Equal("XYZ"))
+			Expect(replayErr.Date).To(Equal(newDatedTick(5, 0.0).D()))
+			Expect(replayErr.Err).To(Equal(gotrade.ErrInconsistentBar))
+		})
+
+		It("should replay the other bars", func() {
+			Expect(len(abc.Data)).To(Equal(4))
+			Expect(len(xyz.Data)).To(Equal(3))
+			Expect(recorder.dates).To(HaveLen(5))
+		})
+	})
+})